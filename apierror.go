@@ -0,0 +1,103 @@
+package klaviyo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalJSON decodes an error object of an error response. Klaviyo occasionally returns error objects
+// with unexpected shapes, e.g. the status as a string or missing fields, so the decoding never fails:
+// numbers and strings are accepted for every field, values of other types are kept as raw JSON text,
+// and a value that isn't an object becomes the detail of the error.
+func (e *APIError) UnmarshalJSON(data []byte) error {
+	*e = APIError{}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		e.Detail = jsonText(data)
+		return nil
+	}
+
+	e.Id = jsonText(fields["id"])
+	e.Status = jsonInt(fields["status"])
+	e.Code = jsonText(fields["code"])
+	e.Title = jsonText(fields["title"])
+	e.Detail = jsonText(fields["detail"])
+
+	var source map[string]json.RawMessage
+	if json.Unmarshal(fields["source"], &source) == nil {
+		e.Source.Pointer = jsonText(source["pointer"])
+		e.Source.Parameter = jsonText(source["parameter"])
+	}
+	var meta map[string]json.RawMessage
+	if json.Unmarshal(fields["meta"], &meta) == nil {
+		e.Meta.DuplicateProfileID = jsonText(meta["duplicate_profile_id"])
+	}
+	return nil
+}
+
+// apiErrors holds the errors of an error response. It accepts a single error object as well as an array of them.
+type apiErrors []*APIError
+
+// UnmarshalJSON decodes the errors of an error response, skipping null errors.
+func (a *apiErrors) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		raw = []json.RawMessage{data}
+	}
+
+	errs := make(apiErrors, 0, len(raw))
+	for _, r := range raw {
+		if isJSONNull(r) {
+			continue
+		}
+		e := &APIError{}
+		_ = e.UnmarshalJSON(r)
+		errs = append(errs, e)
+	}
+	*a = errs
+	return nil
+}
+
+// jsonText returns the JSON value as text: strings are unquoted, null and missing values are empty,
+// and other values are returned as compact JSON.
+func jsonText(data json.RawMessage) string {
+	if isJSONNull(data) {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if json.Compact(&buf, data) == nil {
+		return buf.String()
+	}
+	return string(data)
+}
+
+// jsonInt returns the JSON number, or the number in the JSON string, as an integer.
+// It returns zero if the value isn't an integer.
+func jsonInt(data json.RawMessage) int {
+	var n json.Number
+	if json.Unmarshal(data, &n) != nil {
+		var s string
+		if json.Unmarshal(data, &s) != nil {
+			return 0
+		}
+		n = json.Number(strings.TrimSpace(s))
+	}
+	i, err := strconv.Atoi(n.String())
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+// isJSONNull reports whether the JSON value is null or missing.
+func isJSONNull(data json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
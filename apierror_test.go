@@ -0,0 +1,142 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+var apiErrorSeeds = []string{
+	`{"id":"e1","status":400,"code":"invalid","title":"Invalid input.","detail":"bad filter","source":{"pointer":"/data"}}`,
+	`{"id":"e1","status":"400","code":"invalid"}`,
+	`{"status":" 409 ","code":"duplicate_profile","meta":{"duplicate_profile_id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ"}}`,
+	`{"status":null,"detail":{"reason":"nested"},"source":"body","meta":[]}`,
+	`{}`,
+	`"Internal server error"`,
+	`null`,
+	`[1,2]`,
+	`{"status":4e2}`,
+}
+
+func TestAPIError_UnmarshalJSON(t *testing.T) {
+	decode := func(s string) *klaviyo.APIError {
+		var e klaviyo.APIError
+		require.NoError(t, json.Unmarshal([]byte(s), &e))
+		return &e
+	}
+
+	e := decode(apiErrorSeeds[0])
+	require.Equal(t, 400, e.Status)
+	require.Equal(t, "invalid", e.Code)
+	require.Equal(t, "/data", e.Source.Pointer)
+
+	require.Equal(t, 400, decode(apiErrorSeeds[1]).Status)
+
+	e = decode(apiErrorSeeds[2])
+	require.Equal(t, 409, e.Status)
+	require.Equal(t, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", e.Meta.DuplicateProfileID)
+
+	e = decode(apiErrorSeeds[3])
+	require.Zero(t, e.Status)
+	require.Equal(t, `{"reason":"nested"}`, e.Detail)
+	require.Empty(t, e.Source.Pointer)
+
+	require.Equal(t, "Internal server error", decode(apiErrorSeeds[5]).Detail)
+}
+
+func TestClient_UnexpectedErrorShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		detail string
+	}{
+		{"single error object", `{"errors":{"status":"422","detail":"invalid profile"}}`, "invalid profile"},
+		{"error message string", `{"errors":"invalid profile"}`, "invalid profile"},
+		{"null errors are skipped", `{"errors":[null,{"detail":"invalid profile"}]}`, "invalid profile"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(http.StatusUnprocessableEntity, tt.body), nil
+			})}
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			_, err := kc.GetProfiles(context.TODO())
+
+			var apiErr *klaviyo.APIError
+			require.True(t, errors.As(err, &apiErr), "%T %v", err, err)
+			require.Equal(t, http.StatusUnprocessableEntity, apiErr.Status)
+			require.Equal(t, tt.detail, apiErr.Detail)
+		})
+	}
+}
+
+func FuzzAPIError_UnmarshalJSON(f *testing.F) {
+	for _, s := range apiErrorSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !json.Valid([]byte(s)) {
+			return
+		}
+		var e klaviyo.APIError
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			t.Fatalf("decoding %q failed: %v", s, err)
+		}
+		var errs struct {
+			Errors []klaviyo.APIError `json:"errors"`
+		}
+		_ = json.Unmarshal([]byte(`{"errors":[`+s+`]}`), &errs)
+	})
+}
+
+func FuzzClient_ErrorResponse(f *testing.F) {
+	for _, s := range apiErrorSeeds {
+		f.Add(`{"errors":[` + s + `]}`)
+		f.Add(`{"errors":` + s + `}`)
+	}
+	f.Add(`<html>Bad Request</html>`)
+	f.Add(``)
+	f.Fuzz(func(t *testing.T, body string) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusBadRequest, body), nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.NewNop(), c)
+
+		_, err := kc.GetProfiles(context.TODO())
+		if err == nil {
+			t.Fatalf("no error for body %q", body)
+		}
+
+		// the error always carries the status of the failed response
+		var (
+			apiErr         *klaviyo.APIError
+			badResponseErr *klaviyo.BadHTTPResponseError
+		)
+		switch {
+		case errors.As(err, &apiErr):
+			if apiErr.Status == 0 {
+				t.Fatalf("no status in %v for body %q", err, body)
+			}
+		case errors.As(err, &badResponseErr):
+			if badResponseErr.StatusCode() != http.StatusBadRequest {
+				t.Fatalf("wrong status in %v for body %q", err, body)
+			}
+		case errors.Is(err, klaviyo.ErrProfileDoesNotExist), errors.Is(err, klaviyo.ErrInvalidAPIKey):
+			// the body claims a different status
+		default:
+			var scopeErr *klaviyo.ErrMissingScope
+			var dupErr *klaviyo.ErrProfileAlreadyExists
+			if !errors.As(err, &scopeErr) && !errors.As(err, &dupErr) {
+				t.Fatalf("unexpected error %T %v for body %q", err, err, body)
+			}
+		}
+	})
+}
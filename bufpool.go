@@ -0,0 +1,44 @@
+package klaviyo
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBufferSize is the maximum capacity of a buffer returned to the pool. Larger buffers only come
+// from requests exceeding the API limits, so they are left to the garbage collector instead of being kept alive.
+const maxPooledBufferSize = maxBulkImportPayloadSize
+
+// bufferPool holds the buffers request bodies are encoded into.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns the buffer to the pool. The buffer and its contents must not be used afterwards.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// encodeJSON encodes the value as JSON into a buffer from the pool, like json.Marshal. The caller returns
+// the buffer to the pool with putBuffer once it's done with it.
+func encodeJSON(v interface{}) (*bytes.Buffer, error) {
+	buf := getBuffer()
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+	// drop the newline terminating the value
+	buf.Truncate(buf.Len() - 1)
+	return buf, nil
+}
@@ -0,0 +1,205 @@
+package klaviyo
+
+import (
+	"fmt"
+)
+
+// DuplicateIdentifiers defines how bulk imports treat profile updates sharing an identifier. Klaviyo processes
+// the profiles of a bulk import job in no particular order, so the result of such updates is nondeterministic.
+type DuplicateIdentifiers int
+
+const (
+	// DuplicateIdentifiersError fails the bulk import with ErrDuplicateIdentifier before any job is created.
+	DuplicateIdentifiersError DuplicateIdentifiers = iota
+	// DuplicateIdentifiersMerge merges the updates sharing an identifier into a single profile, in the order
+	// of the updates: attributes and properties set by later updates override those set by earlier ones.
+	DuplicateIdentifiersMerge
+	// DuplicateIdentifiersLastWins keeps only the last of the updates sharing an identifier.
+	DuplicateIdentifiersLastWins
+)
+
+// ErrDuplicateIdentifier indicates that two profile updates of a bulk import share an identifier,
+// so the result of the import depends on the order in which Klaviyo processes them.
+type ErrDuplicateIdentifier struct {
+	// Index is the index of the update sharing the identifier with an earlier update.
+	Index int
+	// FirstIndex is the index of the earlier update with the identifier.
+	FirstIndex int
+	// Identifier is the shared identifier, e.g. "email", or "id" for the profile ID.
+	Identifier string
+	Value      string
+}
+
+// Error returns a string representation of the ErrDuplicateIdentifier error.
+// It conforms to the error interface.
+func (e *ErrDuplicateIdentifier) Error() string {
+	return fmt.Sprintf("klaviyo: profile update %d has the same %s %q as profile update %d", e.Index, e.Identifier, e.Value, e.FirstIndex)
+}
+
+// identifierKey is an identifier of a bulk profile with its value.
+type identifierKey struct {
+	identifier, value string
+}
+
+// bulkProfileIdentifiers returns the ID, email, phone number and external ID of the bulk profile that are set.
+func bulkProfileIdentifiers(p map[string]interface{}) []identifierKey {
+	attributes, _ := p["attributes"].(map[string]interface{})
+
+	var keys []identifierKey
+	for _, name := range append([]string{"id"}, profileIdentifiers...) {
+		v, ok := p[name]
+		if name != "id" {
+			v, ok = attributes[name]
+		}
+		if !ok || v == nil || v == "" {
+			continue
+		}
+		keys = append(keys, identifierKey{identifier: name, value: fmt.Sprint(v)})
+	}
+	return keys
+}
+
+// duplicateIdentifiers returns the bulk profiles sharing their ID, email, phone number or external ID
+// with an earlier profile. The indexes are the indexes of the profile updates of the bulk profiles.
+func duplicateIdentifiers(indexes []int, profiles []map[string]interface{}) []*ErrDuplicateIdentifier {
+	first := make(map[identifierKey]int)
+
+	var dups []*ErrDuplicateIdentifier
+	for i, p := range profiles {
+		for _, k := range bulkProfileIdentifiers(p) {
+			if j, ok := first[k]; ok {
+				dups = append(dups, &ErrDuplicateIdentifier{Index: indexes[i], FirstIndex: j, Identifier: k.identifier, Value: k.value})
+				continue
+			}
+			first[k] = indexes[i]
+		}
+	}
+	return dups
+}
+
+// dedupeBulkProfiles applies the duplicate identifiers policy of the client to the bulk profiles. The indexes are
+// the indexes of the profile updates of the bulk profiles. In the error mode, the profiles are returned unchanged
+// together with the duplicates found; otherwise, the profiles sharing an identifier are resolved into one, whose
+// index is the index of the first merged update or of the kept update.
+func (c *Client) dedupeBulkProfiles(indexes []int, profiles []map[string]interface{}) ([]int, []map[string]interface{}, []*ErrDuplicateIdentifier) {
+	mode := c.options.duplicateIdentifiers
+	if mode == DuplicateIdentifiersError {
+		return indexes, profiles, duplicateIdentifiers(indexes, profiles)
+	}
+
+	groups := duplicateGroups(profiles)
+	if len(groups) == len(profiles) {
+		return indexes, profiles, nil
+	}
+
+	var (
+		resolved        = make([]map[string]interface{}, 0, len(groups))
+		resolvedIndexes = make([]int, 0, len(groups))
+	)
+	for _, g := range groups {
+		switch {
+		case len(g) == 1:
+			resolved = append(resolved, profiles[g[0]])
+			resolvedIndexes = append(resolvedIndexes, indexes[g[0]])
+		case mode == DuplicateIdentifiersMerge:
+			group := make([]map[string]interface{}, 0, len(g))
+			for _, i := range g {
+				group = append(group, profiles[i])
+			}
+			resolved = append(resolved, mergeBulkProfiles(group))
+			resolvedIndexes = append(resolvedIndexes, indexes[g[0]])
+		default:
+			resolved = append(resolved, profiles[g[len(g)-1]])
+			resolvedIndexes = append(resolvedIndexes, indexes[g[len(g)-1]])
+		}
+	}
+
+	c.logger.Warn("profile updates with duplicate identifiers resolved",
+		LogFieldOperation, OperationCreateBulkImportJob,
+		"updates", len(profiles),
+		"profiles", len(resolved),
+	)
+	return resolvedIndexes, resolved, nil
+}
+
+// duplicateGroups groups the positions of the bulk profiles connected by shared identifiers, directly or through
+// other profiles. The groups are ordered by their first position, and the positions in a group are ascending.
+func duplicateGroups(profiles []map[string]interface{}) [][]int {
+	parent := make([]int, len(profiles))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+
+	first := make(map[identifierKey]int)
+	for i, p := range profiles {
+		for _, k := range bulkProfileIdentifiers(p) {
+			j, ok := first[k]
+			if !ok {
+				first[k] = i
+				continue
+			}
+			// the smaller position is the root, so the root of a group is its first position
+			ri, rj := root(i), root(j)
+			if ri > rj {
+				ri, rj = rj, ri
+			}
+			parent[rj] = ri
+		}
+	}
+
+	var (
+		groups  [][]int
+		groupOf = make(map[int]int)
+	)
+	for i := range profiles {
+		r := root(i)
+		g, ok := groupOf[r]
+		if !ok {
+			g = len(groups)
+			groupOf[r] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// mergeBulkProfiles merges the bulk profiles in order: the ID, attributes and properties of later profiles
+// override those of earlier ones.
+func mergeBulkProfiles(profiles []map[string]interface{}) map[string]interface{} {
+	var (
+		merged     = map[string]interface{}{"type": profileType}
+		attributes = map[string]interface{}{}
+		properties map[string]interface{}
+	)
+	for _, p := range profiles {
+		if id, ok := p["id"]; ok {
+			merged["id"] = id
+		}
+		attrs, _ := p["attributes"].(map[string]interface{})
+		for name, v := range attrs {
+			if ps, ok := v.(map[string]interface{}); ok && name == "properties" {
+				if properties == nil {
+					properties = make(map[string]interface{}, len(ps))
+				}
+				for k, pv := range ps {
+					properties[k] = pv
+				}
+				continue
+			}
+			attributes[name] = v
+		}
+	}
+	if properties != nil {
+		attributes["properties"] = properties
+	}
+	merged["attributes"] = attributes
+	return merged
+}
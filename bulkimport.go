@@ -0,0 +1,307 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/monetha/go-klaviyo/models/bulkimport"
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+	"github.com/monetha/go-klaviyo/operations/getbulkimportjobs"
+)
+
+const (
+	bulkImportJobsPath = "profile-bulk-import-jobs"
+	bulkImportJobType  = "profile-bulk-import-job"
+
+	// maxBulkImportProfiles is the maximum number of profiles in a single bulk import job.
+	maxBulkImportProfiles = 10000
+)
+
+// ProfileUpdate is an update of a single profile submitted by BulkUpdateProfiles. The profile is identified
+// by ProfileID or, if it is empty, by the email, phone number or external ID set by the updaters.
+type ProfileUpdate struct {
+	ProfileID string
+	Updaters  []updater.Profile
+}
+
+// ErrBulkUnsupportedUpdate indicates that a profile update can't be performed by a bulk import job,
+// e.g. because it unsets, appends or unappends properties, which bulk imports don't support.
+type ErrBulkUnsupportedUpdate struct {
+	// Index is the index of the update in the submitted updates.
+	Index  int
+	Reason string
+}
+
+// Error returns a string representation of the ErrBulkUnsupportedUpdate error.
+// It conforms to the error interface.
+func (e *ErrBulkUnsupportedUpdate) Error() string {
+	return fmt.Sprintf("klaviyo: profile update %d can't be imported in bulk: %s", e.Index, e.Reason)
+}
+
+// BulkUpdateProfiles submits the profile updates as bulk import jobs, so mass attribute updates don't
+// require a PATCH request per profile. Only the attributes set by the updaters are sent; the other
+// attributes of the profiles are kept. Updates without a profile ID, email, phone number or external ID fail with
+// ErrBulkUnsupportedUpdate. Updates are split into jobs of at most 10,000 profiles and 5 MB.
+// Updates sharing an identifier fail with ErrDuplicateIdentifier unless WithDuplicateIdentifiers
+// sets a policy resolving them.
+// Profiles exceeding the maximum size of a bulk import profile fail with ErrBulkProfileTooLarge unless
+// WithOversizedBulkProfiles enables splitting them; the split properties are set by follow-up profile updates
+// once all the jobs are created, and a failed follow-up update is reported by a *PartialError.
+//
+// The jobs are processed asynchronously; the returned jobs can be tracked with GetBulkImportJobs.
+// All the updates are checked before any job is created; if a job can't be created after some were,
+// a *PartialError is returned together with the jobs created so far.
+func (c *Client) BulkUpdateProfiles(ctx context.Context, updates []ProfileUpdate) ([]*bulkimport.ExistingJob, error) {
+	var (
+		profiles = make([]map[string]interface{}, 0, len(updates))
+		indexes  = make([]int, 0, len(updates))
+	)
+	for i, u := range updates {
+		data, err := c.bulkProfileData(i, u)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkBulkIdentifier(i, u.ProfileID, data); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, newBulkProfile(u.ProfileID, data))
+		indexes = append(indexes, i)
+	}
+
+	indexes, profiles, dups := c.dedupeBulkProfiles(indexes, profiles)
+	if len(dups) > 0 {
+		return nil, dups[0]
+	}
+
+	var followUps []bulkFollowUp
+	for i, p := range profiles {
+		fs, err := c.splitBulkProfile(indexes[i], p)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.validateProperties(OperationCreateBulkImportJob, bulkProperties(p)); err != nil {
+			return nil, err
+		}
+		for _, f := range fs {
+			if err := c.validateProperties(OperationCreateBulkImportJob, f.properties()); err != nil {
+				return nil, err
+			}
+		}
+		followUps = append(followUps, fs...)
+	}
+
+	chunks, err := bulkImportJobs(profiles)
+	if err != nil {
+		return nil, err
+	}
+	for i, chunk := range chunks {
+		if chunk.size > maxBulkImportPayloadSize {
+			return nil, &ErrBulkImportTooLarge{Job: i, Size: chunk.size, Max: maxBulkImportPayloadSize}
+		}
+	}
+
+	var (
+		jobs  []*bulkimport.ExistingJob
+		steps []StepResult
+	)
+	for _, chunk := range chunks {
+		name := fmt.Sprintf("import profiles %d-%d", chunk.start, chunk.end-1)
+		job, err := c.createBulkImportJob(ctx, profiles[chunk.start:chunk.end])
+		if err != nil {
+			if len(jobs) == 0 {
+				return nil, err
+			}
+			steps = append(steps, StepResult{Name: name, Err: err, Retryable: isWriteRetryable(err)})
+			return jobs, &PartialError{Operation: "bulk update profiles", Steps: steps}
+		}
+		jobs = append(jobs, job)
+		steps = append(steps, StepResult{Name: name, Done: true})
+	}
+
+	for _, f := range followUps {
+		name := fmt.Sprintf("update split properties of profile update %d", f.index)
+		if err := c.sendBulkFollowUp(ctx, f); err != nil {
+			steps = append(steps, StepResult{Name: name, Err: err, Retryable: true})
+			return jobs, &PartialError{Operation: "bulk update profiles", Steps: steps}
+		}
+		steps = append(steps, StepResult{Name: name, Done: true})
+	}
+
+	return jobs, nil
+}
+
+// checkBulkIdentifier returns ErrBulkUnsupportedUpdate if the profile update has neither a profile ID
+// nor an email, phone number or external ID identifying the profile.
+func checkBulkIdentifier(index int, profileID string, data *updater.ProfileData) error {
+	if profileID == "" && !hasProfileIdentifier(data.Attributes) {
+		return &ErrBulkUnsupportedUpdate{Index: index, Reason: "the profile has no ID, email, phone number or external ID"}
+	}
+	return nil
+}
+
+// bulkProperties returns the properties of the bulk profile.
+func bulkProperties(p map[string]interface{}) map[string]interface{} {
+	attributes, _ := p["attributes"].(map[string]interface{})
+	properties, _ := attributes["properties"].(map[string]interface{})
+	return properties
+}
+
+// bulkProfileData applies the updaters of the profile update and prepares the profile data for a bulk import job,
+// without validating the property values.
+func (c *Client) bulkProfileData(index int, u ProfileUpdate) (*updater.ProfileData, error) {
+	data := updater.NewProfileData()
+	for _, up := range u.Updaters {
+		up.Apply(data)
+	}
+	if len(data.PropertiesToRemove) > 0 || len(data.PropertiesToAppend) > 0 || len(data.PropertiesToUnappend) > 0 {
+		return nil, &ErrBulkUnsupportedUpdate{Index: index, Reason: "unset, append and unappend operations are not supported"}
+	}
+	if err := c.prepareProfileAttributes(OperationCreateBulkImportJob, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// newBulkProfile returns the profile of a bulk import job with the given ID, if any, and the prepared profile data.
+func newBulkProfile(profileID string, data *updater.ProfileData) map[string]interface{} {
+	p := map[string]interface{}{
+		"type":       profileType,
+		"attributes": data.Attributes,
+	}
+	if profileID != "" {
+		p["id"] = profileID
+	}
+	return p
+}
+
+// createBulkImportJob creates a bulk import job of the profiles.
+func (c *Client) createBulkImportJob(ctx context.Context, profiles []map[string]interface{}) (*bulkimport.ExistingJob, error) {
+	request := bulkImportJobRequest(profiles)
+
+	var result struct {
+		Data bulkimport.ExistingJob `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationCreateBulkImportJob, http.MethodPost, bulkImportJobsPath, nil, request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// bulkImportJobRequest returns the request creating a bulk import job of the profiles.
+func bulkImportJobRequest(profiles []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": bulkImportJobType,
+			"attributes": map[string]interface{}{
+				"profiles": map[string]interface{}{
+					"data": profiles,
+				},
+			},
+		},
+	}
+}
+
+// GetBulkImportJobs retrieves a page of profile bulk import jobs of the account, including the jobs
+// submitted by other services. Use NewBulkImportJobsPaginator to iterate over all the jobs.
+func (c *Client) GetBulkImportJobs(ctx context.Context, params ...getbulkimportjobs.Param) ([]*bulkimport.ExistingJob, error) {
+	js, _, err := c.getBulkImportJobsPage(ctx, bulkImportJobsFields(params))
+	if err != nil {
+		return nil, err
+	}
+
+	return js, nil
+}
+
+// GetBulkImportJob retrieves the profile bulk import job with the given ID.
+func (c *Client) GetBulkImportJob(ctx context.Context, jobID string) (*bulkimport.ExistingJob, error) {
+	endpoint := path.Join(bulkImportJobsPath, jobID)
+
+	var result struct {
+		Data bulkimport.ExistingJob `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetBulkImportJob, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// WaitForBulkImportJob polls the profile bulk import job with the given ID until its status is final
+// (complete or cancelled) and returns the job. The polls are spaced like by WaitForCampaignSendJob;
+// WithWaitProgress reports every polled job together with its completed, failed and total counts.
+// It returns early if the context is done or polling fails, together with the last polled job, if any;
+// if the context is done, the error is an *ErrWaitInterrupted wrapping the error of the context.
+func (c *Client) WaitForBulkImportJob(ctx context.Context, jobID string, interval time.Duration, opts ...WaitOption) (*bulkimport.ExistingJob, error) {
+	opts = append(opts, withWaitCounts(func(v interface{}) (completed, failed, total int) {
+		a := v.(*bulkimport.ExistingJob).Attributes
+		return a.CompletedCount, a.FailedCount, a.TotalCount
+	}))
+	return poll(ctx, c.options.clock, interval, opts, func(ctx context.Context) (*bulkimport.ExistingJob, error) {
+		return c.GetBulkImportJob(ctx, jobID)
+	}, func(job *bulkimport.ExistingJob) bool {
+		return job.Attributes.Status.IsFinal()
+	})
+}
+
+// NewBulkImportJobsPaginator creates a paginator over all the profile bulk import jobs matching the given parameters.
+func (c *Client) NewBulkImportJobsPaginator(params ...getbulkimportjobs.Param) *Paginator[*bulkimport.ExistingJob] {
+	p := newPaginator(c, c.getBulkImportJobsPage, bulkImportJobsFields(params))
+	p.limit(params)
+	return p
+}
+
+func bulkImportJobsFields(params []getbulkimportjobs.Param) url.Values {
+	fields := url.Values{}
+	for _, p := range params {
+		p.Apply(fields)
+	}
+	return fields
+}
+
+// FindStuckBulkImportJobs returns the profile bulk import jobs that are queued or processing and were created
+// more than olderThan ago, oldest first, so that a sync can report them instead of piling new jobs behind them.
+// The API doesn't allow cancelling profile bulk import jobs, so the jobs are only reported.
+func (c *Client) FindStuckBulkImportJobs(ctx context.Context, olderThan time.Duration) ([]*bulkimport.ExistingJob, error) {
+	cutoff := c.options.clock.Now().Add(-olderThan)
+
+	var stuck []*bulkimport.ExistingJob
+	for _, status := range []bulkimport.Status{bulkimport.StatusQueued, bulkimport.StatusProcessing} {
+		paginator := c.NewBulkImportJobsPaginator(getbulkimportjobs.WithStatus(status))
+		for paginator.HasNext() {
+			js, err := paginator.Next(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, j := range js {
+				if createdAt := j.Attributes.CreatedAt; createdAt != nil && createdAt.Before(cutoff) {
+					stuck = append(stuck, j)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(stuck, func(i, j int) bool {
+		return stuck[i].Attributes.CreatedAt.Before(*stuck[j].Attributes.CreatedAt)
+	})
+
+	return stuck, nil
+}
+
+// getBulkImportJobsPage retrieves a single page of bulk import jobs and returns the cursor of the next page, if any.
+func (c *Client) getBulkImportJobsPage(ctx context.Context, fields url.Values) ([]*bulkimport.ExistingJob, string, error) {
+	var result struct {
+		Data  []*bulkimport.ExistingJob `json:"data"`
+		Links links                     `json:"links"`
+	}
+	if err := c.doReq(ctx, OperationGetBulkImportJobs, http.MethodGet, bulkImportJobsPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data, result.Links.nextCursor(), nil
+}
@@ -0,0 +1,506 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/models/bulkimport"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/property"
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+	"github.com/monetha/go-klaviyo/operations/getbulkimportjobs"
+)
+
+func TestClient_GetBulkImportJobs(t *testing.T) {
+	var filters []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profile-bulk-import-jobs", req.URL.Path)
+		filters = append(filters, req.URL.Query().Get("filter"))
+		if req.URL.Query().Get("page[cursor]") == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTE","attributes":{"status":"processing","created_at":"2024-01-30T05:10:00+00:00","total_count":500,"completed_count":120,"failed_count":2,"completed_at":null,"expires_at":"2024-02-06T05:10:00+00:00","started_at":"2024-01-30T05:11:00+00:00"}}],"links":{"next":"https://a.klaviyo.com/api/profile-bulk-import-jobs/?page%5Bcursor%5D=bmV4dA"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTI","attributes":{"status":"processing","created_at":"2024-01-29T05:10:00+00:00","total_count":10,"completed_count":0,"failed_count":0}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	t.Run("get first page", func(t *testing.T) {
+		filters = nil
+
+		js, err := kc.GetBulkImportJobs(ctx, getbulkimportjobs.WithStatus(bulkimport.StatusProcessing))
+
+		require.NoError(t, err)
+		require.Len(t, js, 1)
+		require.Equal(t, "ZXhhbXBsZTE", js[0].ID)
+		require.Equal(t, bulkimport.StatusProcessing, js[0].Attributes.Status)
+		require.Equal(t, 500, js[0].Attributes.TotalCount)
+		require.Equal(t, 120, js[0].Attributes.CompletedCount)
+		require.Nil(t, js[0].Attributes.CompletedAt)
+		require.Equal(t, []string{`equals(status,"processing")`}, filters)
+	})
+
+	t.Run("paginate over all jobs", func(t *testing.T) {
+		filters = nil
+
+		p := kc.NewBulkImportJobsPaginator(getbulkimportjobs.WithStatus(bulkimport.StatusProcessing))
+		var ids []string
+		for p.HasNext() {
+			js, err := p.Next(ctx)
+			require.NoError(t, err)
+			for _, j := range js {
+				ids = append(ids, j.ID)
+			}
+		}
+
+		require.Equal(t, []string{"ZXhhbXBsZTE", "ZXhhbXBsZTI"}, ids)
+		require.Equal(t, []string{`equals(status,"processing")`, `equals(status,"processing")`}, filters)
+	})
+}
+
+func TestClient_FindStuckBulkImportJobs(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Query().Get("filter") {
+		case `equals(status,"queued")`:
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"profile-bulk-import-job","id":"cXVldWVkLW9sZA","attributes":{"status":"queued","created_at":"2024-01-30T01:00:00+00:00"}},{"type":"profile-bulk-import-job","id":"cXVldWVkLW5ldw","attributes":{"status":"queued","created_at":"2024-01-30T11:30:00+00:00"}}],"links":{"next":null}}`), nil
+		case `equals(status,"processing")`:
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"profile-bulk-import-job","id":"cHJvY2Vzc2luZw","attributes":{"status":"processing","created_at":"2024-01-29T22:00:00+00:00"}}],"links":{"next":null}}`), nil
+		}
+		t.Fatalf("unexpected filter %q", req.URL.Query().Get("filter"))
+		return nil, nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+
+	js, err := kc.FindStuckBulkImportJobs(context.TODO(), 6*time.Hour)
+
+	require.NoError(t, err)
+	ids := make([]string, 0, len(js))
+	for _, j := range js {
+		ids = append(ids, j.ID)
+	}
+	require.Equal(t, []string{"cHJvY2Vzc2luZw", "cXVldWVkLW9sZA"}, ids)
+}
+
+func TestClient_BulkUpdateProfiles(t *testing.T) {
+	const jobResponse = `{"data":{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTE","attributes":{"status":"queued","total_count":2}}}`
+
+	t.Run("updates are submitted as a bulk import job", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, "/api/profile-bulk-import-jobs", req.URL.Path)
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, jobResponse), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), []klaviyo.ProfileUpdate{
+			{
+				ProfileID: "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+				Updaters:  []updater.Profile{profile.WithFirstName("Sarah")},
+			},
+			{
+				Updaters: []updater.Profile{
+					profile.WithEmail("john.smith@klaviyo-demo.com"),
+					profile.WithProperties(property.WithValue("tier", "gold")),
+				},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, "ZXhhbXBsZTE", jobs[0].ID)
+		require.JSONEq(t, `{"data":{"type":"profile-bulk-import-job","attributes":{"profiles":{"data":[`+
+			`{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"first_name":"Sarah"}},`+
+			`{"type":"profile","attributes":{"email":"john.smith@klaviyo-demo.com","properties":{"tier":"gold"}}}`+
+			`]}}}}`, body)
+	})
+
+	t.Run("unset properties are not supported", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), []klaviyo.ProfileUpdate{
+			{ProfileID: "01H8HKMDG8F4MN7PSRZ4YQYNVQ", Updaters: []updater.Profile{profile.WithFirstName("Sarah")}},
+			{ProfileID: "01HN6AFEHGF6F77WJRKT1C9JHG", Updaters: []updater.Profile{profile.UnsetProperties("tier")}},
+		})
+
+		var e *klaviyo.ErrBulkUnsupportedUpdate
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, 1, e.Index)
+		require.Nil(t, jobs)
+	})
+
+	t.Run("updates without identifier are rejected", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), []klaviyo.ProfileUpdate{
+			{ProfileID: "01H8HKMDG8F4MN7PSRZ4YQYNVQ", Updaters: []updater.Profile{profile.WithFirstName("Sarah")}},
+			{Updaters: []updater.Profile{profile.WithFirstName("John")}},
+		})
+
+		var e *klaviyo.ErrBulkUnsupportedUpdate
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, 1, e.Index)
+		require.Nil(t, jobs)
+	})
+
+	t.Run("updates are split into jobs by payload size", func(t *testing.T) {
+		var sizes, counts []int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var request struct {
+				Data struct {
+					Attributes struct {
+						Profiles struct {
+							Data []json.RawMessage `json:"data"`
+						} `json:"profiles"`
+					} `json:"attributes"`
+				} `json:"data"`
+			}
+			b, _ := io.ReadAll(req.Body)
+			require.NoError(t, json.Unmarshal(b, &request))
+			sizes = append(sizes, len(b))
+			counts = append(counts, len(request.Data.Attributes.Profiles.Data))
+			return jsonResponse(http.StatusAccepted, jobResponse), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), largeBulkUpdates(6000))
+
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		require.Equal(t, 6000, counts[0]+counts[1])
+		for _, size := range sizes {
+			require.LessOrEqual(t, size, 5<<20)
+		}
+	})
+
+	t.Run("updates are split into several jobs", func(t *testing.T) {
+		var requests int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			if requests == 2 {
+				return jsonResponse(http.StatusBadRequest, `{"errors":[{"status":400,"code":"invalid","title":"Invalid input."}]}`), nil
+			}
+			return jsonResponse(http.StatusAccepted, jobResponse), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		updates := make([]klaviyo.ProfileUpdate, 10001)
+		for i := range updates {
+			updates[i] = klaviyo.ProfileUpdate{Updaters: []updater.Profile{profile.WithExternalId(strconv.Itoa(i))}}
+		}
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), updates)
+
+		var e *klaviyo.PartialError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Steps, 2)
+		require.True(t, e.Steps[0].Done)
+		require.Len(t, e.Failed(), 1)
+		require.Len(t, jobs, 1)
+		require.Equal(t, 2, requests)
+	})
+}
+
+func TestClient_BulkUpdateProfiles_DuplicateIdentifiers(t *testing.T) {
+	const jobResponse = `{"data":{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTE","attributes":{"status":"queued","total_count":2}}}`
+
+	updates := []klaviyo.ProfileUpdate{
+		{Updaters: []updater.Profile{
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithFirstName("Sarah"),
+			profile.WithProperties(property.WithValue("tier", "silver"), property.WithValue("source", "shop")),
+		}},
+		{Updaters: []updater.Profile{profile.WithExternalId("63f64a2b"), profile.WithFirstName("John")}},
+		{Updaters: []updater.Profile{
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithPhoneNumber("+15005550006"),
+			profile.WithProperties(property.WithValue("tier", "gold")),
+		}},
+		{Updaters: []updater.Profile{profile.WithPhoneNumber("+15005550006"), profile.WithLastName("Mason")}},
+	}
+
+	bulkUpdate := func(t *testing.T, opts ...klaviyo.Option) (body string, err error) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, jobResponse), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, opts...)
+		_, err = kc.BulkUpdateProfiles(context.TODO(), updates)
+		return body, err
+	}
+
+	t.Run("duplicates are rejected by default", func(t *testing.T) {
+		body, err := bulkUpdate(t)
+
+		var e *klaviyo.ErrDuplicateIdentifier
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, klaviyo.ErrDuplicateIdentifier{Index: 2, FirstIndex: 0, Identifier: "email", Value: "sarah.mason@klaviyo-demo.com"}, *e)
+		require.Empty(t, body)
+	})
+
+	t.Run("duplicates are merged", func(t *testing.T) {
+		body, err := bulkUpdate(t, klaviyo.WithDuplicateIdentifiers(klaviyo.DuplicateIdentifiersMerge))
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"data":{"type":"profile-bulk-import-job","attributes":{"profiles":{"data":[`+
+			`{"type":"profile","attributes":{"email":"sarah.mason@klaviyo-demo.com","phone_number":"+15005550006","first_name":"Sarah","last_name":"Mason","properties":{"tier":"gold","source":"shop"}}},`+
+			`{"type":"profile","attributes":{"external_id":"63f64a2b","first_name":"John"}}`+
+			`]}}}}`, body)
+	})
+
+	t.Run("last duplicate wins", func(t *testing.T) {
+		body, err := bulkUpdate(t, klaviyo.WithDuplicateIdentifiers(klaviyo.DuplicateIdentifiersLastWins))
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"data":{"type":"profile-bulk-import-job","attributes":{"profiles":{"data":[`+
+			`{"type":"profile","attributes":{"phone_number":"+15005550006","last_name":"Mason"}},`+
+			`{"type":"profile","attributes":{"external_id":"63f64a2b","first_name":"John"}}`+
+			`]}}}}`, body)
+	})
+}
+
+func TestClient_BulkUpdateProfiles_OversizedProfiles(t *testing.T) {
+	const jobResponse = `{"data":{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTE","attributes":{"status":"queued","total_count":2}}}`
+
+	var (
+		notes   = strings.Repeat("n", 61000)
+		history = strings.Repeat("h", 60000)
+	)
+	updates := []klaviyo.ProfileUpdate{
+		{Updaters: []updater.Profile{profile.WithExternalId("63f64a2b"), profile.WithFirstName("John")}},
+		{Updaters: []updater.Profile{
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithProperties(property.WithValue("notes", notes), property.WithValue("history", history), property.WithValue("tier", "gold")),
+		}},
+		{ProfileID: "01H8HKMDG8F4MN7PSRZ4YQYNVQ", Updaters: []updater.Profile{
+			profile.WithProperties(property.WithValue("notes", notes), property.WithValue("history", history)),
+		}},
+	}
+
+	t.Run("oversized profiles are rejected by default", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), updates)
+
+		var e *klaviyo.ErrBulkProfileTooLarge
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, 1, e.Index)
+		require.Equal(t, []string{"notes"}, e.Keys)
+		require.Nil(t, jobs)
+	})
+
+	t.Run("oversized properties are split into follow-up updates", func(t *testing.T) {
+		var requests []string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			requests = append(requests, req.Method+" "+req.URL.Path+" "+string(b))
+			switch req.URL.Path {
+			case "/api/profile-bulk-import-jobs":
+				return jsonResponse(http.StatusAccepted, jobResponse), nil
+			case "/api/profile-import":
+				return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{}}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithOversizedBulkProfiles(klaviyo.OversizedBulkProfilesSplit))
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), updates)
+
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Len(t, requests, 3)
+		require.Equal(t, `POST /api/profile-bulk-import-jobs {"data":{"attributes":{"profiles":{"data":[`+
+			`{"attributes":{"external_id":"63f64a2b","first_name":"John"},"type":"profile"},`+
+			`{"attributes":{"email":"sarah.mason@klaviyo-demo.com","properties":{"history":"`+history+`","tier":"gold"}},"type":"profile"},`+
+			`{"attributes":{"properties":{"history":"`+history+`"}},"id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","type":"profile"}`+
+			`]}},"type":"profile-bulk-import-job"}}`, requests[0])
+		require.Equal(t, `POST /api/profile-import {"data":{"attributes":{"email":"sarah.mason@klaviyo-demo.com","properties":{"notes":"`+notes+`"}},"type":"profile"}}`, requests[1])
+		require.Equal(t, `PATCH /api/profiles/01H8HKMDG8F4MN7PSRZ4YQYNVQ {"data":{"attributes":{"properties":{"notes":"`+notes+`"}},"id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","type":"profile"}}`, requests[2])
+	})
+
+	t.Run("failed follow-up update is reported", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/profile-bulk-import-jobs" {
+				return jsonResponse(http.StatusAccepted, jobResponse), nil
+			}
+			return jsonResponse(http.StatusBadRequest, `{"errors":[{"status":400,"code":"invalid","title":"Invalid input."}]}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithOversizedBulkProfiles(klaviyo.OversizedBulkProfilesSplit))
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), updates)
+
+		var e *klaviyo.PartialError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, jobs, 1)
+		require.Len(t, e.Steps, 2)
+		require.True(t, e.Steps[0].Done)
+		require.Equal(t, "update split properties of profile update 1", e.Steps[1].Name)
+		require.False(t, e.Steps[1].Done)
+	})
+
+	t.Run("property too large for a profile of its own", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{}, klaviyo.WithOversizedBulkProfiles(klaviyo.OversizedBulkProfilesSplit))
+
+		_, err := kc.BulkUpdateProfiles(context.TODO(), []klaviyo.ProfileUpdate{{Updaters: []updater.Profile{
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithProperties(property.WithValue("notes", strings.Repeat("n", 120000)), property.WithValue("tier", "gold")),
+		}}})
+
+		var e *klaviyo.ErrBulkProfileTooLarge
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, 0, e.Index)
+		require.Equal(t, []string{"notes"}, e.Keys)
+	})
+}
+
+func TestClient_ValidateBulkImport(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("request must not be sent")
+		return nil, nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	report := kc.ValidateBulkImport([]klaviyo.ProfileUpdate{
+		{Updaters: []updater.Profile{profile.WithEmail("sarah.mason@klaviyo-demo.com")}},
+		{Updaters: []updater.Profile{profile.WithFirstName("Sarah")}},
+		{ProfileID: "01HN6AFEHGF6F77WJRKT1C9JHG", Updaters: []updater.Profile{profile.UnsetProperties("tier")}},
+		{Updaters: []updater.Profile{
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithProperties(property.WithValue("avatar", []byte("GIF89a")), property.WithValue("visited", time.Now())),
+		}},
+		{Updaters: []updater.Profile{profile.WithExternalId("63f64a2b")}},
+	})
+
+	require.False(t, report.Valid())
+	require.Equal(t, 5, report.Profiles)
+	require.Equal(t, 1, report.Jobs)
+
+	var indexes []int
+	for _, issue := range report.Issues {
+		indexes = append(indexes, issue.Index)
+	}
+	require.Equal(t, []int{1, 2, 3, 3, 3}, indexes)
+
+	var unsupported *klaviyo.ErrBulkUnsupportedUpdate
+	require.True(t, errors.As(report.Issues[0].Err, &unsupported), "no identifier")
+	require.True(t, errors.As(report.Issues[1].Err, &unsupported), "unset properties")
+
+	var invalid *klaviyo.ErrInvalidPropertyValue
+	require.True(t, errors.As(report.Issues[2].Err, &invalid))
+	require.Equal(t, "avatar", invalid.Key)
+	require.True(t, errors.As(report.Issues[3].Err, &invalid))
+	require.Equal(t, "visited", invalid.Key)
+
+	var dup *klaviyo.ErrDuplicateIdentifier
+	require.True(t, errors.As(report.Issues[4].Err, &dup))
+	require.Equal(t, klaviyo.ErrDuplicateIdentifier{Index: 3, FirstIndex: 0, Identifier: "email", Value: "sarah.mason@klaviyo-demo.com"}, *dup)
+}
+
+func TestClient_ValidateBulkImport_PayloadSize(t *testing.T) {
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{})
+
+	report := kc.ValidateBulkImport(largeBulkUpdates(6000))
+	require.True(t, report.Valid())
+	require.Equal(t, 2, report.Jobs)
+}
+
+// largeBulkUpdates returns n profile updates of about 1 KB each.
+func largeBulkUpdates(n int) []klaviyo.ProfileUpdate {
+	bio := strings.Repeat("x", 1000)
+	updates := make([]klaviyo.ProfileUpdate, n)
+	for i := range updates {
+		updates[i] = klaviyo.ProfileUpdate{Updaters: []updater.Profile{
+			profile.WithExternalId(strconv.Itoa(i)),
+			profile.WithProperties(property.WithValue("bio", bio)),
+		}}
+	}
+	return updates
+}
+
+func TestClient_WaitForBulkImportJob(t *testing.T) {
+	counts := [][3]int{{0, 0, 500}, {120, 2, 500}, {498, 2, 500}}
+	var polls int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profile-bulk-import-jobs/ZXhhbXBsZTE", req.URL.Path)
+		status := "processing"
+		if polls == len(counts)-1 {
+			status = "complete"
+		}
+		n := counts[polls]
+		polls++
+		return jsonResponse(http.StatusOK, `{"data":{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTE","attributes":{"status":"`+status+
+			`","completed_count":`+strconv.Itoa(n[0])+`,"failed_count":`+strconv.Itoa(n[1])+`,"total_count":`+strconv.Itoa(n[2])+`}}}`), nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+
+	var progress []klaviyo.WaitProgress
+	type result struct {
+		job *bulkimport.ExistingJob
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// a zero interval is raised to the minimum interval instead of polling in a busy loop
+		job, err := kc.WaitForBulkImportJob(context.TODO(), "ZXhhbXBsZTE", 0,
+			klaviyo.WithWaitProgress(func(_ *bulkimport.ExistingJob, p klaviyo.WaitProgress) {
+				progress = append(progress, p)
+			}),
+		)
+		done <- result{job, err}
+	}()
+
+	for _, d := range []time.Duration{time.Second, 2 * time.Second} {
+		waitForSleeper(t, clk)
+		clk.Advance(d)
+	}
+
+	r := <-done
+	require.NoError(t, r.err)
+	require.Equal(t, bulkimport.StatusComplete, r.job.Attributes.Status)
+	require.Equal(t, []klaviyo.WaitProgress{
+		{Polls: 1, Elapsed: 0, NextPoll: time.Second, Completed: 0, Failed: 0, Total: 500},
+		{Polls: 2, Elapsed: time.Second, NextPoll: 2 * time.Second, Completed: 120, Failed: 2, Total: 500},
+		{Polls: 3, Elapsed: 3 * time.Second, Completed: 498, Failed: 2, Total: 500},
+	}, progress)
+}
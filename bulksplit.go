@@ -0,0 +1,187 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+)
+
+// maxBulkProfileSize is the maximum size of a single profile of a bulk import job.
+const maxBulkProfileSize = 100 << 10
+
+// OversizedBulkProfiles defines how bulk imports treat profiles exceeding the per-profile limits of bulk import jobs.
+type OversizedBulkProfiles int
+
+const (
+	// OversizedBulkProfilesError fails the bulk import with ErrBulkProfileTooLarge before any job is created.
+	OversizedBulkProfilesError OversizedBulkProfiles = iota
+	// OversizedBulkProfilesSplit imports the profile with its core attributes and the properties that fit,
+	// and sets the remaining properties by follow-up profile updates once all the jobs are created.
+	OversizedBulkProfilesSplit
+)
+
+// ErrBulkProfileTooLarge indicates that a profile update exceeds the maximum size of a profile of a bulk import job.
+type ErrBulkProfileTooLarge struct {
+	// Index is the index of the update in the submitted updates.
+	Index int
+	Size  int
+	Max   int
+	// Keys are the properties that don't fit in the profile, largest first. In the split mode, they are
+	// the properties that don't fit even in a follow-up update of their own.
+	Keys []string
+}
+
+// Error returns a string representation of the ErrBulkProfileTooLarge error.
+// It conforms to the error interface.
+func (e *ErrBulkProfileTooLarge) Error() string {
+	return fmt.Sprintf("klaviyo: profile update %d has %d bytes, more than the maximum of %d bytes of a bulk import profile (properties %q)",
+		e.Index, e.Size, e.Max, e.Keys)
+}
+
+// bulkFollowUp is an update of the properties that were split off a bulk profile, sent once the bulk import jobs are created.
+type bulkFollowUp struct {
+	// index is the index of the profile update in the submitted updates.
+	index      int
+	profileID  string
+	attributes map[string]interface{}
+}
+
+// properties returns the properties set by the follow-up update.
+func (f bulkFollowUp) properties() map[string]interface{} {
+	properties, _ := f.attributes["properties"].(map[string]interface{})
+	return properties
+}
+
+// splitBulkProfile checks the bulk profile against the per-profile limits of bulk import jobs. In the split mode,
+// the largest properties are moved out of the profile into follow-up updates until it fits, also if it has more
+// properties than a request allows; otherwise, ErrBulkProfileTooLarge is returned for a profile that is too large.
+func (c *Client) splitBulkProfile(index int, p map[string]interface{}) ([]bulkFollowUp, error) {
+	split := c.options.oversizedBulkProfiles == OversizedBulkProfilesSplit
+	attributes, _ := p["attributes"].(map[string]interface{})
+	properties, _ := attributes["properties"].(map[string]interface{})
+
+	size, err := jsonSize(p)
+	if err != nil {
+		// values that can't be serialized are reported by the property validation or by the request
+		return nil, nil
+	}
+	if size <= maxBulkProfileSize && (!split || len(properties) <= maxProperties) {
+		return nil, nil
+	}
+
+	// the size of a property within the profile, including the separating comma
+	sizes := make(map[string]int, len(properties))
+	keys := make([]string, 0, len(properties))
+	for k, v := range properties {
+		n, err := jsonSize(map[string]interface{}{k: v})
+		if err != nil {
+			return nil, err
+		}
+		sizes[k] = n - 1
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if sizes[keys[i]] != sizes[keys[j]] {
+			return sizes[keys[i]] > sizes[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	remaining, moved := size, 0
+	for moved < len(keys) && (remaining > maxBulkProfileSize || split && len(keys)-moved > maxProperties) {
+		remaining -= sizes[keys[moved]]
+		moved++
+	}
+	offending := keys[:moved]
+	if !split || remaining > maxBulkProfileSize {
+		return nil, &ErrBulkProfileTooLarge{Index: index, Size: size, Max: maxBulkProfileSize, Keys: offending}
+	}
+
+	profileID, _ := p["id"].(string)
+	identifiers := make(map[string]interface{})
+	if profileID == "" {
+		for _, name := range profileIdentifiers {
+			if v, ok := attributes[name]; ok && v != nil && v != "" {
+				identifiers[name] = v
+			}
+		}
+		if len(identifiers) == 0 {
+			return nil, &ErrBulkUnsupportedUpdate{Index: index, Reason: "an oversized profile without an ID, email, phone number or external ID can't be split"}
+		}
+	}
+	overhead, err := jsonSize(map[string]interface{}{"type": profileType, "id": profileID, "attributes": withProperties(identifiers, map[string]interface{}{})})
+	if err != nil {
+		return nil, err
+	}
+
+	var unsplittable []string
+	for _, k := range offending {
+		if overhead+sizes[k] > maxBulkProfileSize {
+			unsplittable = append(unsplittable, k)
+		}
+	}
+	if len(unsplittable) > 0 {
+		return nil, &ErrBulkProfileTooLarge{Index: index, Size: size, Max: maxBulkProfileSize, Keys: unsplittable}
+	}
+
+	var (
+		followUps []bulkFollowUp
+		chunk     map[string]interface{}
+		chunkSize int
+	)
+	for _, k := range offending {
+		if chunk == nil || chunkSize+sizes[k] > maxBulkProfileSize || len(chunk) == maxProperties {
+			chunk = make(map[string]interface{})
+			chunkSize = overhead
+			followUps = append(followUps, bulkFollowUp{index: index, profileID: profileID, attributes: withProperties(identifiers, chunk)})
+		}
+		chunk[k] = properties[k]
+		chunkSize += sizes[k]
+		delete(properties, k)
+	}
+	if len(properties) == 0 {
+		delete(attributes, "properties")
+	}
+
+	c.logger.Warn("oversized bulk import profile split",
+		LogFieldOperation, OperationCreateBulkImportJob,
+		"index", index,
+		"size", size,
+		"properties", offending,
+	)
+	return followUps, nil
+}
+
+// sendBulkFollowUp sets the properties split off a bulk profile, by a PATCH request if the profile ID is known,
+// or by creating or updating the profile matching the identifiers otherwise.
+func (c *Client) sendBulkFollowUp(ctx context.Context, f bulkFollowUp) error {
+	data := &updater.ProfileData{Attributes: f.attributes}
+	if f.profileID != "" {
+		_, err := c.patchProfile(ctx, f.profileID, data)
+		return err
+	}
+	_, err := c.importProfile(ctx, data)
+	return err
+}
+
+// withProperties returns a copy of the attributes with the given properties.
+func withProperties(attributes, properties map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(attributes)+1)
+	for k, v := range attributes {
+		m[k] = v
+	}
+	m["properties"] = properties
+	return m
+}
+
+// jsonSize returns the size of the value serialized as JSON.
+func jsonSize(v interface{}) (int, error) {
+	buf, err := encodeJSON(v)
+	if err != nil {
+		return 0, err
+	}
+	defer putBuffer(buf)
+	return buf.Len(), nil
+}
@@ -0,0 +1,154 @@
+package klaviyo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// maxBulkImportPayloadSize is the maximum size of the request creating a bulk import job.
+const maxBulkImportPayloadSize = 5 << 20
+
+// ErrBulkImportTooLarge indicates that the request creating a bulk import job exceeds the maximum payload size.
+type ErrBulkImportTooLarge struct {
+	// Job is the index of the job of the bulk import.
+	Job  int
+	Size int
+	Max  int
+}
+
+// Error returns a string representation of the ErrBulkImportTooLarge error.
+// It conforms to the error interface.
+func (e *ErrBulkImportTooLarge) Error() string {
+	return fmt.Sprintf("klaviyo: bulk import job %d has %d bytes, more than the maximum of %d bytes", e.Job, e.Size, e.Max)
+}
+
+// BulkImportIssue is a problem of a bulk import found by ValidateBulkImport.
+type BulkImportIssue struct {
+	// Index is the index of the profile update with the problem, or -1 for a problem of a whole job.
+	Index int
+	Err   error
+}
+
+// BulkImportReport is the result of ValidateBulkImport.
+type BulkImportReport struct {
+	// Profiles is the number of validated profile updates.
+	Profiles int
+	// Jobs is the number of bulk import jobs the updates are split into.
+	Jobs int
+	// Issues holds the problems found, ordered by the index of the profile update, followed by the problems of jobs.
+	Issues []BulkImportIssue
+}
+
+// Valid reports whether no problems were found.
+func (r *BulkImportReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// ValidateBulkImport runs all the client-side checks of BulkUpdateProfiles on the profile updates without
+// calling the API, e.g. as a pre-flight check of a marketing data feed in CI. Unlike BulkUpdateProfiles,
+// it doesn't stop at the first problem but reports every problem of every update: unsupported updates,
+// updates without an identifier, invalid attributes and property values (unless the property validation
+// is off), identifiers shared by several updates (unless they are resolved by the duplicate identifiers policy),
+// oversized profiles (unless they can be split), and jobs exceeding the maximum payload size, which only
+// a job of a single profile can once the updates are split into jobs by their size.
+func (c *Client) ValidateBulkImport(updates []ProfileUpdate) *BulkImportReport {
+	report := &BulkImportReport{Profiles: len(updates)}
+	issue := func(index int, err error) {
+		report.Issues = append(report.Issues, BulkImportIssue{Index: index, Err: err})
+	}
+
+	var (
+		profiles = make([]map[string]interface{}, 0, len(updates))
+		indexes  = make([]int, 0, len(updates))
+	)
+	for i, u := range updates {
+		data, err := c.bulkProfileData(i, u)
+		if err != nil {
+			issue(i, err)
+			continue
+		}
+		if err := checkBulkIdentifier(i, u.ProfileID, data); err != nil {
+			issue(i, err)
+		}
+		if c.options.propertyValidation != PropertyValidationOff {
+			properties, _ := data.Attributes["properties"].(map[string]interface{})
+			for _, p := range checkProperties("", properties, 1) {
+				issue(i, p)
+			}
+			for _, l := range checkPropertyLimits(properties) {
+				if l.Limit == PropertyLimitCount && c.options.oversizedBulkProfiles == OversizedBulkProfilesSplit {
+					// the properties over the limit are split off into follow-up updates
+					continue
+				}
+				issue(i, l)
+			}
+		}
+		profiles = append(profiles, newBulkProfile(u.ProfileID, data))
+		indexes = append(indexes, i)
+	}
+
+	indexes, profiles, dups := c.dedupeBulkProfiles(indexes, profiles)
+	for _, dup := range dups {
+		issue(dup.Index, dup)
+	}
+	for i, p := range profiles {
+		if _, err := c.splitBulkProfile(indexes[i], p); err != nil {
+			issue(indexes[i], err)
+		}
+	}
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Index < report.Issues[j].Index
+	})
+
+	chunks, err := bulkImportJobs(profiles)
+	if err != nil {
+		issue(-1, err)
+		return report
+	}
+	report.Jobs = len(chunks)
+	for i, chunk := range chunks {
+		if chunk.size > maxBulkImportPayloadSize {
+			issue(-1, &ErrBulkImportTooLarge{Job: i, Size: chunk.size, Max: maxBulkImportPayloadSize})
+		}
+	}
+
+	return report
+}
+
+// bulkImportJob is the range [start, end) of the profiles of a bulk import job and the size of its request.
+type bulkImportJob struct {
+	start, end int
+	size       int
+}
+
+// bulkImportJobs splits the profiles into bulk import jobs of at most 10,000 profiles whose requests don't
+// exceed the maximum payload size, unless a single profile does.
+func bulkImportJobs(profiles []map[string]interface{}) ([]bulkImportJob, error) {
+	base, err := jsonSize(bulkImportJobRequest([]map[string]interface{}{}))
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []bulkImportJob
+	job := bulkImportJob{size: base}
+	for i, p := range profiles {
+		n, err := jsonSize(p)
+		if err != nil {
+			return nil, err
+		}
+		if job.end > job.start {
+			if job.end-job.start == maxBulkImportProfiles || job.size+1+n > maxBulkImportPayloadSize {
+				jobs = append(jobs, job)
+				job = bulkImportJob{start: i, end: i, size: base}
+			} else {
+				// the comma separating the profile from the previous one
+				job.size++
+			}
+		}
+		job.end, job.size = i+1, job.size+n
+	}
+	if job.end > job.start {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
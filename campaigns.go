@@ -0,0 +1,97 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/monetha/go-klaviyo/models/campaign"
+)
+
+const (
+	campaignType        = "campaign"
+	campaignMessageType = "campaign-message"
+	campaignsPath       = "campaigns"
+)
+
+// GetCampaign retrieves a specific campaign by its ID from Klaviyo, together with its messages.
+func (c *Client) GetCampaign(ctx context.Context, campaignID string) (*campaign.ExistingCampaign, error) {
+	endpoint := path.Join(campaignsPath, campaignID)
+
+	fields := url.Values{}
+	fields.Set("include", "campaign-messages")
+
+	var result struct {
+		Data     campaign.ExistingCampaign `json:"data"`
+		Included []struct {
+			Type string `json:"type"`
+			campaign.ExistingMessage
+		} `json:"included"`
+	}
+	if err := c.doReq(ctx, OperationGetCampaign, http.MethodGet, endpoint, fields, nil, &result); err != nil {
+		return nil, err
+	}
+
+	for i := range result.Included {
+		if inc := &result.Included[i]; inc.Type == campaignMessageType {
+			result.Data.Messages = append(result.Data.Messages, &inc.ExistingMessage)
+		}
+	}
+
+	return &result.Data, nil
+}
+
+// CreateCampaign creates a new campaign in Klaviyo.
+func (c *Client) CreateCampaign(ctx context.Context, nc *campaign.NewCampaign) (*campaign.ExistingCampaign, error) {
+	type requestData struct {
+		*campaign.NewCampaign
+		Type string `json:"type"`
+	}
+
+	request := struct {
+		Data requestData `json:"data"`
+	}{
+		Data: requestData{
+			NewCampaign: nc,
+			Type:        campaignType,
+		},
+	}
+
+	var result struct {
+		Data campaign.ExistingCampaign `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationCreateCampaign, http.MethodPost, campaignsPath, nil, request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// CloneCampaign reads the campaign with the given ID (messages, audiences and settings) and creates
+// a copy of it in Klaviyo, applying the given overrides (e.g. name, audiences, send time) to the copy.
+// If the copy can't be created after the campaign was read, a *PartialError is returned.
+func (c *Client) CloneCampaign(ctx context.Context, campaignID string, overrides ...campaign.Override) (*campaign.ExistingCampaign, error) {
+	existing, err := c.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := existing.ToNewCampaign()
+	for _, o := range overrides {
+		o.Apply(nc)
+	}
+
+	created, err := c.CreateCampaign(ctx, nc)
+	if err != nil {
+		return nil, &PartialError{
+			Operation: "clone campaign",
+			Steps: []StepResult{
+				{Name: "get campaign", Done: true},
+				{Name: "create campaign", Err: err, Retryable: isWriteRetryable(err)},
+			},
+		}
+	}
+
+	return created, nil
+}
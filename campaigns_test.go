@@ -0,0 +1,191 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/models/campaign"
+)
+
+func TestClient_CloneCampaign(t *testing.T) {
+	t.Run("clone campaign with valid API key", func(t *testing.T) {
+		withHTTPRecorder("tests/clone_campaign_valid_api_key", func(c *http.Client) {
+			const (
+				existingCampaignID = "01HQ4CAMPAIGN00000000000000"
+				newCampaignName    = "Weekly newsletter (copy)"
+			)
+
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			ctx := context.TODO()
+			cc, err := kc.CloneCampaign(ctx, existingCampaignID,
+				campaign.WithName(newCampaignName),
+				campaign.WithSendTime(time.Date(2024, 2, 12, 10, 0, 0, 0, time.UTC)),
+			)
+
+			require.NoError(t, err)
+			require.NotNil(t, cc)
+			require.Equal(t, "01HQ5CLONE0000000000000000", cc.ID, "Mismatch in field: ID")
+			require.Equal(t, newCampaignName, cc.Attributes.Name, "Mismatch in field: Name")
+			require.Equal(t, []string{"Y6nRLr"}, cc.Attributes.Audiences.Included, "Mismatch in field: Audiences.Included")
+			require.Equal(t, []string{"UTd5ui"}, cc.Attributes.Audiences.Excluded, "Mismatch in field: Audiences.Excluded")
+		})
+	})
+}
+
+func TestClient_CancelCampaignSendJob(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		action string
+		call   func(kc *klaviyo.Client, ctx context.Context, id string) error
+	}{
+		{name: "cancel", action: "cancel", call: (*klaviyo.Client).CancelCampaignSendJob},
+		{name: "revert", action: "revert", call: (*klaviyo.Client).RevertCampaignSendJob},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				require.Equal(t, http.MethodPatch, req.Method)
+				require.Equal(t, "/api/campaign-send-jobs/01HN6AFEHGF6F77WJRKT1C9JHA", req.URL.Path)
+
+				var body struct {
+					Data struct {
+						Type       string `json:"type"`
+						ID         string `json:"id"`
+						Attributes struct {
+							Action string `json:"action"`
+						} `json:"attributes"`
+					} `json:"data"`
+				}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				require.Equal(t, "campaign-send-job", body.Data.Type)
+				require.Equal(t, "01HN6AFEHGF6F77WJRKT1C9JHA", body.Data.ID)
+				require.Equal(t, tc.action, body.Data.Attributes.Action)
+
+				return &http.Response{StatusCode: http.StatusNoContent, Header: http.Header{}, Body: http.NoBody}, nil
+			})}
+
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+			require.NoError(t, tc.call(kc, context.TODO(), "01HN6AFEHGF6F77WJRKT1C9JHA"))
+		})
+	}
+}
+
+func TestClient_WaitForCampaignSendJob(t *testing.T) {
+	statuses := []string{"processing", "processing", "cancelled"}
+	var polls int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method)
+		require.Equal(t, "/api/campaign-send-jobs/01HN6AFEHGF6F77WJRKT1C9JHA", req.URL.Path)
+		status := statuses[polls]
+		polls++
+		return jsonResponse(http.StatusOK, `{"data":{"type":"campaign-send-job","id":"01HN6AFEHGF6F77WJRKT1C9JHA","attributes":{"status":"`+status+`"}}}`), nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+
+	type result struct {
+		job *campaign.SendJob
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		job, err := kc.WaitForCampaignSendJob(context.TODO(), "01HN6AFEHGF6F77WJRKT1C9JHA", 10*time.Second)
+		done <- result{job, err}
+	}()
+
+	waitForSleeper(t, clk)
+	clk.Advance(10 * time.Second)
+	waitForSleeper(t, clk)
+	// the interval doubles after every poll
+	clk.Advance(20 * time.Second)
+
+	r := <-done
+	require.NoError(t, r.err)
+	require.Equal(t, campaign.SendJobStatusCancelled, r.job.Attributes.Status)
+	require.Equal(t, 3, polls)
+}
+
+func TestClient_WaitForCampaignSendJob_Progress(t *testing.T) {
+	statuses := []string{"queued", "processing", "processing", "complete"}
+	var polls int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[polls]
+		polls++
+		return jsonResponse(http.StatusOK, `{"data":{"type":"campaign-send-job","id":"01HN6AFEHGF6F77WJRKT1C9JHA","attributes":{"status":"`+status+`"}}}`), nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+
+	var progress []klaviyo.WaitProgress
+	var seen []campaign.SendJobStatus
+	done := make(chan error, 1)
+	go func() {
+		_, err := kc.WaitForCampaignSendJob(context.TODO(), "01HN6AFEHGF6F77WJRKT1C9JHA", 10*time.Second,
+			klaviyo.WithMaxWaitInterval(15*time.Second),
+			klaviyo.WithWaitProgress(func(job *campaign.SendJob, p klaviyo.WaitProgress) {
+				seen = append(seen, job.Attributes.Status)
+				progress = append(progress, p)
+			}),
+		)
+		done <- err
+	}()
+
+	for _, d := range []time.Duration{10 * time.Second, 15 * time.Second, 15 * time.Second} {
+		waitForSleeper(t, clk)
+		clk.Advance(d)
+	}
+
+	require.NoError(t, <-done)
+	require.Equal(t, []campaign.SendJobStatus{"queued", "processing", "processing", "complete"}, seen)
+	require.Equal(t, []klaviyo.WaitProgress{
+		{Polls: 1, Elapsed: 0, NextPoll: 10 * time.Second},
+		{Polls: 2, Elapsed: 10 * time.Second, NextPoll: 15 * time.Second},
+		{Polls: 3, Elapsed: 25 * time.Second, NextPoll: 15 * time.Second},
+		{Polls: 4, Elapsed: 40 * time.Second},
+	}, progress)
+}
+
+func TestClient_WaitForCampaignSendJob_Canceled(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"data":{"type":"campaign-send-job","id":"01HN6AFEHGF6F77WJRKT1C9JHA","attributes":{"status":"processing"}}}`), nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	type result struct {
+		job *campaign.SendJob
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		job, err := kc.WaitForCampaignSendJob(ctx, "01HN6AFEHGF6F77WJRKT1C9JHA", 10*time.Second)
+		done <- result{job, err}
+	}()
+
+	waitForSleeper(t, clk)
+	clk.Advance(10 * time.Second)
+	waitForSleeper(t, clk)
+	cancel()
+
+	r := <-done
+	require.ErrorIs(t, r.err, context.Canceled)
+	var e *klaviyo.ErrWaitInterrupted
+	require.ErrorAs(t, r.err, &e)
+	require.Equal(t, 2, e.Polls)
+	require.NotNil(t, r.job)
+	require.Equal(t, campaign.SendJobStatusProcessing, r.job.Attributes.Status)
+}
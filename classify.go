@@ -0,0 +1,71 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// IsRetryable reports whether the error returned by a client method is transient, i.e. the same call can
+// succeed if it is repeated later, e.g. when a message-queue consumer requeues the message.
+//
+// Rate limiting, unavailability of the service, server errors, concurrent write conflicts, timeouts and
+// reset or refused connections are retryable. A *PartialError is retryable if all its failed steps can be safely retried.
+// Canceled contexts and the errors reporting invalid input, credentials or permissions are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var partialErr *PartialError
+	if errors.As(err, &partialErr) {
+		failed := partialErr.Failed()
+		return len(failed) > 0 && len(partialErr.Retryable()) == len(failed)
+	}
+
+	var (
+		serviceUnavailableErr *ErrServiceUnavailable
+		deadlineErr           *ErrRetryAfterExceedsDeadline
+		badResponseErr        *BadHTTPResponseError
+		apiErr                *APIError
+		netErr                net.Error
+	)
+	switch {
+	case errors.Is(err, ErrTooManyRequests),
+		errors.As(err, &serviceUnavailableErr),
+		errors.As(err, &deadlineErr),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.As(err, &badResponseErr):
+		return isRetryableStatus(badResponseErr.StatusCode())
+	case errors.As(err, &apiErr):
+		return isRetryableStatus(apiErr.Status)
+	case errors.As(err, &netErr) && netErr.Timeout(),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED):
+		// the request failed in transport, before a response was received; other transport errors,
+		// e.g. invalid certificates or URLs, fail again
+		return true
+	}
+	return false
+}
+
+// IsPermanent reports whether the error returned by a client method is permanent, i.e. repeating
+// the same call fails again, e.g. when a message-queue consumer should drop the message rather than requeue it.
+// It is the opposite of IsRetryable for non-nil errors.
+func IsPermanent(err error) bool {
+	return err != nil && !IsRetryable(err)
+}
+
+// isRetryableStatus reports whether the HTTP status code of a failed response indicates a transient failure.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return true
+	}
+	return statusCode >= http.StatusInternalServerError && statusCode != http.StatusNotImplemented
+}
@@ -0,0 +1,81 @@
+package klaviyo_test
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"rate limited", &klaviyo.ErrRateLimited{}, true},
+		{"service unavailable", &klaviyo.ErrServiceUnavailable{Maintenance: true}, true},
+		{"retry after exceeds deadline", &klaviyo.ErrRetryAfterExceedsDeadline{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", &klaviyo.APIError{Status: http.StatusBadGateway}, true},
+		{"conflict", &klaviyo.APIError{Status: http.StatusConflict}, true},
+		{"deadline exceeded", fmt.Errorf("get profile: %w", context.DeadlineExceeded), true},
+		{"connection reset", &url.Error{Op: "Get", URL: "https://a.klaviyo.com/api/profiles", Err: &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, true},
+		{"connection refused", &url.Error{Op: "Get", URL: "https://a.klaviyo.com/api/profiles", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}, true},
+		{"network timeout", &url.Error{Op: "Get", URL: "https://a.klaviyo.com/api/profiles", Err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}}, true},
+		{"retryable partial failure", &klaviyo.PartialError{Steps: []klaviyo.StepResult{
+			{Name: "create", Done: true},
+			{Name: "update", Err: &klaviyo.ErrRateLimited{}, Retryable: true},
+		}}, true},
+
+		{"nil", nil, false},
+		{"canceled", context.Canceled, false},
+		{"invalid API key", klaviyo.ErrInvalidAPIKey, false},
+		{"profile does not exist", klaviyo.ErrProfileDoesNotExist, false},
+		{"profile already exists", &klaviyo.ErrProfileAlreadyExists{DuplicateProfileID: "01H8HKMDG8F4MN7PSRZ4YQYNVQ"}, false},
+		{"missing scope", &klaviyo.ErrMissingScope{Cause: &klaviyo.APIError{Status: http.StatusForbidden}}, false},
+		{"operation not allowed", &klaviyo.ErrOperationNotAllowed{Operation: klaviyo.OperationCreateProfile}, false},
+		{"bad request", &klaviyo.APIError{Status: http.StatusBadRequest}, false},
+		{"invalid property value", &klaviyo.ErrInvalidPropertyValue{Key: "score"}, false},
+		{"response too large", &klaviyo.ErrResponseTooLarge{Limit: 1 << 20}, false},
+		{"invalid certificate", &url.Error{Op: "Get", URL: "https://a.klaviyo.com/api/profiles", Err: x509.UnknownAuthorityError{}}, false},
+		{"unsupported scheme", &url.Error{Op: "Get", URL: "ftp://a.klaviyo.com/api/profiles", Err: errors.New("unsupported protocol scheme \"ftp\"")}, false},
+		{"non-retryable partial failure", &klaviyo.PartialError{Steps: []klaviyo.StepResult{
+			{Name: "update", Err: &klaviyo.APIError{Status: http.StatusBadRequest}},
+		}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.retryable, klaviyo.IsRetryable(tt.err))
+			require.Equal(t, !tt.retryable && tt.err != nil, klaviyo.IsPermanent(tt.err))
+		})
+	}
+}
+
+func TestIsRetryable_ClientErrors(t *testing.T) {
+	status := http.StatusBadRequest
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(status, `{"errors":[{"id":"e1","status":`+strconv.Itoa(status)+`,"code":"error","title":"Error.","detail":"error"}]}`), nil
+	})}
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	_, err := kc.GetProfiles(context.TODO())
+	require.Error(t, err)
+	require.True(t, klaviyo.IsPermanent(err))
+
+	status = http.StatusConflict
+	_, err = kc.GetProfiles(context.TODO())
+	require.Error(t, err)
+	require.True(t, klaviyo.IsRetryable(err))
+}
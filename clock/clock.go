@@ -0,0 +1,126 @@
+// Package clock provides the time source used by the client for pagination, caching and polling,
+// so that tests can advance time artificially instead of sleeping for real. The retries of error responses
+// performed by the HTTP layer wait on the clock too; the retries after transport errors use the system time.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock is an interface that any time source should implement.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep waits for the given duration or until the context is done, in which case it returns the context error.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// System is the Clock using the system time.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+// Now returns the current system time.
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Sleep waits for the given duration or until the context is done.
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Manual is a Clock whose time only moves when it is advanced, intended for tests.
+type Manual struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	until time.Time
+	done  chan struct{}
+}
+
+// NewManual creates a new manual clock set to the given time.
+func NewManual(now time.Time) *Manual {
+	return &Manual{now: now}
+}
+
+// Now returns the current time of the clock.
+func (m *Manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+// Sleep waits until the clock is advanced by the given duration or until the context is done.
+func (m *Manual) Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	m.mu.Lock()
+	w := &waiter{until: m.now.Add(d), done: make(chan struct{})}
+	m.waiters = append(m.waiters, w)
+	m.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		m.remove(w)
+		return ctx.Err()
+	case <-w.done:
+		return nil
+	}
+}
+
+// Advance moves the time of the clock forward, waking up the sleepers whose time has come.
+func (m *Manual) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+
+	waiters := m.waiters[:0]
+	for _, w := range m.waiters {
+		if m.now.Before(w.until) {
+			waiters = append(waiters, w)
+			continue
+		}
+		close(w.done)
+	}
+	m.waiters = waiters
+}
+
+// Sleepers returns the number of goroutines currently sleeping on the clock.
+func (m *Manual) Sleepers() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.waiters)
+}
+
+func (m *Manual) remove(w *waiter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, other := range m.waiters {
+		if other == w {
+			m.waiters = append(m.waiters[:i], m.waiters[i+1:]...)
+			return
+		}
+	}
+}
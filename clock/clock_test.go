@@ -0,0 +1,40 @@
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+func TestManual(t *testing.T) {
+	t.Run("sleep returns once the clock is advanced", func(t *testing.T) {
+		start := time.Date(2024, 1, 30, 5, 10, 0, 0, time.UTC)
+		c := clock.NewManual(start)
+
+		done := make(chan error)
+		go func() { done <- c.Sleep(context.TODO(), time.Minute) }()
+
+		require.Eventually(t, func() bool { return c.Sleepers() == 1 }, time.Second, time.Millisecond)
+
+		c.Advance(30 * time.Second)
+		require.Equal(t, 1, c.Sleepers())
+
+		c.Advance(30 * time.Second)
+		require.NoError(t, <-done)
+		require.Equal(t, start.Add(time.Minute), c.Now())
+	})
+
+	t.Run("sleep returns when the context is done", func(t *testing.T) {
+		c := clock.NewManual(time.Now())
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+
+		require.ErrorIs(t, c.Sleep(ctx, time.Minute), context.Canceled)
+		require.Zero(t, c.Sleepers())
+	})
+}
@@ -0,0 +1,40 @@
+package klaviyo
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// coalesces reports whether identical in-flight requests of the operation are coalesced.
+func (o *Options) coalesces(op Operation) bool {
+	if o.coalescedOps == nil {
+		return false
+	}
+	if _, ok := o.coalescedOps[op]; ok {
+		return true
+	}
+	_, ok := o.coalescedOps[""]
+	return ok
+}
+
+// coalescingKey returns the key identifying identical requests: the method, the URL and the headers.
+func coalescingKey(req *http.Request) string {
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(req.Method)
+	b.WriteByte(' ')
+	b.WriteString(req.URL.String())
+	for _, name := range names {
+		b.WriteByte('\n')
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(req.Header[name], ","))
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestWithRequestCoalescing(t *testing.T) {
+	newClient := func(calls *int32, release <-chan struct{}, opts ...klaviyo.Option) *klaviyo.Client {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(calls, 1)
+			<-release
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}}`), nil
+		})}
+		return klaviyo.NewWithClient(validAPIKey, zap.L(), c, opts...)
+	}
+
+	getConcurrently := func(kc *klaviyo.Client, n int, release chan<- struct{}) []string {
+		emails := make([]string, n)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				p, err := kc.GetProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ")
+				if err == nil {
+					emails[i] = p.Attributes.Email
+				}
+			}(i)
+		}
+		// give the goroutines time to join the request in flight
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		return emails
+	}
+
+	t.Run("identical requests are coalesced", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		kc := newClient(&calls, release, klaviyo.WithRequestCoalescing(klaviyo.OperationGetProfile))
+
+		emails := getConcurrently(kc, 5, release)
+
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		for _, email := range emails {
+			require.Equal(t, "sarah.mason@klaviyo-demo.com", email)
+		}
+	})
+
+	t.Run("requests are not coalesced by default", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		kc := newClient(&calls, release)
+
+		getConcurrently(kc, 5, release)
+
+		require.Equal(t, int32(5), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("canceling the first caller doesn't fail the others", func(t *testing.T) {
+		var calls int32
+		release := make(chan struct{})
+		kc := newClient(&calls, release, klaviyo.WithRequestCoalescing(klaviyo.OperationGetProfile))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := kc.GetProfile(ctx, "01H8HKMDG8F4MN7PSRZ4YQYNVQ")
+			firstErr <- err
+		}()
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+
+		emails := make(chan []string, 1)
+		go func() { emails <- getConcurrently(kc, 3, release) }()
+		// give the goroutines time to join the request in flight before canceling the first caller
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+
+		require.Equal(t, []string{"sarah.mason@klaviyo-demo.com", "sarah.mason@klaviyo-demo.com", "sarah.mason@klaviyo-demo.com"}, <-emails)
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
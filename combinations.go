@@ -0,0 +1,80 @@
+package klaviyo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+)
+
+// AttributeCombinations defines how the client treats profile attribute combinations that an endpoint rejects.
+type AttributeCombinations int
+
+const (
+	// AttributeCombinationsError fails the request with ErrUnsupportedAttributes before it is sent.
+	AttributeCombinationsError AttributeCombinations = iota
+	// AttributeCombinationsStrip removes the offending attribute, logs a warning and sends the request.
+	AttributeCombinationsStrip
+)
+
+// ErrUnsupportedAttributes indicates that the operation doesn't accept Attribute together with the With attributes.
+type ErrUnsupportedAttributes struct {
+	Operation Operation
+	Attribute string
+	With      []string
+}
+
+// Error returns a string representation of the ErrUnsupportedAttributes error.
+// It conforms to the error interface.
+func (e *ErrUnsupportedAttributes) Error() string {
+	return fmt.Sprintf("klaviyo: %s doesn't accept %q together with %s", e.Operation, e.Attribute, strings.Join(e.With, ", "))
+}
+
+// attributeCombination is a rule that forbids sending attribute together with any of the with attributes.
+// The attribute is the one stripped in the AttributeCombinationsStrip mode.
+type attributeCombination struct {
+	attribute string
+	with      []string
+}
+
+// unsupportedAttributeCombinations are the attribute combinations rejected per operation.
+// Bulk import jobs match profiles by email, phone number or external ID and reject anonymous IDs alongside them.
+var unsupportedAttributeCombinations = map[Operation][]attributeCombination{
+	OperationCreateBulkImportJob: {
+		{attribute: "anonymous_id", with: []string{"email", "phone_number", "external_id"}},
+	},
+}
+
+// checkAttributeCombinations applies the attribute combination rules of the operation to the profile update.
+// In the strip mode, the offending attributes are removed from the update and nil is returned.
+func (c *Client) checkAttributeCombinations(op Operation, data *updater.ProfileData) error {
+	for _, rule := range unsupportedAttributeCombinations[op] {
+		if _, ok := data.Attributes[rule.attribute]; !ok {
+			continue
+		}
+
+		var with []string
+		for _, other := range rule.with {
+			if _, ok := data.Attributes[other]; ok {
+				with = append(with, other)
+			}
+		}
+		if len(with) == 0 {
+			continue
+		}
+		sort.Strings(with)
+
+		if c.options.attributeCombinations != AttributeCombinationsStrip {
+			return &ErrUnsupportedAttributes{Operation: op, Attribute: rule.attribute, With: with}
+		}
+
+		delete(data.Attributes, rule.attribute)
+		c.logger.Warn("unsupported attribute stripped",
+			LogFieldOperation, op,
+			"attribute", rule.attribute,
+			"with", with,
+		)
+	}
+	return nil
+}
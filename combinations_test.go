@@ -0,0 +1,80 @@
+package klaviyo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+)
+
+func TestWithAttributeCombinations(t *testing.T) {
+	const jobResponse = `{"data":{"type":"profile-bulk-import-job","id":"ZXhhbXBsZTE","attributes":{"status":"queued","total_count":1}}}`
+
+	updates := []klaviyo.ProfileUpdate{{
+		Updaters: []updater.Profile{
+			profile.WithAnonymousId("anon-1"),
+			profile.WithExternalId("ext-1"),
+			profile.WithEmail("user@example.com"),
+		},
+	}}
+
+	t.Run("unsupported combination is rejected by default", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		jobs, err := kc.BulkUpdateProfiles(context.TODO(), updates)
+
+		var e *klaviyo.ErrUnsupportedAttributes
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, klaviyo.OperationCreateBulkImportJob, e.Operation)
+		require.Equal(t, "anonymous_id", e.Attribute)
+		require.Equal(t, []string{"email", "external_id"}, e.With)
+		require.Nil(t, jobs)
+	})
+
+	t.Run("unsupported attribute is stripped with a warning", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, jobResponse), nil
+		})}
+		core, logs := observer.New(zapcore.WarnLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithAttributeCombinations(klaviyo.AttributeCombinationsStrip))
+
+		_, err := kc.BulkUpdateProfiles(context.TODO(), updates)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"data":{"type":"profile-bulk-import-job","attributes":{"profiles":{"data":[`+
+			`{"type":"profile","attributes":{"email":"user@example.com","external_id":"ext-1"}}`+
+			`]}}}}`, body)
+
+		entries := logs.FilterMessage("unsupported attribute stripped").All()
+		require.Len(t, entries, 1)
+		require.Equal(t, "anonymous_id", entries[0].ContextMap()["attribute"])
+	})
+
+	t.Run("anonymous ID alone is accepted", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusAccepted, jobResponse), nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		_, err := kc.BulkUpdateProfiles(context.TODO(), []klaviyo.ProfileUpdate{{
+			ProfileID: "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			Updaters:  []updater.Profile{profile.WithAnonymousId("anon-1")},
+		}})
+		require.NoError(t, err)
+	})
+}
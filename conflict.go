@@ -0,0 +1,42 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// conflictRetryWait is the wait before the first retry of an upsert that failed with a conflict.
+// It grows linearly with every further retry.
+const conflictRetryWait = 250 * time.Millisecond
+
+// isConflict reports whether the error is a 409 Conflict reported by the API.
+func isConflict(err error) bool {
+	var dup *ErrProfileAlreadyExists
+	if errors.As(err, &dup) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict
+}
+
+// retryConflicts calls the upsert and retries it up to the number of times set by WithConflictRetries
+// while it fails with a conflict. Conflicts of upserts are usually caused by a concurrent write of the same
+// identifiers, so the retried upsert resolves the identifiers again and matches the profile written meanwhile.
+// The error of the last attempt is returned if all retries fail.
+func (c *Client) retryConflicts(ctx context.Context, op Operation, upsert func() error) error {
+	err := upsert()
+	for attempt := 1; attempt <= c.options.conflictRetries && isConflict(err); attempt++ {
+		c.logger.Warn("retrying conflicting upsert",
+			LogFieldOperation, op,
+			"attempt", attempt,
+			"error", err,
+		)
+		if sleepErr := c.options.clock.Sleep(ctx, time.Duration(attempt)*conflictRetryWait); sleepErr != nil {
+			return sleepErr
+		}
+		err = upsert()
+	}
+	return err
+}
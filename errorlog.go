@@ -0,0 +1,48 @@
+package klaviyo
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+[0-9][0-9 ()\-]{6,}[0-9]`)
+)
+
+const (
+	redactedEmail = "[redacted-email]"
+	redactedPhone = "[redacted-phone]"
+)
+
+// logErrorBody logs a redacted snippet of the body of a failed response, if enabled by WithErrorBodyLogging.
+func (c *Client) logErrorBody(op Operation, method, endpoint string, statusCode int, body []byte) {
+	limit := c.options.errorBodyLogSize
+	if limit <= 0 {
+		return
+	}
+
+	c.logger.Warn("request failed",
+		LogFieldOperation, op,
+		LogFieldMethod, method,
+		LogFieldEndpoint, endpoint,
+		LogFieldStatus, statusCode,
+		"body", redactBody(body, limit),
+	)
+}
+
+// redactBody replaces email addresses and phone numbers in the body and truncates it to at most limit bytes,
+// without splitting a UTF-8 encoded character.
+func redactBody(body []byte, limit int) string {
+	redacted := emailPattern.ReplaceAll(body, []byte(redactedEmail))
+	redacted = phonePattern.ReplaceAll(redacted, []byte(redactedPhone))
+	if len(redacted) <= limit {
+		return string(redacted)
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(redacted[cut]) {
+		cut--
+	}
+	return string(redacted[:cut]) + "..."
+}
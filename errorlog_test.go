@@ -0,0 +1,64 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+func TestWithErrorBodyLogging(t *testing.T) {
+	const body = `{"errors":[{"id":"8c7d0d53","status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid phone number +1 (555) 010-9999 for john.doe@example.com","source":{"pointer":"/data/attributes/phone_number"}}]}`
+
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusBadRequest, body), nil
+	})}
+
+	t.Run("redacted body is logged", func(t *testing.T) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithErrorBodyLogging(1024))
+
+		_, err := kc.UpdateProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM", profile.WithPhoneNumber("+1 (555) 010-9999"))
+		require.Error(t, err)
+
+		entries := logs.FilterMessage("request failed").All()
+		require.Len(t, entries, 1)
+		fields := entries[0].ContextMap()
+		require.Equal(t, klaviyo.OperationUpdateProfile, fields["operation"])
+		require.Equal(t, "/api/profiles/01GVCF3FZ5W7YCE0MW4ZCBVWXM", fields[klaviyo.LogFieldEndpoint])
+		require.EqualValues(t, http.StatusBadRequest, fields[klaviyo.LogFieldStatus])
+
+		logged := fields["body"].(string)
+		require.Contains(t, logged, `Invalid phone number [redacted-phone] for [redacted-email]`)
+		require.NotContains(t, logged, "john.doe")
+		require.NotContains(t, logged, "010-9999")
+	})
+
+	t.Run("body is truncated", func(t *testing.T) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithErrorBodyLogging(32))
+
+		_, err := kc.GetProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM")
+		require.Error(t, err)
+
+		entries := logs.FilterMessage("request failed").All()
+		require.Len(t, entries, 1)
+		require.Equal(t, body[:32]+"...", entries[0].ContextMap()["body"])
+	})
+
+	t.Run("body is not logged by default", func(t *testing.T) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c)
+
+		_, err := kc.GetProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM")
+		require.Error(t, err)
+		require.Zero(t, logs.FilterMessage("request failed").Len())
+	})
+}
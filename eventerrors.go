@@ -0,0 +1,83 @@
+package klaviyo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidMetricName indicates that an event was rejected because of the name of its metric.
+type ErrInvalidMetricName struct {
+	Cause *APIError
+}
+
+// Error returns a string representation of the ErrInvalidMetricName error.
+// It conforms to the error interface.
+func (e *ErrInvalidMetricName) Error() string {
+	return fmt.Sprintf("klaviyo: invalid metric name: %s", e.Cause.Detail)
+}
+
+// Unwrap provides compatibility for Go's errors.Is() and errors.As() functions.
+func (e *ErrInvalidMetricName) Unwrap() error {
+	return e.Cause
+}
+
+// ErrInvalidEventTime indicates that an event was rejected because of its time, e.g. a malformed time
+// or a time too far in the past or the future.
+type ErrInvalidEventTime struct {
+	Cause *APIError
+}
+
+// Error returns a string representation of the ErrInvalidEventTime error.
+// It conforms to the error interface.
+func (e *ErrInvalidEventTime) Error() string {
+	return fmt.Sprintf("klaviyo: invalid event time: %s", e.Cause.Detail)
+}
+
+// Unwrap provides compatibility for Go's errors.Is() and errors.As() functions.
+func (e *ErrInvalidEventTime) Unwrap() error {
+	return e.Cause
+}
+
+// ErrEventTooLarge indicates that an event was rejected because it or its properties are too large.
+type ErrEventTooLarge struct {
+	Cause *APIError
+}
+
+// Error returns a string representation of the ErrEventTooLarge error.
+// It conforms to the error interface.
+func (e *ErrEventTooLarge) Error() string {
+	return fmt.Sprintf("klaviyo: event too large: %s", e.Cause.Detail)
+}
+
+// Unwrap provides compatibility for Go's errors.Is() and errors.As() functions.
+func (e *ErrEventTooLarge) Unwrap() error {
+	return e.Cause
+}
+
+// wrapEventError maps the error of a rejected event to a typed error, based on the attribute the error points to.
+// It returns nil if the error is not specific to events.
+func wrapEventError(apiErr *APIError) error {
+	if apiErr.Status == http.StatusRequestEntityTooLarge {
+		return &ErrEventTooLarge{Cause: apiErr}
+	}
+	if apiErr.Status != http.StatusBadRequest {
+		return nil
+	}
+
+	pointer := apiErr.Source.Pointer
+	switch {
+	case strings.Contains(pointer, "/metric/"):
+		return &ErrInvalidMetricName{Cause: apiErr}
+	case strings.HasSuffix(pointer, "/attributes/time"):
+		return &ErrInvalidEventTime{Cause: apiErr}
+	case strings.HasSuffix(pointer, "/attributes/properties") && isTooLarge(apiErr):
+		return &ErrEventTooLarge{Cause: apiErr}
+	}
+	return nil
+}
+
+func isTooLarge(apiErr *APIError) bool {
+	s := strings.ToLower(apiErr.Code + " " + apiErr.Detail)
+	return strings.Contains(s, "too large") || strings.Contains(s, "too_large") || strings.Contains(s, "exceed")
+}
@@ -0,0 +1,75 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_CreateEventErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		target interface{}
+	}{
+		{
+			name:   "invalid metric name",
+			status: http.StatusBadRequest,
+			body:   `{"errors":[{"id":"1c1a4fc4","status":400,"code":"invalid","title":"Invalid input.","detail":"Metric name must not be blank.","source":{"pointer":"/data/attributes/metric/data/attributes/name"}}]}`,
+			target: new(*klaviyo.ErrInvalidMetricName),
+		},
+		{
+			name:   "invalid event time",
+			status: http.StatusBadRequest,
+			body:   `{"errors":[{"id":"1c1a4fc4","status":400,"code":"invalid","title":"Invalid input.","detail":"Time must be within the last year.","source":{"pointer":"/data/attributes/time"}}]}`,
+			target: new(*klaviyo.ErrInvalidEventTime),
+		},
+		{
+			name:   "oversize properties",
+			status: http.StatusBadRequest,
+			body:   `{"errors":[{"id":"1c1a4fc4","status":400,"code":"invalid","title":"Invalid input.","detail":"Properties exceed the maximum size of 400 KB.","source":{"pointer":"/data/attributes/properties"}}]}`,
+			target: new(*klaviyo.ErrEventTooLarge),
+		},
+		{
+			name:   "oversize request",
+			status: http.StatusRequestEntityTooLarge,
+			body:   `<html><body>413 Request Entity Too Large</body></html>`,
+			target: new(*klaviyo.ErrEventTooLarge),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return jsonResponse(tt.status, tt.body), nil
+			})}
+
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			res, err := kc.CreateEvent(context.TODO(), &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", "Reward")
+
+			require.ErrorAs(t, err, tt.target)
+			require.Nil(t, res)
+		})
+	}
+
+	t.Run("other errors are not mapped", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"1c1a4fc4","status":400,"code":"invalid","title":"Invalid input.","detail":"Profile is required.","source":{"pointer":"/data/attributes/profile"}}]}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		_, err := kc.CreateEvent(context.TODO(), &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", "Reward")
+
+		var apiErr *klaviyo.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, "/data/attributes/profile", apiErr.Source.Pointer)
+	})
+}
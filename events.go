@@ -0,0 +1,213 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/models/event"
+	"github.com/monetha/go-klaviyo/operations/getevents"
+)
+
+// eventsByIDsConcurrency is the maximum number of requests performed concurrently by GetEventsByIDs.
+const eventsByIDsConcurrency = 4
+
+// GetEvent retrieves the event with the given ID. If there is no such event, an *APIError matching ErrNotFound
+// is returned.
+func (c *Client) GetEvent(ctx context.Context, eventID string) (*event.ExistingEvent, error) {
+	endpoint := path.Join(eventsPath, eventID)
+
+	var result struct {
+		Data event.ExistingEvent `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetEvent, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// GetEventsByIDs retrieves the events with the given IDs, e.g. to reconcile an event archive with Klaviyo.
+// The events are retrieved one by one with GetEvent, because the events endpoint doesn't document filtering
+// by ID; at most eventsByIDsConcurrency requests are performed concurrently. Events are returned in the order
+// of the IDs; IDs of events that don't exist are skipped and duplicate IDs are returned once.
+// If a request fails, the remaining ones are canceled and its error is returned.
+func (c *Client) GetEventsByIDs(ctx context.Context, ids []string) ([]*event.ExistingEvent, error) {
+	ids = uniqueStrings(ids)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		found    = make([]*event.ExistingEvent, len(ids))
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, eventsByIDsConcurrency)
+	for i, id := range ids {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			e, err := c.GetEvent(ctx, id)
+			switch {
+			case err == nil:
+				found[i] = e
+			case !errors.Is(err, ErrNotFound):
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	events := make([]*event.ExistingEvent, 0, len(found))
+	for _, e := range found {
+		if e != nil {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// GetProfileEvents retrieves the most recent events of the profile with the given ID, newest first unless
+// sorted otherwise with getevents.WithSort.
+// Like GetEvents, it returns a single page; its size can be set with getevents.WithPageSize.
+// A filter given with getevents.WithFilter is combined with the filter by the profile.
+func (c *Client) GetProfileEvents(ctx context.Context, profileID string, params ...getevents.Param) ([]*event.ExistingEvent, error) {
+	fields := url.Values{}
+	fields.Set("sort", "-datetime")
+	for _, p := range params {
+		p.Apply(fields)
+	}
+	fields.Set("filter", filter.And(filter.Raw(fields.Get("filter")), filter.Equals("profile_id", profileID)).String())
+
+	es, _, err := c.getEventsPage(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+// ForEachEventSince walks the events that happened after since, oldest first, and calls fn with every page,
+// e.g. to ingest new events incrementally. It returns the checkpoint: the latest time of the events passed to fn,
+// or since if there were none. Passing the checkpoint as since on the next run reads only the newer events,
+// without re-reading the history.
+//
+// The events are filtered with greater-than(datetime,since), combined with a filter given with getevents.WithFilter,
+// and sorted by datetime regardless of getevents.WithSort. Klaviyo filters datetimes with a precision of a second,
+// and events received after the walk with a datetime at or before the checkpoint are not read; to tolerate such
+// late events, pass an earlier since and deduplicate the events by ID.
+//
+// If the API rejects a page cursor, e.g. because it expired during a long walk, the walk restarts from the first page
+// of the events after the checkpoint; it fails with *ErrCursorExpired only if no page was processed since the restart.
+// Like ForEachProfilePage, it stops if fetching a page or fn fails, or if the context is canceled between the pages,
+// and returns an *ErrPaginationInterrupted; the checkpoint returned with it covers the pages fn has processed.
+func (c *Client) ForEachEventSince(ctx context.Context, since time.Time, fn func([]*event.ExistingEvent) error, params ...getevents.Param) (time.Time, error) {
+	base := url.Values{}
+	for _, p := range params {
+		p.Apply(base)
+	}
+	base.Set("sort", "datetime")
+	newEventsPaginator := func(since time.Time) *Paginator[*event.ExistingEvent] {
+		fields := cloneValues(base)
+		fields.Set("filter", filter.And(filter.Raw(base.Get("filter")), filter.GreaterThan("datetime", since)).String())
+		paginator := newPaginator(c, c.getEventsPage, fields)
+		paginator.limit(params)
+		return paginator
+	}
+
+	var (
+		paginator  = newEventsPaginator(since)
+		checkpoint = since
+		// a rejected cursor restarts the walk, unless it was rejected right after a restart
+		canRestart = paginator.Cursor() != ""
+	)
+	for paginator.HasNext() {
+		if err := ctx.Err(); err != nil {
+			return checkpoint, paginator.interrupted(err)
+		}
+		es, err := paginator.Next(ctx)
+		if err != nil {
+			var expired *ErrCursorExpired
+			if canRestart && errors.As(err, &expired) {
+				c.logger.Warn("page cursor rejected, restarting from the checkpoint",
+					LogFieldOperation, OperationGetEvents,
+					"checkpoint", checkpoint,
+				)
+				base.Del(pageCursorField)
+				// the restarted walk counts against the limits of the call too
+				progress := paginator.Progress()
+				paginator, canRestart = newEventsPaginator(checkpoint), false
+				paginator.progress.Pages, paginator.progress.Records = progress.Pages, progress.Records
+				continue
+			}
+			return checkpoint, err
+		}
+		if err := fn(es); err != nil {
+			progress := paginator.Progress()
+			return checkpoint, &ErrPaginationInterrupted{Cursor: progress.LastCursor, Progress: progress, Err: err}
+		}
+		for _, e := range es {
+			if t := e.Attributes.Time(); t.After(checkpoint) {
+				checkpoint = t
+			}
+		}
+		canRestart = true
+	}
+	return checkpoint, nil
+}
+
+// getEventsPage retrieves a single page of events and returns the cursor of the next page, if any.
+func (c *Client) getEventsPage(ctx context.Context, fields url.Values) ([]*event.ExistingEvent, string, error) {
+	var result struct {
+		Data     []*event.ExistingEvent `json:"data"`
+		Links    links                  `json:"links"`
+		Included included               `json:"included"`
+	}
+	if err := c.doReq(ctx, OperationGetEvents, http.MethodGet, eventsPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+	if err := hydrateEvents(result.Data, result.Included); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data, result.Links.nextCursor(), nil
+}
+
+// uniqueStrings returns the strings without duplicates, keeping the order of their first occurrence.
+func uniqueStrings(ss []string) []string {
+	seen := make(map[string]struct{}, len(ss))
+	unique := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		unique = append(unique, s)
+	}
+	return unique
+}
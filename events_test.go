@@ -0,0 +1,389 @@
+package klaviyo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/models/event"
+	"github.com/monetha/go-klaviyo/operations/getevents"
+)
+
+func TestClient_GetEventsByIDs(t *testing.T) {
+	ids := make([]string, 0, 22)
+	for i := 0; i < 20; i++ {
+		ids = append(ids, fmt.Sprintf("evt%03d", i))
+	}
+	ids = append(ids, "evt000", "missing")
+
+	var (
+		requested   int32
+		inFlight    int32
+		maxInFlight int32
+	)
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requested, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		id := strings.TrimPrefix(req.URL.Path, "/api/events/")
+		if id == "missing" {
+			return jsonResponse(http.StatusNotFound, `{"errors":[{"id":"e1","status":404,"code":"not_found","title":"Not found.","detail":"Event not found."}]}`), nil
+		}
+		return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":{"type":"event","id":%q,"attributes":{}}}`, id)), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	t.Run("events are fetched by ID in the order of the IDs", func(t *testing.T) {
+		events, err := kc.GetEventsByIDs(context.TODO(), ids)
+		require.NoError(t, err)
+		require.Equal(t, int32(21), atomic.LoadInt32(&requested), "duplicate IDs are fetched once")
+		require.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(4))
+
+		require.Len(t, events, 20)
+		for i, e := range events {
+			require.Equal(t, ids[i], e.ID)
+		}
+	})
+
+	t.Run("a failed request fails the call", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusForbidden, `{"errors":[{"id":"e1","status":403,"code":"permission_denied","title":"Permission denied.","detail":"Missing scope."}]}`), nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		events, err := kc.GetEventsByIDs(context.TODO(), ids)
+		var apiErr *klaviyo.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.Status)
+		require.Nil(t, events)
+	})
+}
+
+func TestClient_ForEachEventSince(t *testing.T) {
+	since := time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)
+
+	var queries []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/events", req.URL.Path)
+		q := req.URL.Query()
+		queries = append(queries, q.Get("sort")+" "+q.Get("filter"))
+		if q.Get("page[cursor]") == "" {
+			return jsonResponse(http.StatusOK, `{"data":[`+
+				`{"type":"event","id":"evt1","attributes":{"timestamp":1706580000,"datetime":"2024-01-30T02:00:00+00:00"}},`+
+				`{"type":"event","id":"evt2","attributes":{"timestamp":1706583600,"datetime":"2024-01-30T03:00:00+00:00"}}`+
+				`],"links":{"next":"https://a.klaviyo.com/api/events/?page%5Bcursor%5D=bmV4dA"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"event","id":"evt3","attributes":{"timestamp":1706587200}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	t.Run("checkpoint is the latest event time", func(t *testing.T) {
+		queries = nil
+
+		var ids []string
+		checkpoint, err := kc.ForEachEventSince(ctx, since, func(es []*event.ExistingEvent) error {
+			for _, e := range es {
+				ids = append(ids, e.ID)
+			}
+			return nil
+		}, getevents.WithSort("-datetime"), getevents.WithFilter(filter.Equals("metric_id", "UMTLbD")))
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"evt1", "evt2", "evt3"}, ids)
+		require.Equal(t, time.Date(2024, 1, 30, 4, 0, 0, 0, time.UTC), checkpoint)
+		require.Equal(t, []string{
+			`datetime and(equals(metric_id,"UMTLbD"),greater-than(datetime,2024-01-30T00:00:00Z))`,
+			`datetime and(equals(metric_id,"UMTLbD"),greater-than(datetime,2024-01-30T00:00:00Z))`,
+		}, queries)
+	})
+
+	t.Run("checkpoint covers the processed pages", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var pages int
+		checkpoint, err := kc.ForEachEventSince(ctx, since, func(es []*event.ExistingEvent) error {
+			pages++
+			if pages == 2 {
+				return errStop
+			}
+			return nil
+		})
+
+		require.ErrorIs(t, err, errStop)
+		var e *klaviyo.ErrPaginationInterrupted
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "bmV4dA", e.Cursor)
+		require.True(t, time.Date(2024, 1, 30, 3, 0, 0, 0, time.UTC).Equal(checkpoint), checkpoint)
+	})
+
+	t.Run("checkpoint is since without events", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"data":[],"links":{"next":null}}`), nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		checkpoint, err := kc.ForEachEventSince(ctx, since, func(es []*event.ExistingEvent) error { return nil })
+
+		require.NoError(t, err)
+		require.Equal(t, since, checkpoint)
+	})
+}
+
+func TestClient_ForEachEventSince_CursorExpired(t *testing.T) {
+	const cursorRejected = `{"errors":[{"status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid page cursor.","source":{"parameter":"page[cursor]"}}]}`
+
+	var queries []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		queries = append(queries, q.Get("page[cursor]")+" "+q.Get("filter"))
+		switch {
+		case q.Get("page[cursor]") != "":
+			return jsonResponse(http.StatusBadRequest, cursorRejected), nil
+		case len(queries) == 1:
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"event","id":"evt1","attributes":{"timestamp":1706580000}}],`+
+				`"links":{"next":"https://a.klaviyo.com/api/events/?page%5Bcursor%5D=bmV4dA"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"event","id":"evt2","attributes":{"timestamp":1706583600}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	since := time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)
+
+	var ids []string
+	checkpoint, err := kc.ForEachEventSince(context.TODO(), since, func(es []*event.ExistingEvent) error {
+		for _, e := range es {
+			ids = append(ids, e.ID)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"evt1", "evt2"}, ids)
+	require.Equal(t, time.Date(2024, 1, 30, 3, 0, 0, 0, time.UTC), checkpoint)
+	require.Equal(t, []string{
+		` greater-than(datetime,2024-01-30T00:00:00Z)`,
+		`bmV4dA greater-than(datetime,2024-01-30T00:00:00Z)`,
+		` greater-than(datetime,2024-01-30T02:00:00Z)`,
+	}, queries)
+
+	t.Run("expired starting cursor fails after the restart", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusBadRequest, cursorRejected), nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		checkpoint, err := kc.ForEachEventSince(context.TODO(), since, func(es []*event.ExistingEvent) error { return nil },
+			getevents.WithCursor("c3RhbGU"))
+
+		var expired *klaviyo.ErrCursorExpired
+		require.False(t, errors.As(err, &expired), "the restart has no cursor to expire")
+		require.Error(t, err)
+		require.Equal(t, since, checkpoint)
+	})
+}
+
+func TestClient_GetEvents_Params(t *testing.T) {
+	since := time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)
+
+	var query map[string][]string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/events", req.URL.Path)
+		query = req.URL.Query()
+		return jsonResponse(http.StatusOK, `{"data":[],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	t.Run("events parameters", func(t *testing.T) {
+		_, err := kc.GetEvents(ctx,
+			getevents.WithPageSize(500),
+			getevents.WithFields("timestamp", "event_properties"),
+			getevents.WithFilter(filter.GreaterThan("datetime", since)),
+			getevents.WithSort("-datetime"),
+			getevents.WithInclude("profile", "metric"),
+			getevents.WithCursor("bmV4dA"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]string{
+			"page[size]":    {"200"},
+			"fields[event]": {"timestamp,event_properties"},
+			"filter":        {"greater-than(datetime,2024-01-30T00:00:00Z)"},
+			"sort":          {"-datetime"},
+			"include":       {"profile,metric"},
+			"page[cursor]":  {"bmV4dA"},
+		}, query)
+	})
+
+	t.Run("profile events filter is combined", func(t *testing.T) {
+		_, err := kc.GetProfileEvents(ctx, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", getevents.WithFilter(filter.GreaterThan("datetime", since)))
+		require.NoError(t, err)
+		require.Equal(t, []string{`and(greater-than(datetime,2024-01-30T00:00:00Z),equals(profile_id,"01H8HKMDG8F4MN7PSRZ4YQYNVQ"))`}, query["filter"])
+		require.Equal(t, []string{"-datetime"}, query["sort"])
+	})
+}
+
+func TestClient_GetEvents_Include(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "profile,metric", req.URL.Query().Get("include"))
+		return jsonResponse(http.StatusOK, `{
+			"data":[
+				{"type":"event","id":"evt1","attributes":{},"relationships":{
+					"profile":{"data":{"type":"profile","id":"p1"}},
+					"metric":{"data":{"type":"metric","id":"m1"}}}},
+				{"type":"event","id":"evt2","attributes":{},"relationships":{
+					"profile":{"data":{"type":"profile","id":"p2"}}}}
+			],
+			"included":[
+				{"type":"metric","id":"m1","attributes":{"name":"Placed Order"}},
+				{"type":"profile","id":"p1","attributes":{"email":"john@example.com"}}
+			]
+		}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	events, err := kc.GetEvents(context.TODO(), getevents.WithInclude("profile", "metric"))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.NotNil(t, events[0].Profile)
+	require.Equal(t, "p1", events[0].Profile.Id)
+	require.Equal(t, "john@example.com", events[0].Profile.Attributes.Email)
+	require.NotNil(t, events[0].Metric)
+	require.Equal(t, "Placed Order", events[0].Metric.Attributes.Name)
+
+	// the profile of the second event isn't included
+	require.Nil(t, events[1].Profile)
+	require.Nil(t, events[1].Metric)
+}
+
+func TestClient_CreateEvent_StructuredProperties(t *testing.T) {
+	var body string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+		return jsonResponse(http.StatusAccepted, ""), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	e := event.PlacedOrder(event.Order{
+		OrderID: "1001",
+		Value:   9.99,
+		Items:   []event.Item{{ProductID: "p1", SKU: "sku-1", ProductName: "Mug", Quantity: 1, ItemPrice: 9.99}},
+	})
+	e.Properties = map[string]string{"Channel": "web"}
+
+	_, err := kc.CreateEvent(context.TODO(), e, "01HN6AFEHGF6F77WJRKT1C9JHG", event.MetricPlacedOrder)
+	require.NoError(t, err)
+	require.Contains(t, body, `"value":9.99,"unique_id":"1001"`)
+	require.Contains(t, body, `"properties":{"Brands":[],"Categories":[],"Channel":"web","ItemNames":["Mug"],`+
+		`"Items":[{"Categories":[],"ItemPrice":9.99,"ProductID":"p1","ProductName":"Mug","Quantity":1,"RowTotal":9.99,"SKU":"sku-1"}],`+
+		`"OrderId":"1001"}`)
+	require.Contains(t, body, `"name":"Placed Order"`)
+}
+
+func TestClient_CreateEvent_StructuredPropertiesSerialized(t *testing.T) {
+	t.Run("registered serializers apply to structured properties", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithPropertySerializer(func(c cents) (interface{}, error) {
+			return fmt.Sprintf("%d.%02d", c/100, c%100), nil
+		}))
+
+		e := &event.NewEvent{NewAttributes: event.NewAttributes{
+			StructuredProperties: map[string]interface{}{"Discount": cents(250), "Rate": 1e21},
+		}}
+
+		_, err := kc.CreateEvent(context.TODO(), e, "01HN6AFEHGF6F77WJRKT1C9JHG", "Applied Coupon")
+		require.NoError(t, err)
+		require.Contains(t, body, `"properties":{"Discount":"2.50","Rate":1000000000000000000000}`)
+	})
+
+	t.Run("invalid structured properties are rejected", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithPropertyValidation(klaviyo.PropertyValidationError))
+
+		e := &event.NewEvent{NewAttributes: event.NewAttributes{
+			StructuredProperties: map[string]interface{}{"Score": math.NaN()},
+		}}
+
+		_, err := kc.CreateEvent(context.TODO(), e, "01HN6AFEHGF6F77WJRKT1C9JHG", "Applied Coupon")
+
+		var invalid *klaviyo.ErrInvalidPropertyValue
+		require.ErrorAs(t, err, &invalid)
+		require.Equal(t, "Score", invalid.Key)
+	})
+}
+
+func TestClient_CreateEvent_ValueCurrency(t *testing.T) {
+	var body string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		body = string(b)
+		return jsonResponse(http.StatusAccepted, ""), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	e := event.StartedCheckout(event.Checkout{CheckoutID: "chk-1", Total: event.Money{Amount: 1250, Currency: "EUR"}})
+
+	_, err := kc.CreateEvent(context.TODO(), e, "01HN6AFEHGF6F77WJRKT1C9JHG", event.MetricStartedCheckout)
+	require.NoError(t, err)
+	require.Contains(t, body, `"value":12.5,"value_currency":"EUR","unique_id":"chk-1"`)
+}
+
+func BenchmarkClient_CreateEvent(b *testing.B) {
+	kc := newOverheadClient(zap.NewNop())
+	ctx := context.Background()
+	e := event.PlacedOrder(event.Order{
+		Time:    time.Date(2023, 8, 15, 12, 0, 0, 0, time.UTC),
+		OrderID: "1001",
+		Value:   29.98,
+		Items: []event.Item{
+			{ProductID: "p1", SKU: "sku-1", ProductName: "Mug", Quantity: 1, ItemPrice: 9.99, Categories: []string{"Kitchen"}},
+			{ProductID: "p2", SKU: "sku-2", ProductName: "Teapot", Quantity: 1, ItemPrice: 19.99, Categories: []string{"Kitchen"}},
+		},
+	})
+	e.Properties = map[string]string{"Channel": "web"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := kc.CreateEvent(ctx, e, "01GDDKASAP8TKDDA2GRZDSVP4H", event.MetricPlacedOrder); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
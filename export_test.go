@@ -0,0 +1,36 @@
+package klaviyo
+
+import (
+	"net/http"
+)
+
+// TransportFunc executes a request of the operation and returns the response, standing in for the transport
+// of the client in tests, e.g. to inject latency, 429 storms or truncated bodies.
+type TransportFunc func(op Operation, req *http.Request) (statusCode int, header http.Header, body []byte, err error)
+
+// SetTransport replaces the transport of the client with f. The function receives the default transport
+// of the client to delegate the requests that are not faulted.
+func SetTransport(c *Client, f func(next TransportFunc) TransportFunc) {
+	next := c.transport
+	faulty := f(func(op Operation, req *http.Request) (int, http.Header, []byte, error) {
+		resp, err := next.roundTrip(op, req)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		return resp.statusCode, resp.header, resp.body, nil
+	})
+	c.transport = transportFunc(func(op Operation, req *http.Request) (*rawResponse, error) {
+		statusCode, header, body, err := faulty(op, req)
+		if err != nil {
+			return nil, err
+		}
+		return &rawResponse{statusCode: statusCode, header: header, body: body}, nil
+	})
+}
+
+// DefaultTransport returns the default transport of the clients created by New with the options,
+// or nil if the options don't configure it.
+func DefaultTransport(opts ...Option) *http.Transport {
+	t, _ := newOptions(opts).transport().(*http.Transport)
+	return t
+}
@@ -0,0 +1,178 @@
+// Package filter builds expressions of the Klaviyo filter syntax used by the filter query parameter,
+// e.g. And(Equals("email", "sarah.mason@klaviyo-demo.com"), GreaterThan("created", t)).
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expr is a filter expression. The zero value is the empty expression, which matches everything
+// and is omitted from requests.
+type Expr struct {
+	s string
+}
+
+// String returns the expression in the Klaviyo filter syntax.
+func (e Expr) String() string {
+	return e.s
+}
+
+// IsEmpty reports whether the expression is empty.
+func (e Expr) IsEmpty() bool {
+	return e.s == ""
+}
+
+// Raw returns an expression given in the Klaviyo filter syntax, e.g. for operators not covered by the builder.
+func Raw(expr string) Expr {
+	return Expr{s: expr}
+}
+
+// Equals matches the records whose field equals the value.
+func Equals(field string, value interface{}) Expr {
+	return compare("equals", field, value)
+}
+
+// LessThan matches the records whose field is less than the value.
+func LessThan(field string, value interface{}) Expr {
+	return compare("less-than", field, value)
+}
+
+// LessOrEqual matches the records whose field is less than or equal to the value.
+func LessOrEqual(field string, value interface{}) Expr {
+	return compare("less-or-equal", field, value)
+}
+
+// GreaterThan matches the records whose field is greater than the value.
+func GreaterThan(field string, value interface{}) Expr {
+	return compare("greater-than", field, value)
+}
+
+// GreaterOrEqual matches the records whose field is greater than or equal to the value.
+func GreaterOrEqual(field string, value interface{}) Expr {
+	return compare("greater-or-equal", field, value)
+}
+
+// Contains matches the records whose field contains the value, e.g. a substring or an element of an array.
+func Contains(field string, value interface{}) Expr {
+	return compare("contains", field, value)
+}
+
+// ContainsAny matches the records whose array field contains any of the values.
+func ContainsAny(field string, values ...interface{}) Expr {
+	return compare("contains-any", field, values)
+}
+
+// ContainsAll matches the records whose array field contains all the values.
+func ContainsAll(field string, values ...interface{}) Expr {
+	return compare("contains-all", field, values)
+}
+
+// StartsWith matches the records whose field starts with the prefix.
+func StartsWith(field, prefix string) Expr {
+	return compare("starts-with", field, prefix)
+}
+
+// EndsWith matches the records whose field ends with the suffix.
+func EndsWith(field, suffix string) Expr {
+	return compare("ends-with", field, suffix)
+}
+
+// Any matches the records whose field equals any of the values.
+func Any(field string, values ...interface{}) Expr {
+	return compare("any", field, values)
+}
+
+// AnyString matches the records whose field equals any of the strings.
+func AnyString(field string, values ...string) Expr {
+	return compare("any", field, values)
+}
+
+// Has matches the records that have the field set.
+func Has(field string) Expr {
+	return Expr{s: "has(" + field + ")"}
+}
+
+// And matches the records matching all the expressions. Empty expressions are skipped.
+func And(exprs ...Expr) Expr {
+	return combine("and", exprs)
+}
+
+// Or matches the records matching any of the expressions. Empty expressions are skipped.
+func Or(exprs ...Expr) Expr {
+	return combine("or", exprs)
+}
+
+// Not matches the records not matching the expression.
+func Not(expr Expr) Expr {
+	if expr.IsEmpty() {
+		return expr
+	}
+	return Expr{s: "not(" + expr.s + ")"}
+}
+
+func compare(op, field string, value interface{}) Expr {
+	return Expr{s: op + "(" + field + "," + formatValue(value) + ")"}
+}
+
+func combine(op string, exprs []Expr) Expr {
+	parts := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		if !e.IsEmpty() {
+			parts = append(parts, e.s)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return Expr{}
+	case 1:
+		return Expr{s: parts[0]}
+	}
+	return Expr{s: op + "(" + strings.Join(parts, ",") + ")"}
+}
+
+// formatValue formats the value in the filter syntax: strings are quoted, times are formatted as
+// RFC 3339 datetimes in UTC, nil is null and slices are lists.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return "null"
+		}
+		return v.UTC().Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case fmt.Stringer:
+		return strconv.Quote(v.String())
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = formatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	}
+	return strconv.Quote(fmt.Sprint(value))
+}
@@ -0,0 +1,50 @@
+package filter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/filter"
+)
+
+func TestExpr(t *testing.T) {
+	created := time.Date(2024, 1, 30, 13, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name string
+		expr filter.Expr
+		want string
+	}{
+		{name: "equals string", expr: filter.Equals("email", `sarah."sam".mason@klaviyo-demo.com`), want: `equals(email,"sarah.\"sam\".mason@klaviyo-demo.com")`},
+		{name: "equals null", expr: filter.Equals("external_id", nil), want: `equals(external_id,null)`},
+		{name: "equals bool", expr: filter.Equals("properties.vip", true), want: `equals(properties.vip,true)`},
+		{name: "greater than datetime in UTC", expr: filter.GreaterThan("created", created), want: `greater-than(created,2024-01-30T12:00:00Z)`},
+		{name: "less or equal number", expr: filter.LessOrEqual("properties.score", 9.5), want: `less-or-equal(properties.score,9.5)`},
+		{name: "any strings", expr: filter.AnyString("id", "a", "b"), want: `any(id,["a","b"])`},
+		{name: "any mixed", expr: filter.Any("properties.level", 1, "gold"), want: `any(properties.level,[1,"gold"])`},
+		{name: "contains any", expr: filter.ContainsAny("properties.tags", "x", "y"), want: `contains-any(properties.tags,["x","y"])`},
+		{name: "starts with", expr: filter.StartsWith("email", "sarah"), want: `starts-with(email,"sarah")`},
+		{name: "has", expr: filter.Has("phone_number"), want: `has(phone_number)`},
+		{name: "not", expr: filter.Not(filter.Has("phone_number")), want: `not(has(phone_number))`},
+		{
+			name: "and with or",
+			expr: filter.And(
+				filter.Equals("email", "a@example.com"),
+				filter.Or(filter.Contains("first_name", "Sa"), filter.EndsWith("last_name", "son")),
+			),
+			want: `and(equals(email,"a@example.com"),or(contains(first_name,"Sa"),ends-with(last_name,"son")))`,
+		},
+		{name: "and skips empty expressions", expr: filter.And(filter.Expr{}, filter.Has("email")), want: `has(email)`},
+		{name: "empty and", expr: filter.And(), want: ``},
+		{name: "raw", expr: filter.Raw(`equals(email,"x@y.com")`), want: `equals(email,"x@y.com")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.expr.String())
+			require.Equal(t, tt.want == "", tt.expr.IsEmpty())
+		})
+	}
+}
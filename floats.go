@@ -0,0 +1,32 @@
+package klaviyo
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// formatDecimal formats the float as a plain decimal number, never in the exponent notation, rounded to
+// the given number of decimal places. A negative precision keeps the shortest representation that
+// round-trips to the same float.
+func formatDecimal(f float64, precision int) json.Number {
+	return json.Number(strconv.FormatFloat(f, 'f', precision, 64))
+}
+
+// decimalValue returns float values as plain decimal numbers formatted with the configured precision.
+// NaN and infinite values can't be represented and are returned as is, so the property validation reports them.
+func (c *Client) decimalValue(value interface{}) (interface{}, bool) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	default:
+		return value, false
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return value, false
+	}
+	return formatDecimal(f, c.options.floatPrecision), true
+}
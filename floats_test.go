@@ -0,0 +1,62 @@
+package klaviyo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/event"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/property"
+)
+
+func TestWithFloatPrecision(t *testing.T) {
+	recordBody := func(body *string, response string) *http.Client {
+		return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			*body = string(b)
+			return jsonResponse(http.StatusAccepted, response), nil
+		})}
+	}
+
+	tests := []struct {
+		name  string
+		opts  []klaviyo.Option
+		value float64
+		want  string
+	}{
+		{name: "large value", value: 1e21, want: `1000000000000000000000`},
+		{name: "small value", value: 1e-7, want: `0.0000001`},
+		{name: "shortest representation", value: 19.99, want: `19.99`},
+		{name: "rounded to precision", opts: []klaviyo.Option{klaviyo.WithFloatPrecision(2)}, value: 1234567.891, want: `1234567.89`},
+	}
+
+	for _, tt := range tests {
+		t.Run("event "+tt.name, func(t *testing.T) {
+			var body string
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), recordBody(&body, ""), tt.opts...)
+
+			e := event.NewEvent{NewAttributes: event.NewAttributes{Time: "2024-01-30T05:10:00Z", Value: tt.value}}
+			_, err := kc.CreateEvent(context.TODO(), &e, "01HN6AFEHGF6F77WJRKT1C9JHG", "Placed Order")
+			require.NoError(t, err)
+			require.Contains(t, body, `"value":`+tt.want+`,`)
+		})
+
+		t.Run("profile property "+tt.name, func(t *testing.T) {
+			var body string
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(),
+				recordBody(&body, `{"data":{"type":"profile","id":"01GVCF3FZ5W7YCE0MW4ZCBVWXM","attributes":{}}}`), tt.opts...)
+
+			_, err := kc.UpdateProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM",
+				profile.WithProperties(property.WithValue("ltv", map[string]interface{}{"total": tt.value})),
+			)
+			require.NoError(t, err)
+			require.Contains(t, body, `"ltv":{"total":`+tt.want+`}`)
+		})
+	}
+}
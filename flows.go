@@ -0,0 +1,67 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/monetha/go-klaviyo/models/flow"
+)
+
+const (
+	flowsPath       = "flows"
+	flowActionsPath = "flow-actions"
+)
+
+// GetFlows retrieves all the flows of the account from Klaviyo, fetching every page.
+func (c *Client) GetFlows(ctx context.Context) ([]*flow.ExistingFlow, error) {
+	return allPages(ctx, newPaginator(c, c.getFlowsPage, url.Values{}))
+}
+
+// getFlowsPage retrieves a single page of flows and returns the cursor of the next page, if any.
+func (c *Client) getFlowsPage(ctx context.Context, fields url.Values) ([]*flow.ExistingFlow, string, error) {
+	var result struct {
+		Data  []*flow.ExistingFlow `json:"data"`
+		Links links                `json:"links"`
+	}
+	if err := c.doReq(ctx, OperationGetFlows, http.MethodGet, flowsPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data, result.Links.nextCursor(), nil
+}
+
+// GetFlow retrieves a specific flow by its ID from Klaviyo.
+func (c *Client) GetFlow(ctx context.Context, flowID string) (*flow.ExistingFlow, error) {
+	endpoint := path.Join(flowsPath, flowID)
+
+	var result struct {
+		Data flow.ExistingFlow `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetFlow, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// GetFlowActions retrieves all the actions of a specific flow from Klaviyo, fetching every page, including their
+// configuration details, e.g. the URLs and templates of webhook actions.
+func (c *Client) GetFlowActions(ctx context.Context, flowID string) ([]*flow.ExistingAction, error) {
+	endpoint := path.Join(flowsPath, flowID, flowActionsPath)
+
+	getPage := func(ctx context.Context, fields url.Values) ([]*flow.ExistingAction, string, error) {
+		var result struct {
+			Data  []*flow.ExistingAction `json:"data"`
+			Links links                  `json:"links"`
+		}
+		if err := c.doReq(ctx, OperationGetFlowActions, http.MethodGet, endpoint, fields, nil, &result); err != nil {
+			return nil, "", err
+		}
+
+		return result.Data, result.Links.nextCursor(), nil
+	}
+
+	return allPages(ctx, newPaginator(c, getPage, url.Values{}))
+}
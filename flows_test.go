@@ -0,0 +1,80 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_GetFlows(t *testing.T) {
+	var cursors []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/flows", req.URL.Path)
+		cursor := req.URL.Query().Get("page[cursor]")
+		cursors = append(cursors, cursor)
+		if cursor == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"flow","id":"XVTP5Q","attributes":{"name":"Welcome Series","status":"live"}}],`+
+				`"links":{"next":"https://a.klaviyo.com/api/flows/?page%5Bcursor%5D=cDI"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"flow","id":"Y6nRLr","attributes":{"name":"Abandoned Cart","status":"draft"}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	flows, err := kc.GetFlows(context.TODO())
+
+	require.NoError(t, err)
+	require.Len(t, flows, 2)
+	require.Equal(t, "XVTP5Q", flows[0].ID)
+	require.Equal(t, "Abandoned Cart", flows[1].Attributes.Name)
+	require.Equal(t, []string{"", "cDI"}, cursors)
+}
+
+func TestClient_GetFlowActions(t *testing.T) {
+	t.Run("actions of all pages are returned", func(t *testing.T) {
+		var cursors []string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/api/flows/XVTP5Q/flow-actions", req.URL.Path)
+			cursor := req.URL.Query().Get("page[cursor]")
+			cursors = append(cursors, cursor)
+			if cursor == "" {
+				return jsonResponse(http.StatusOK, `{"data":[{"type":"flow-action","id":"1","attributes":{"action_type":"SEND_EMAIL"}}],`+
+					`"links":{"next":"https://a.klaviyo.com/api/flows/XVTP5Q/flow-actions/?page%5Bcursor%5D=cDI"}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"flow-action","id":"2","attributes":{"action_type":"WEBHOOK",`+
+				`"settings":{"url":"https://hooks.example.com/klaviyo"}}}],"links":{"next":null}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		actions, err := kc.GetFlowActions(context.TODO(), "XVTP5Q")
+
+		require.NoError(t, err)
+		require.Len(t, actions, 2)
+		require.False(t, actions[0].IsWebhook())
+		require.Equal(t, "https://hooks.example.com/klaviyo", actions[1].WebhookURL())
+		require.Equal(t, []string{"", "cDI"}, cursors)
+	})
+
+	t.Run("failure of a later page fails the call", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("page[cursor]") == "" {
+				return jsonResponse(http.StatusOK, `{"data":[{"type":"flow-action","id":"1","attributes":{}}],`+
+					`"links":{"next":"https://a.klaviyo.com/api/flows/XVTP5Q/flow-actions/?page%5Bcursor%5D=cDI"}}`), nil
+			}
+			return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"e1","status":400,"code":"invalid","title":"Invalid input."}]}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		actions, err := kc.GetFlowActions(context.TODO(), "XVTP5Q")
+
+		require.Error(t, err)
+		require.Nil(t, actions)
+	})
+}
@@ -0,0 +1,38 @@
+package klaviyo
+
+import (
+	"fmt"
+)
+
+// ErrOperationNotAllowed is returned when the client is configured not to perform the operation,
+// see WithAllowedOperations and WithDeniedOperations. The request is not sent.
+type ErrOperationNotAllowed struct {
+	Operation Operation
+}
+
+// Error returns a string representation of the ErrOperationNotAllowed error.
+// It conforms to the error interface.
+func (e *ErrOperationNotAllowed) Error() string {
+	return fmt.Sprintf("klaviyo: operation %s is not allowed", e.Operation)
+}
+
+// allows reports whether the client is allowed to perform the operation. Denied operations are never allowed;
+// if an allowlist is configured, only the operations in it are allowed.
+func (o *Options) allows(op Operation) bool {
+	if _, denied := o.deniedOps[op]; denied {
+		return false
+	}
+	if o.allowedOps == nil {
+		return true
+	}
+	_, ok := o.allowedOps[op]
+	return ok
+}
+
+// checkOperation returns ErrOperationNotAllowed if the client is not allowed to perform the operation.
+func (c *Client) checkOperation(op Operation) error {
+	if !c.options.allows(op) {
+		return &ErrOperationNotAllowed{Operation: op}
+	}
+	return nil
+}
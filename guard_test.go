@@ -0,0 +1,49 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestWithAllowedOperations(t *testing.T) {
+	var requests int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01GVCF3FZ5W7YCE0MW4ZCBVWXM","attributes":{}}}`), nil
+	})}
+
+	t.Run("only allowed operations are performed", func(t *testing.T) {
+		requests = 0
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c,
+			klaviyo.WithAllowedOperations(klaviyo.OperationCreateEvent, klaviyo.OperationGetProfile),
+		)
+
+		_, err := kc.GetProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM")
+		require.NoError(t, err)
+
+		err = kc.CancelCampaignSendJob(context.TODO(), "01HN6AFEHGF6F77WJRKT1C9JHA")
+		var e *klaviyo.ErrOperationNotAllowed
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, klaviyo.OperationUpdateCampaignSendJob, e.Operation)
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("denied operations take precedence", func(t *testing.T) {
+		requests = 0
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c,
+			klaviyo.WithAllowedOperations(klaviyo.OperationGetProfile),
+			klaviyo.WithDeniedOperations(klaviyo.OperationGetProfile),
+		)
+
+		_, err := kc.GetProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM")
+		var e *klaviyo.ErrOperationNotAllowed
+		require.ErrorAs(t, err, &e)
+		require.Zero(t, requests)
+	})
+}
@@ -0,0 +1,65 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// blockedHeaders lists the canonical names of the headers that can't be set per call: the authentication headers
+// and the headers managed by the client, which select the API revision and the format of the bodies.
+var blockedHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"Revision":            {},
+	"Accept":              {},
+	"Content-Type":        {},
+}
+
+// ErrHeaderNotAllowed indicates that an attempt was made to set a per-call header
+// which is reserved for authentication or managed by the client and can't be overridden.
+type ErrHeaderNotAllowed struct {
+	Header string
+}
+
+// Error returns a string representation of the ErrHeaderNotAllowed error.
+// It conforms to the error interface.
+func (e *ErrHeaderNotAllowed) Error() string {
+	return fmt.Sprintf("klaviyo: header %q is not allowed to be set per call", e.Header)
+}
+
+type headersContextKey struct{}
+
+// WithHeader returns a copy of the context that makes the client send an additional header
+// with every request performed using the returned context, e.g. experiment flags or trace headers
+// required by the egress infrastructure. Authentication headers and the headers managed by the client
+// (revision, accept and content-type) are not allowed, requests made with such header fail with ErrHeaderNotAllowed.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	headers := http.Header{}
+	if parent, ok := ctx.Value(headersContextKey{}).(http.Header); ok {
+		headers = parent.Clone()
+	}
+	headers.Add(key, value)
+	return context.WithValue(ctx, headersContextKey{}, headers)
+}
+
+// setContextHeaders sets the per-call headers stored in the context of the request.
+func setContextHeaders(req *http.Request) error {
+	headers, ok := req.Context().Value(headersContextKey{}).(http.Header)
+	if !ok {
+		return nil
+	}
+
+	for key, values := range headers {
+		if _, blocked := blockedHeaders[key]; blocked {
+			return &ErrHeaderNotAllowed{Header: key}
+		}
+		req.Header.Del(key)
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package klaviyo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestWithHeader(t *testing.T) {
+	t.Run("custom header is sent with the request", func(t *testing.T) {
+		var got http.Header
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			got = req.Header.Clone()
+			return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ctx := klaviyo.WithHeader(context.TODO(), "X-Experiment", "new-checkout")
+		_, err := kc.GetProfiles(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, "new-checkout", got.Get("X-Experiment"))
+		require.Equal(t, "Klaviyo-API-Key "+validAPIKey, got.Get("Authorization"))
+	})
+
+	t.Run("authentication header is not allowed", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ctx := klaviyo.WithHeader(context.TODO(), "authorization", "Bearer token")
+		ps, err := kc.GetProfiles(ctx)
+
+		var e *klaviyo.ErrHeaderNotAllowed
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "Authorization", e.Header)
+		require.Nil(t, ps)
+	})
+
+	t.Run("client-managed headers are not allowed", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		for _, header := range []string{"revision", "Accept", "content-type"} {
+			ctx := klaviyo.WithHeader(context.TODO(), header, "2024-02-15")
+			_, err := kc.GetProfiles(ctx)
+
+			var e *klaviyo.ErrHeaderNotAllowed
+			require.ErrorAs(t, err, &e, header)
+			require.Equal(t, http.CanonicalHeaderKey(header), e.Header)
+		}
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func jsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/vnd.api+json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
@@ -0,0 +1,55 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/monetha/go-klaviyo/internal/singleflight"
+)
+
+const accountsPath = "accounts"
+
+// ping performs a cheap authenticated call that fails if the API key is invalid or revoked.
+func (c *Client) ping(ctx context.Context) error {
+	return c.doReq(ctx, OperationGetAccounts, http.MethodGet, accountsPath, nil, nil, nil)
+}
+
+// HealthChecker returns a check suitable for readiness probes that fails when the client can't perform
+// authenticated calls, e.g. because the API key is invalid or revoked (ErrInvalidAPIKey).
+// The result of the check is cached for interval, so probes don't consume the rate limit of the API;
+// concurrent checks share the call in progress, and a check returns the error of its context as soon as
+// the context is done. The API key requires the accounts:read scope.
+func HealthChecker(c *Client, interval time.Duration) func(ctx context.Context) error {
+	var (
+		mu        sync.Mutex
+		checkedAt time.Time
+		checked   bool
+		lastErr   error
+		pings     singleflight.Group[struct{}]
+	)
+
+	return func(ctx context.Context) error {
+		mu.Lock()
+		if checked && c.options.clock.Now().Sub(checkedAt) < interval {
+			mu.Unlock()
+			return lastErr
+		}
+		mu.Unlock()
+
+		_, _, err := pings.Do(ctx, accountsPath, func(ctx context.Context) (struct{}, error) {
+			now := c.options.clock.Now()
+			err := c.ping(ctx)
+			if ctx.Err() != nil {
+				// all the probes gave up, the result says nothing about the API
+				return struct{}{}, err
+			}
+			mu.Lock()
+			checked, checkedAt, lastErr = true, now, err
+			mu.Unlock()
+			return struct{}{}, err
+		})
+		return err
+	}
+}
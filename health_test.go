@@ -0,0 +1,77 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+func TestHealthChecker(t *testing.T) {
+	const unauthorized = `{"errors":[{"id":"b3c1f0e2-5a6d-4c8e-9f1a-2d3e4f5a6b7c","status":401,"code":"not_authenticated","title":"Authentication credentials were not provided.","detail":"Missing or invalid private key."}]}`
+
+	var (
+		requests int
+		revoked  bool
+	)
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		require.Equal(t, "/api/accounts", req.URL.Path)
+		if revoked {
+			return jsonResponse(http.StatusUnauthorized, unauthorized), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"account","id":"AbC123","attributes":{}}]}`), nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+	check := klaviyo.HealthChecker(kc, time.Minute)
+	ctx := context.TODO()
+
+	require.NoError(t, check(ctx))
+	require.NoError(t, check(ctx))
+	require.Equal(t, 1, requests, "result is cached for the interval")
+
+	revoked = true
+	clk.Advance(time.Minute)
+	require.ErrorIs(t, check(ctx), klaviyo.ErrInvalidAPIKey)
+	require.ErrorIs(t, check(ctx), klaviyo.ErrInvalidAPIKey)
+	require.Equal(t, 2, requests, "failure is cached for the interval")
+
+	revoked = false
+	clk.Advance(time.Minute)
+	require.NoError(t, check(ctx))
+	require.Equal(t, 3, requests)
+}
+
+func TestHealthChecker_ContextDone(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		started <- struct{}{}
+		<-release
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"account","id":"AbC123","attributes":{}}]}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	check := klaviyo.HealthChecker(kc, time.Minute)
+
+	waiting := make(chan error, 1)
+	go func() { waiting <- check(context.Background()) }()
+	<-started
+
+	// a probe giving up doesn't wait for the check in progress, nor fail the other probes
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, check(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, <-waiting)
+	require.NoError(t, check(context.Background()), "result of the shared check is cached")
+}
@@ -0,0 +1,90 @@
+package klaviyo
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Family identifies a family of Klaviyo endpoints served from the same base URL.
+type Family string
+
+// Families of Klaviyo endpoints.
+const (
+	// FamilyREST is the server-side REST API authenticated with a private API key.
+	FamilyREST Family = "rest"
+	// FamilyClient is the client-side API authenticated with a public API key.
+	FamilyClient Family = "client"
+	// FamilyStatic serves static assets, e.g. the onsite JavaScript.
+	FamilyStatic Family = "static"
+)
+
+// defaultHosts holds the default base URLs of the endpoint families.
+var defaultHosts = map[Family]string{
+	FamilyREST:   restAPIHost,
+	FamilyClient: clientAPIHost,
+	FamilyStatic: staticHost,
+}
+
+// ErrInvalidHost is returned by New, and by the requests of a client created with NewWithClient,
+// when WithHost is given an unknown family or a base URL that isn't absolute.
+type ErrInvalidHost struct {
+	Family Family
+	Reason string
+}
+
+// Error returns a string representation of the ErrInvalidHost error.
+// It conforms to the error interface.
+func (e *ErrInvalidHost) Error() string {
+	return fmt.Sprintf("klaviyo: invalid host of the %q family: %s", e.Family, e.Reason)
+}
+
+// validateHost returns an *ErrInvalidHost if the base URL can't serve the family.
+func validateHost(family Family, baseURL *url.URL) error {
+	if _, ok := defaultHosts[family]; !ok {
+		return &ErrInvalidHost{Family: family, Reason: "unknown family"}
+	}
+	if baseURL == nil || !baseURL.IsAbs() || baseURL.Host == "" {
+		return &ErrInvalidHost{Family: family, Reason: "the base URL must be an absolute URL"}
+	}
+	return nil
+}
+
+// family returns the family of the endpoints of the operation.
+// All the operations currently performed by the client belong to the REST API; the base URLs of the other
+// families are available with BaseURL for the requests the client doesn't perform itself.
+func (op Operation) family() Family {
+	return FamilyREST
+}
+
+// newHosts returns the base URLs of the endpoint families, taking the valid overrides of the options into account.
+func newHosts(o *Options) map[Family]*url.URL {
+	hosts := make(map[Family]*url.URL, len(defaultHosts))
+	for family, host := range defaultHosts {
+		u, err := url.Parse(host)
+		if err != nil {
+			panic(err)
+		}
+		hosts[family] = u
+	}
+	for family, u := range o.hosts {
+		hosts[family] = u
+	}
+	return hosts
+}
+
+// baseURL returns a copy of the base URL of the endpoints of the operation.
+func (c *Client) baseURL(op Operation) url.URL {
+	return *c.hosts[op.family()]
+}
+
+// BaseURL returns a copy of the base URL of the family of endpoints, taking WithHost into account,
+// e.g. to call the client-side API or to load the static assets from the configured hosts.
+// It returns an *ErrInvalidHost for an unknown family.
+func (c *Client) BaseURL(family Family) (*url.URL, error) {
+	u, ok := c.hosts[family]
+	if !ok {
+		return nil, &ErrInvalidHost{Family: family, Reason: "unknown family"}
+	}
+	cp := *u
+	return &cp, nil
+}
@@ -0,0 +1,96 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestWithHost(t *testing.T) {
+	t.Run("REST API requests are sent to the overridden host", func(t *testing.T) {
+		var got *url.URL
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			got = req.URL
+			return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+		})}
+
+		gateway, err := url.Parse("https://eu-gateway.example.com/klaviyo/api")
+		require.NoError(t, err)
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithHost(klaviyo.FamilyREST, gateway))
+
+		_, err = kc.GetProfiles(context.TODO())
+		require.NoError(t, err)
+		require.Equal(t, "eu-gateway.example.com", got.Host)
+		require.Equal(t, "/klaviyo/api/profiles", got.Path)
+	})
+
+	t.Run("other families don't affect REST API requests", func(t *testing.T) {
+		var got *url.URL
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			got = req.URL
+			return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+		})}
+
+		static, err := url.Parse("https://cdn.example.com")
+		require.NoError(t, err)
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithHost(klaviyo.FamilyStatic, static))
+
+		_, err = kc.GetProfiles(context.TODO())
+		require.NoError(t, err)
+		require.Equal(t, "a.klaviyo.com", got.Host)
+		require.Equal(t, "/api/profiles", got.Path)
+	})
+
+	t.Run("the base URLs of the families honor the overrides", func(t *testing.T) {
+		static, err := url.Parse("https://cdn.example.com")
+		require.NoError(t, err)
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), http.DefaultClient, klaviyo.WithHost(klaviyo.FamilyStatic, static))
+
+		u, err := kc.BaseURL(klaviyo.FamilyStatic)
+		require.NoError(t, err)
+		require.Equal(t, "https://cdn.example.com", u.String())
+
+		u, err = kc.BaseURL(klaviyo.FamilyClient)
+		require.NoError(t, err)
+		require.Equal(t, "https://a.klaviyo.com/client", u.String())
+
+		var e *klaviyo.ErrInvalidHost
+		_, err = kc.BaseURL("unknown")
+		require.ErrorAs(t, err, &e)
+	})
+
+	t.Run("invalid hosts are rejected", func(t *testing.T) {
+		relative, err := url.Parse("/klaviyo/api")
+		require.NoError(t, err)
+
+		for name, opt := range map[string]klaviyo.Option{
+			"nil base URL":      klaviyo.WithHost(klaviyo.FamilyREST, nil),
+			"relative base URL": klaviyo.WithHost(klaviyo.FamilyREST, relative),
+			"unknown family":    klaviyo.WithHost("unknown", &url.URL{Scheme: "https", Host: "example.com"}),
+		} {
+			t.Run(name, func(t *testing.T) {
+				var e *klaviyo.ErrInvalidHost
+
+				_, err := klaviyo.New(validAPIKey, zap.L(), opt)
+				require.ErrorAs(t, err, &e)
+
+				c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					t.Fatal("unexpected request")
+					return nil, nil
+				})}
+				kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, opt)
+				_, err = kc.GetProfiles(context.TODO())
+				require.ErrorAs(t, err, &e)
+			})
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package klaviyo
+
+import (
+	"encoding/json"
+
+	"github.com/monetha/go-klaviyo/models/event"
+	"github.com/monetha/go-klaviyo/models/metric"
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+// includedResource is a resource of the top-level included array of a JSON:API response.
+type includedResource struct {
+	Type string
+	ID   string
+	Raw  json.RawMessage
+}
+
+// UnmarshalJSON decodes the type and the ID of the resource and keeps the raw resource to decode it on demand.
+func (r *includedResource) UnmarshalJSON(data []byte) error {
+	var head struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	r.Type, r.ID = head.Type, head.ID
+	r.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// included holds the related resources included in a response with the include parameter.
+type included []includedResource
+
+// decode decodes the included resource with the given type and ID into v.
+// It returns false if the resource is not included.
+func (in included) decode(typ, id string, v interface{}) (bool, error) {
+	for _, r := range in {
+		if r.Type == typ && r.ID == id {
+			return true, json.Unmarshal(r.Raw, v)
+		}
+	}
+	return false, nil
+}
+
+// hydrateEvents attaches the included profiles and metrics to the events.
+func hydrateEvents(events []*event.ExistingEvent, in included) error {
+	if len(in) == 0 {
+		return nil
+	}
+	for _, e := range events {
+		if rel := e.Relationships.Profile.Data; rel != nil {
+			p := new(profile.ExistingProfile)
+			ok, err := in.decode(rel.Type, rel.ID, p)
+			if err != nil {
+				return err
+			}
+			if ok {
+				e.Profile = p
+			}
+		}
+		if rel := e.Relationships.Metric.Data; rel != nil {
+			m := new(metric.ExistingMetric)
+			ok, err := in.decode(rel.Type, rel.ID, m)
+			if err != nil {
+				return err
+			}
+			if ok {
+				e.Metric = m
+			}
+		}
+	}
+	return nil
+}
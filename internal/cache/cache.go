@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// TTL is a concurrency-safe string cache, which expires entries after a fixed time to live.
+type TTL struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	value   string
+	expires time.Time
+}
+
+// NewTTL creates a new cache expiring entries after the given time to live.
+// The now function is used to get the current time.
+func NewTTL(ttl time.Duration, now func() time.Time) *TTL {
+	return &TTL{
+		ttl:     ttl,
+		now:     now,
+		entries: map[string]entry{},
+	}
+}
+
+// Get returns the value stored for the key, if it is present and not expired.
+func (c *TTL) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return e.value, true
+}
+
+// Set stores the value for the key.
+func (c *TTL) Set(key, value string) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry{
+		value:   value,
+		expires: c.now().Add(c.ttl),
+	}
+}
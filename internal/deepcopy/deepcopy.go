@@ -0,0 +1,62 @@
+// Package deepcopy provides helpers to deep copy the values of models.
+package deepcopy
+
+// Ptr returns a pointer to a copy of the value p points to, or nil if p is nil.
+func Ptr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// Map returns a deep copy of the map, or nil if m is nil.
+func Map(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = Value(v)
+	}
+	return c
+}
+
+// StringMap returns a copy of the map, or nil if m is nil.
+func StringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// Value returns a deep copy of the value, as decoded from JSON or built by callers: maps and slices
+// of interface values and strings are copied recursively; other values are returned as is.
+func Value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return Map(v)
+	case map[string]string:
+		return StringMap(v)
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			c[i] = Value(e)
+		}
+		return c
+	case []string:
+		if v == nil {
+			return v
+		}
+		return append([]string(nil), v...)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,74 @@
+// Package flatten provides helpers to flatten the nested values of models into flat maps, e.g. for exports.
+package flatten
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// String stores the value in the map formatted as a string, flattening nested maps up to the maximum depth.
+// Keys of nested values are joined with the separator. Values nested deeper than the maximum depth and
+// arrays are serialized as JSON; zero maximum depth means no limit.
+func String(m map[string]string, key string, value interface{}, sep string, depth, maxDepth int) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		if maxDepth > 0 && depth >= maxDepth {
+			m[key] = JSON(v)
+			return
+		}
+		for k, nested := range v {
+			String(m, key+sep+k, nested, sep, depth+1, maxDepth)
+		}
+	case string:
+		m[key] = v
+	case bool:
+		m[key] = strconv.FormatBool(v)
+	case float64:
+		m[key] = Float(v)
+	case json.Number:
+		m[key] = v.String()
+	case time.Time:
+		m[key] = v.Format(time.RFC3339)
+	case fmt.Stringer:
+		m[key] = v.String()
+	default:
+		m[key] = JSON(v)
+	}
+}
+
+// Value stores the value in the map as is, flattening nested maps up to the maximum depth like String.
+// Values nested deeper than the maximum depth are stored as maps.
+func Value(m map[string]interface{}, key string, value interface{}, sep string, depth, maxDepth int) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		if maxDepth > 0 && depth >= maxDepth {
+			m[key] = v
+			return
+		}
+		for k, nested := range v {
+			Value(m, key+sep+k, nested, sep, depth+1, maxDepth)
+		}
+	default:
+		m[key] = v
+	}
+}
+
+// Float formats the float without an exponent and with the minimal number of digits.
+func Float(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// JSON formats the value as JSON, or with the default format if it can't be serialized.
+func JSON(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -1,35 +1,144 @@
 package log
 
-import "go.uber.org/zap"
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Structured log field keys emitted by the client.
+const (
+	// FieldEndpoint is the key of the API endpoint path (without query parameters).
+	FieldEndpoint = "endpoint"
+	// FieldMethod is the key of the HTTP method.
+	FieldMethod = "method"
+	// FieldStatus is the key of the HTTP response status code.
+	FieldStatus = "status"
+	// FieldAttempt is the key of the 1-based attempt number of a request.
+	FieldAttempt = "attempt"
+	// FieldRequestID is the key of the request ID reported by the API.
+	FieldRequestID = "request_id"
+	// FieldDurationMs is the key of the request duration in milliseconds.
+	FieldDurationMs = "duration_ms"
+	// FieldOperation is the key of the name of the API operation, e.g. "GetProfile".
+	FieldOperation = "operation"
+)
+
+// performingRequestMessage is the debug message the retrying HTTP client logs before every attempt.
+// It is not logged, because RequestAttempt logs the attempt with the attempt number.
+const performingRequestMessage = "performing request"
 
 // LeveledZapLogger is a wrapper around zap.SugaredLogger that implements the LeveledLogger interface.
 // The LeveledLogger interface provides leveled logging with methods for logging messages at different levels (Error, Info, Debug, Warn).
 // The methods accept a message string and a variadic number of key-value pairs.
 type LeveledZapLogger struct {
-	sl *zap.SugaredLogger
+	sl   *zap.SugaredLogger
+	core zapcore.Core
 }
 
 // Error logs an error message with the given key-value pairs.
 func (l *LeveledZapLogger) Error(msg string, keysAndValues ...interface{}) {
-	l.sl.Errorw(msg, keysAndValues...)
+	l.sl.Errorw(msg, standardize(keysAndValues)...)
 }
 
 // Info logs an info message with the given key-value pairs.
 func (l *LeveledZapLogger) Info(msg string, keysAndValues ...interface{}) {
-	l.sl.Infow(msg, keysAndValues...)
+	l.sl.Infow(msg, standardize(keysAndValues)...)
 }
 
 // Debug logs a debug message with the given key-value pairs.
+// The "performing request" message of the retrying HTTP client is dropped in favor of RequestAttempt.
 func (l *LeveledZapLogger) Debug(msg string, keysAndValues ...interface{}) {
-	l.sl.Debugw(msg, keysAndValues...)
+	if msg == performingRequestMessage {
+		return
+	}
+	l.sl.Debugw(msg, standardize(keysAndValues)...)
 }
 
 // Warn logs a warning message with the given key-value pairs.
 func (l *LeveledZapLogger) Warn(msg string, keysAndValues ...interface{}) {
-	l.sl.Warnw(msg, keysAndValues...)
+	l.sl.Warnw(msg, standardize(keysAndValues)...)
+}
+
+// DebugEnabled reports whether debug messages are logged, so that the fields of debug messages
+// logged on hot paths are only built when they are needed.
+func (l *LeveledZapLogger) DebugEnabled() bool {
+	return l.core.Enabled(zapcore.DebugLevel)
+}
+
+// RequestAttempt logs the attempt of sending the request. The attempt number is 0-based, as reported by the retrying client.
+func (l *LeveledZapLogger) RequestAttempt(req *http.Request, attempt int) {
+	if !l.DebugEnabled() {
+		return
+	}
+	l.sl.Debugw("sending request",
+		FieldMethod, req.Method,
+		FieldEndpoint, req.URL.Path,
+		FieldAttempt, attempt+1,
+	)
 }
 
 // NewLeveledLogger returns a new instance of LeveledZapLogger by wrapping provided zap.Logger.
 func NewLeveledLogger(logger *zap.Logger) *LeveledZapLogger {
-	return &LeveledZapLogger{sl: logger.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+	return &LeveledZapLogger{sl: logger.WithOptions(zap.AddCallerSkip(1)).Sugar(), core: logger.Core()}
+}
+
+// standardize renames the keys used by the retrying HTTP client to the standard field keys.
+// URLs are logged as endpoint paths, so query parameters (e.g. filters containing emails) are not leaked into logs:
+// the "url" key, the "request" description of retried requests (e.g. "GET https://... (status: 429)")
+// and the URL of *url.Error errors are reduced to the path.
+func standardize(keysAndValues []interface{}) []interface{} {
+	out := keysAndValues[:0:0]
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			out = append(out, keysAndValues[i])
+			break
+		}
+		key, value := keysAndValues[i], keysAndValues[i+1]
+		switch key {
+		case "url":
+			key = FieldEndpoint
+			if u, ok := value.(*url.URL); ok && u != nil {
+				value = u.Path
+			}
+		case "request":
+			if desc, ok := value.(string); ok {
+				out = append(out, describedRequest(desc)...)
+				continue
+			}
+		case "error":
+			if err, ok := value.(*url.Error); ok {
+				value = &url.Error{Op: err.Op, URL: endpoint(err.URL), Err: err.Err}
+			}
+		}
+		out = append(out, key, value)
+	}
+	return out
+}
+
+// describedRequest returns the fields of the request described by the retrying HTTP client
+// as "METHOD URL" optionally followed by " (status: CODE)".
+func describedRequest(desc string) []interface{} {
+	method, rest, _ := strings.Cut(desc, " ")
+	rawURL, status, hasStatus := strings.Cut(rest, " (status: ")
+	fields := []interface{}{FieldMethod, method, FieldEndpoint, endpoint(rawURL)}
+	if hasStatus {
+		if code, err := strconv.Atoi(strings.TrimSuffix(status, ")")); err == nil {
+			fields = append(fields, FieldStatus, code)
+		}
+	}
+	return fields
+}
+
+// endpoint returns the path of the raw URL, or an empty string if the URL can't be parsed.
+func endpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
 }
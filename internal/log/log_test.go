@@ -0,0 +1,100 @@
+package log
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestStandardize(t *testing.T) {
+	u, err := url.Parse("https://a.klaviyo.com/api/profiles/?filter=equals(email,%22sarah.mason@klaviyo-demo.com%22)")
+	require.NoError(t, err)
+
+	t.Run("URL is logged as the endpoint path", func(t *testing.T) {
+		kvs := standardize([]interface{}{"method", http.MethodGet, "url", u})
+		require.Equal(t, []interface{}{"method", http.MethodGet, FieldEndpoint, "/api/profiles/"}, kvs)
+	})
+
+	t.Run("retried request is logged as its method, endpoint and status", func(t *testing.T) {
+		kvs := standardize([]interface{}{"request", "GET " + u.String() + " (status: 429)", "timeout", time.Second, "remaining", 3})
+		require.Equal(t, []interface{}{
+			FieldMethod, http.MethodGet,
+			FieldEndpoint, "/api/profiles/",
+			FieldStatus, 429,
+			"timeout", time.Second,
+			"remaining", 3,
+		}, kvs)
+	})
+
+	t.Run("retried request without a status", func(t *testing.T) {
+		kvs := standardize([]interface{}{"request", "POST " + u.String()})
+		require.Equal(t, []interface{}{FieldMethod, http.MethodPost, FieldEndpoint, "/api/profiles/"}, kvs)
+	})
+
+	t.Run("URL of the error is logged as the endpoint path", func(t *testing.T) {
+		kvs := standardize([]interface{}{"error", &url.Error{Op: "Get", URL: u.String(), Err: io.ErrUnexpectedEOF}})
+		require.Len(t, kvs, 2)
+		require.Equal(t, `Get "/api/profiles/": unexpected EOF`, kvs[1].(error).Error())
+	})
+
+	t.Run("other keys and values are kept", func(t *testing.T) {
+		kvs := standardize([]interface{}{"error", "boom", "url", "not a URL", "dangling"})
+		require.Equal(t, []interface{}{"error", "boom", FieldEndpoint, "not a URL", "dangling"}, kvs)
+	})
+}
+
+func TestLeveledZapLogger_RequestAttempt(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://a.klaviyo.com/api/profiles/?additional-fields[profile]=subscriptions", nil)
+	require.NoError(t, err)
+
+	t.Run("attempt is logged 1-based without the query", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		l := NewLeveledLogger(zap.New(core))
+
+		l.RequestAttempt(req, 1)
+
+		entries := logs.FilterMessage("sending request").All()
+		require.Len(t, entries, 1)
+		require.Equal(t, map[string]interface{}{
+			FieldMethod:   http.MethodPost,
+			FieldEndpoint: "/api/profiles/",
+			FieldAttempt:  int64(2),
+		}, entries[0].ContextMap())
+	})
+
+	t.Run("nothing is logged if debug is disabled", func(t *testing.T) {
+		core, logs := observer.New(zapcore.InfoLevel)
+		l := NewLeveledLogger(zap.New(core))
+
+		l.RequestAttempt(req, 0)
+
+		require.Zero(t, logs.Len())
+	})
+}
+
+func TestLeveledZapLogger_Debug(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := NewLeveledLogger(zap.New(core))
+
+	l.Debug(performingRequestMessage, "method", http.MethodGet, "url", &url.URL{Path: "/api/profiles/"})
+	l.Debug("retrying request", "request", `GET https://a.klaviyo.com/api/profiles/?filter=equals(email,"sarah.mason@klaviyo-demo.com") (status: 429)`,
+		"timeout", time.Second, "remaining", 3)
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	require.Equal(t, "retrying request", entry.Message)
+	require.Equal(t, map[string]interface{}{
+		FieldMethod:   http.MethodGet,
+		FieldEndpoint: "/api/profiles/",
+		FieldStatus:   int64(429),
+		"timeout":     time.Second,
+		"remaining":   int64(3),
+	}, entry.ContextMap())
+}
@@ -0,0 +1,123 @@
+// Package singleflight coalesces concurrent identical calls into a single execution.
+package singleflight
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// PanicError is the error returned to the callers sharing a call that panicked.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error returns a string representation of the PanicError error.
+// It conforms to the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: shared call panicked: %v\n\n%s", e.Value, e.Stack)
+}
+
+type call[T any] struct {
+	done    chan struct{}
+	val     T
+	err     error
+	panics  bool
+	callers int
+	cancel  context.CancelFunc
+}
+
+// Group coalesces calls with the same key. The zero value is ready to use.
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// Do executes fn and returns its result, unless a call with the same key is already in flight;
+// in that case, it waits for the call in flight and returns its result, and shared is true.
+//
+// The call runs on a context carrying the values of the context of the first caller, but not its deadline
+// or cancellation, so a caller giving up doesn't fail the others. A caller returns the error of its context
+// if the context is done before the call completes; the context of the call is canceled once all the callers
+// gave up. If fn panics, the first caller panics with a *PanicError and the others get it as the error.
+func (g *Group[T]) Do(ctx context.Context, key string, fn func(context.Context) (T, error)) (v T, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call[T])
+	}
+	c, shared := g.calls[key]
+	if !shared {
+		callCtx, cancel := context.WithCancel(detached{ctx})
+		c = &call[T]{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.callers++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.panics && !shared {
+			panic(c.err)
+		}
+		return c.val, shared, c.err
+	case <-ctx.Done():
+		g.leave(key, c)
+		return v, shared, ctx.Err()
+	}
+}
+
+// run executes the call and releases its callers.
+func (g *Group[T]) run(ctx context.Context, key string, c *call[T], fn func(context.Context) (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.panics, c.err = true, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+		g.mu.Lock()
+		if g.calls[key] == c {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		c.cancel()
+		close(c.done)
+	}()
+
+	c.val, c.err = fn(ctx)
+}
+
+// leave removes a caller that gave up waiting for the call, and cancels the call if it was the last one.
+func (g *Group[T]) leave(key string, c *call[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c.callers--
+	if c.callers > 0 {
+		return
+	}
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	c.cancel()
+}
+
+// waiting returns the number of callers waiting for the call with the key in flight.
+func (g *Group[T]) waiting(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c, ok := g.calls[key]; ok {
+		return c.callers
+	}
+	return 0
+}
+
+// detached is a context carrying the values of the parent context without its deadline and cancellation.
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detached) Done() <-chan struct{}               { return nil }
+func (detached) Err() error                          { return nil }
+func (d detached) Value(key interface{}) interface{} { return d.parent.Value(key) }
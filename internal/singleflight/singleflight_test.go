@@ -0,0 +1,172 @@
+package singleflight
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup_Do(t *testing.T) {
+	var (
+		g       Group[string]
+		calls   int32
+		release = make(chan struct{})
+		started = make(chan struct{})
+	)
+
+	fn := func(context.Context) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 3)
+	shared := make([]bool, 3)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], shared[0], _ = g.Do(context.Background(), "key", fn)
+	}()
+	<-started
+
+	for i := 1; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], shared[i], _ = g.Do(context.Background(), "key", func(context.Context) (string, error) {
+				t.Error("call must be coalesced")
+				return "", nil
+			})
+		}(i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := g.Do(ctx, "key", fn)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Eventually(t, func() bool { return g.waiting("key") == 3 }, time.Second, time.Millisecond)
+	require.Equal(t, 3, g.waiting("key"), "the canceled caller must leave")
+
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	require.Equal(t, []string{"value", "value", "value"}, results)
+	require.False(t, shared[0])
+}
+
+func TestGroup_Do_OwnerCanceled(t *testing.T) {
+	var (
+		g       Group[string]
+		release = make(chan struct{})
+		started = make(chan struct{})
+	)
+
+	fn := func(ctx context.Context) (string, error) {
+		close(started)
+		select {
+		case <-release:
+			return "value", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	ownerCtx, cancelOwner := context.WithCancel(context.Background())
+	ownerErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.Do(ownerCtx, "key", fn)
+		ownerErr <- err
+	}()
+	<-started
+
+	type result struct {
+		v      string
+		shared bool
+		err    error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		v, shared, err := g.Do(context.Background(), "key", fn)
+		waiter <- result{v, shared, err}
+	}()
+	require.Eventually(t, func() bool { return g.waiting("key") == 2 }, time.Second, time.Millisecond)
+
+	cancelOwner()
+	require.ErrorIs(t, <-ownerErr, context.Canceled)
+
+	close(release)
+	require.Equal(t, result{v: "value", shared: true}, <-waiter)
+}
+
+func TestGroup_Do_AllCallersCanceled(t *testing.T) {
+	var g Group[string]
+
+	callErr := make(chan error, 1)
+	started := make(chan struct{})
+	fn := func(ctx context.Context) (string, error) {
+		close(started)
+		<-ctx.Done()
+		callErr <- ctx.Err()
+		return "", ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := g.Do(ctx, "key", fn)
+		done <- err
+	}()
+	<-started
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	require.ErrorIs(t, <-callErr, context.Canceled)
+	require.Zero(t, g.waiting("key"))
+}
+
+func TestGroup_Do_Panic(t *testing.T) {
+	var (
+		g       Group[string]
+		release = make(chan struct{})
+		started = make(chan struct{})
+	)
+
+	fn := func(context.Context) (string, error) {
+		close(started)
+		<-release
+		panic("boom")
+	}
+
+	ownerPanic := make(chan interface{}, 1)
+	go func() {
+		defer func() { ownerPanic <- recover() }()
+		_, _, _ = g.Do(context.Background(), "key", fn)
+	}()
+	<-started
+
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.Do(context.Background(), "key", fn)
+		waiterErr <- err
+	}()
+	require.Eventually(t, func() bool { return g.waiting("key") == 2 }, time.Second, time.Millisecond)
+
+	close(release)
+
+	var panicErr *PanicError
+	require.ErrorAs(t, <-waiterErr, &panicErr)
+	require.Equal(t, "boom", panicErr.Value)
+
+	r := <-ownerPanic
+	require.IsType(t, &PanicError{}, r)
+	require.Equal(t, "boom", r.(*PanicError).Value)
+}
@@ -17,19 +17,30 @@ import (
 	"github.com/hashicorp/go-retryablehttp"
 	"go.uber.org/zap"
 
+	"github.com/monetha/go-klaviyo/clock"
 	"github.com/monetha/go-klaviyo/internal/log"
+	"github.com/monetha/go-klaviyo/internal/singleflight"
 	"github.com/monetha/go-klaviyo/models/profile"
 	"github.com/monetha/go-klaviyo/models/profile/updater"
+	"github.com/monetha/go-klaviyo/operations/getevents"
 	"github.com/monetha/go-klaviyo/operations/getprofiles"
 )
 
 const (
-	restAPIHost  = "https://a.klaviyo.com/api"
-	revision     = "2023-08-15"
-	profileType  = "profile"
-	profilesPath = "profiles"
-	eventType    = "event"
-	eventsPath   = "events"
+	restAPIHost       = "https://a.klaviyo.com/api"
+	clientAPIHost     = "https://a.klaviyo.com/client"
+	staticHost        = "https://static.klaviyo.com"
+	revision          = "2023-08-15"
+	profileType       = "profile"
+	profilesPath      = "profiles"
+	profileImportPath = "profile-import"
+	eventType         = "event"
+	eventsPath        = "events"
+
+	maxProfilesPageSize = 100
+
+	// maxUnsetProperties is the maximum number of properties unset by a single profile update.
+	maxUnsetProperties = 100
 
 	// Default retry configuration
 	defaultRetryWaitMin = 1 * time.Second
@@ -37,8 +48,25 @@ const (
 	defaultRetryMax     = 4
 
 	clientTimeout = 30 * time.Second
+
+	defaultNameResolutionTTL = 5 * time.Minute
+	defaultPageRetries       = 3
 )
 
+// Structured log field keys emitted by the client, so downstream log pipelines can parse them reliably.
+const (
+	LogFieldEndpoint   = log.FieldEndpoint
+	LogFieldMethod     = log.FieldMethod
+	LogFieldStatus     = log.FieldStatus
+	LogFieldAttempt    = log.FieldAttempt
+	LogFieldRequestID  = log.FieldRequestID
+	LogFieldDurationMs = log.FieldDurationMs
+	LogFieldOperation  = log.FieldOperation
+)
+
+// requestIDHeaders lists the response headers that may carry the ID of the request, in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "Cf-Ray"}
+
 var (
 	// ErrInvalidAPIKey indicates that the provided Klaviyo API key is either not specified or invalid.
 	ErrInvalidAPIKey = errors.New("klaviyo: invalid or missing API key")
@@ -50,6 +78,14 @@ var (
 	// ErrProfileDoesNotExist indicates that an attempt was made to retrieve a profile
 	// that does not exist in Klaviyo.
 	ErrProfileDoesNotExist = errors.New("klaviyo: a profile does not exist")
+
+	// ErrNotFound indicates that a requested resource other than a profile, e.g. a list or a campaign,
+	// does not exist. The *APIError returned for such a request matches it with errors.Is.
+	ErrNotFound = errors.New("klaviyo: a resource does not exist")
+
+	// ErrMissingIdentifier indicates that a profile to create or update has neither an email address,
+	// a phone number nor an external ID identifying it.
+	ErrMissingIdentifier = errors.New("klaviyo: a profile requires an email, phone number or external ID")
 )
 
 var (
@@ -78,11 +114,14 @@ type APIError struct {
 	Title  string `json:"title"`
 	Detail string `json:"detail"`
 	Source struct {
-		Pointer string `json:"pointer"`
+		Pointer   string `json:"pointer"`
+		Parameter string `json:"parameter,omitempty"`
 	} `json:"source"`
 	Meta struct {
 		DuplicateProfileID string `json:"duplicate_profile_id,omitempty"`
 	} `json:"meta,omitempty"`
+	// Operation is the operation whose request failed.
+	Operation Operation `json:"-"`
 }
 
 // Error returns a human-readable representation of the APIError.
@@ -91,6 +130,11 @@ func (e *APIError) Error() string {
 		e.Id, e.Status, e.Code, e.Title, e.Detail)
 }
 
+// Is reports whether the APIError matches the target, i.e. ErrNotFound for a not found resource.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.Status == http.StatusNotFound
+}
+
 // ErrProfileAlreadyExists indicates that an attempt was made to create a profile
 // that already exists in Klaviyo. It holds the ID of the duplicate profile.
 type ErrProfileAlreadyExists struct {
@@ -103,13 +147,29 @@ func (e *ErrProfileAlreadyExists) Error() string {
 	return fmt.Sprintf("klaviyo: a profile already exists with one of these identifiers: %s", e.DuplicateProfileID)
 }
 
+// ErrResponseTooLarge indicates that the response body exceeded the maximum response size
+// configured with WithMaxResponseSize. The body was discarded.
+type ErrResponseTooLarge struct {
+	Limit int64
+}
+
+// Error returns a string representation of the ErrResponseTooLarge error.
+// It conforms to the error interface.
+func (e *ErrResponseTooLarge) Error() string {
+	return fmt.Sprintf("klaviyo: response body exceeds the maximum size of %d bytes", e.Limit)
+}
+
 // BadHTTPResponseError represents an error due to a bad HTTP response.
 type BadHTTPResponseError struct {
+	op         Operation
 	statusCode int
 	body       []byte
 	cause      error
 }
 
+// Operation returns the operation whose request failed.
+func (e *BadHTTPResponseError) Operation() Operation { return e.op }
+
 // StatusCode returns the HTTP status code of the response.
 func (e *BadHTTPResponseError) StatusCode() int { return e.statusCode }
 
@@ -131,42 +191,69 @@ func (e *BadHTTPResponseError) Unwrap() error { return e.cause }
 type Client struct {
 	APIKey     string
 	httpClient *http.Client
-	restAPIURL *url.URL
+	hosts      map[Family]*url.URL
+	logger     *log.LeveledZapLogger
+	options    *Options
+	resolvers  *resolvers
+	latency    *latencyTracker
+	usage      *usageTracker
+	inflight   singleflight.Group[*rawResponse]
+	transport  transport
 }
 
 // New initializes a new Klaviyo client with the default http client.
-func New(apiKey string, logger *zap.Logger) *Client {
+// It returns an error if an option is invalid, e.g. an *ErrInvalidHost.
+func New(apiKey string, logger *zap.Logger, opts ...Option) (*Client, error) {
+	o := newOptions(opts)
+	if o.err != nil {
+		return nil, o.err
+	}
 	return NewWithClient(
 		apiKey,
 		logger,
 		&http.Client{
-			Timeout: clientTimeout,
-		})
+			Timeout:   clientTimeout,
+			Transport: o.transport(),
+		},
+		opts...), nil
 }
 
-// NewWithClient initializes a new Klaviyo client with a custom http client.
-func NewWithClient(apiKey string, logger *zap.Logger, httpClient *http.Client) *Client {
+// NewWithClient initializes a new Klaviyo client with a custom http client. The options configuring the default
+// transport (WithProxyURL, WithTLSClientCertificates and WithRootCAs) have no effect: the transport of the http client
+// is used as is.
+// If an option is invalid, e.g. an *ErrInvalidHost, every request of the client fails with its error.
+func NewWithClient(apiKey string, logger *zap.Logger, httpClient *http.Client, opts ...Option) *Client {
+	o := newOptions(opts)
+
+	leveledLogger := log.NewLeveledLogger(logger)
+	usage := newUsageTracker(o.clock)
+
 	retryableHTTPClient := &retryablehttp.Client{
-		HTTPClient:   httpClient,
-		Logger:       log.NewLeveledLogger(logger),
+		HTTPClient:   usage.instrument(httpClient),
+		Logger:       leveledLogger,
 		RetryWaitMin: defaultRetryWaitMin,
 		RetryWaitMax: defaultRetryWaitMax,
 		RetryMax:     defaultRetryMax,
-		CheckRetry:   retryablehttp.DefaultRetryPolicy,
-		Backoff:      retryablehttp.DefaultBackoff,
-		ErrorHandler: errorHandler,
-	}
-
-	restAPIURL, err := url.Parse(restAPIHost)
-	if err != nil {
-		panic(err)
+		CheckRetry:   checkRetry,
+		Backoff:      newBackoff(o.clock),
+		ErrorHandler: newErrorHandler(o.clock),
+		RequestLogHook: func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+			leveledLogger.RequestAttempt(req, attempt)
+		},
 	}
 
-	return &Client{
+	c := &Client{
 		APIKey:     apiKey,
 		httpClient: retryableHTTPClient.StandardClient(),
-		restAPIURL: restAPIURL,
-	}
+		hosts:      newHosts(o),
+		logger:     leveledLogger,
+		options:    o,
+		resolvers:  newResolvers(o),
+		latency:    newLatencyTracker(o),
+		usage:      usage,
+	}
+	c.transport = transportFunc(c.roundTrip)
+	return c
 }
 
 // setCommonHeaders sets common headers required for Klaviyo API requests.
@@ -176,98 +263,169 @@ func (c *Client) setCommonHeaders(req *http.Request) {
 	req.Header.Set("revision", revision)
 }
 
-// GetEvents retrieves a list of created events from Klaviyo.
-func (c *Client) GetEvents(ctx context.Context, params ...getprofiles.Param) ([]*event.ExistingEvent, error) {
+// GetEvents retrieves a list of created events from Klaviyo. It returns a single page of events.
+// Profiles and metrics requested with getevents.WithInclude are attached to the returned events.
+func (c *Client) GetEvents(ctx context.Context, params ...getevents.Param) ([]*event.ExistingEvent, error) {
 	fields := url.Values{}
 	for _, p := range params {
 		p.Apply(fields)
 	}
 
 	var result struct {
-		Data []*event.ExistingEvent `json:"data"`
+		Data     []*event.ExistingEvent `json:"data"`
+		Included included               `json:"included"`
 	}
-	if err := c.doReq(ctx, http.MethodGet, eventsPath, fields, nil, &result); err != nil {
+	if err := c.doReq(ctx, OperationGetEvents, http.MethodGet, eventsPath, fields, nil, &result); err != nil {
+		return nil, err
+	}
+	if err := hydrateEvents(result.Data, result.Included); err != nil {
 		return nil, err
 	}
 
 	return result.Data, nil
 }
 
-// CreateEvent creates a new event in Klaviyo.
-func (c *Client) CreateEvent(ctx context.Context, e *event.NewEvent, ID string, metricName string) error {
-	type requestData struct {
-		*event.NewEvent
-		Type string `json:"type"`
-	}
+// CreateEventResult holds the metadata of a created event, e.g. for archival.
+type CreateEventResult struct {
+	// RequestID is the ID of the request reported by the API, if any.
+	RequestID string
+	// StatusCode is the HTTP status code of the response (Klaviyo replies with 202 Accepted).
+	StatusCode int
+	// UniqueID is the deduplication key sent with the event, if any. Klaviyo deduplicates events
+	// with the same unique ID and metric, but it doesn't report whether the event was a duplicate.
+	UniqueID string
+	// Payload is the serialized request payload sent to the API.
+	Payload []byte
+}
 
+// CreateEvent creates a new event in Klaviyo. The structured properties of the event are serialized
+// and validated like the properties of a profile.
+func (c *Client) CreateEvent(ctx context.Context, e *event.NewEvent, ID string, metricName string) (*CreateEventResult, error) {
 	type reqProfile struct {
-		*event.ExistingProfile
+		event.ExistingProfile
 		Type string `json:"type"`
 	}
 
 	type reqMetric struct {
 		Type string `json:"type"`
-		*event.NewMetric
+		event.NewMetric
 	}
 
-	profileRequestData := struct {
-		Data reqProfile `json:"data"`
-	}{
-		Data: reqProfile{
-			Type:            profileType,
-			ExistingProfile: &event.ExistingProfile{ID: ID},
-		},
+	// requestAttributes mirrors event.NewAttributes, sending the value as a plain decimal number.
+	// The profile and the metric are typed, so that encoding them doesn't allocate.
+	type requestAttributes struct {
+		Time          string      `json:"time"`
+		Value         json.Number `json:"value"`
+		ValueCurrency string      `json:"value_currency,omitempty"`
+		UniqueID      string      `json:"unique_id,omitempty"`
+		Properties    interface{} `json:"properties"`
+		Profile       struct {
+			Data reqProfile `json:"data"`
+		} `json:"profile"`
+		Metric struct {
+			Data reqMetric `json:"data"`
+		} `json:"metric"`
 	}
 
-	metricRequestData := struct {
-		Data reqMetric `json:"data"`
-	}{
-		Data: reqMetric{
-			Type: "metric",
-			NewMetric: &event.NewMetric{
-				Attributes: event.MetricAttributes{Name: metricName},
-			},
-		},
+	type requestData struct {
+		Attributes requestAttributes `json:"attributes"`
+		Type       string            `json:"type"`
+	}
+
+	c.checkMetricName(metricName)
+
+	ev := *e
+	if t, err := event.NormalizeTime(ev.Time, c.options.eventTimeLocation); err == nil {
+		ev.Time = t
+	} else if c.options.strictEventTime {
+		return nil, err
+	}
+	if defaults := c.options.eventProperties; len(defaults) > 0 {
+		properties := make(map[string]string, len(defaults)+len(ev.Properties))
+		for k, v := range defaults {
+			properties[k] = v
+		}
+		for k, v := range ev.Properties {
+			properties[k] = v
+		}
+		ev.Properties = properties
+	}
+	var properties interface{} = ev.Properties
+	if len(ev.StructuredProperties) > 0 {
+		merged := make(map[string]interface{}, len(ev.StructuredProperties)+len(ev.Properties))
+		for k, v := range ev.StructuredProperties {
+			merged[k] = v
+		}
+		for k, v := range ev.Properties {
+			merged[k] = v
+		}
+		serialized, err := c.serializeProperties(merged)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.validateProperties(OperationCreateEvent, serialized); err != nil {
+			return nil, err
+		}
+		properties = serialized
 	}
 
 	request := struct {
 		Data requestData `json:"data"`
 	}{
 		Data: requestData{
-			NewEvent: e,
-			Type:     eventType,
+			Attributes: requestAttributes{
+				Time:          ev.Time,
+				Value:         formatDecimal(ev.Value, c.options.floatPrecision),
+				ValueCurrency: ev.ValueCurrency,
+				UniqueID:      ev.UniqueID,
+				Properties:    properties,
+			},
+			Type: eventType,
 		},
 	}
-	request.Data.NewAttributes.Profile = profileRequestData
-	request.Data.NewAttributes.Metric = metricRequestData
+	request.Data.Attributes.Profile.Data = reqProfile{
+		ExistingProfile: event.ExistingProfile{ID: ID},
+		Type:            profileType,
+	}
+	request.Data.Attributes.Metric.Data = reqMetric{
+		Type:      "metric",
+		NewMetric: event.NewMetric{Attributes: event.MetricAttributes{Name: metricName}},
+	}
 
-	if err := c.doReq(ctx, http.MethodPost, eventsPath, nil, request, nil); err != nil {
-		return err
+	resp, err := c.do(ctx, OperationCreateEvent, http.MethodPost, eventsPath, nil, request, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &CreateEventResult{
+		RequestID:  requestID(resp.header),
+		StatusCode: resp.statusCode,
+		UniqueID:   ev.UniqueID,
+		Payload:    resp.requestBody,
+	}, nil
 }
 
-// GetProfiles retrieves a list of created profiles from Klaviyo.
+// GetProfiles retrieves a list of created profiles from Klaviyo. It returns a single page of profiles;
+// use GetProfilesPage or NewProfilesPaginator to retrieve the following pages.
+// If some profiles of the page can't be decoded, the others are returned together with *ErrPartialPage.
 func (c *Client) GetProfiles(ctx context.Context, params ...getprofiles.Param) ([]*profile.ExistingProfile, error) {
 	fields := url.Values{}
 	for _, p := range params {
 		p.Apply(fields)
 	}
 
-	var result struct {
-		Data []*profile.ExistingProfile `json:"data"`
-	}
-	if err := c.doReq(ctx, http.MethodGet, profilesPath, fields, nil, &result); err != nil {
-		return nil, err
-	}
-
-	return result.Data, nil
+	ps, _, err := c.getProfilesPage(ctx, fields)
+	return ps, err
 }
 
 // CreateProfile creates a new profile in Klaviyo. If a profile with the same identifiers
 // already exists, it will return ErrProfileAlreadyExists.
 func (c *Client) CreateProfile(ctx context.Context, p *profile.NewProfile) (*profile.ExistingProfile, error) {
+	p, err := c.prepareNewProfile(p)
+	if err != nil {
+		return nil, err
+	}
+
 	type requestData struct {
 		*profile.NewProfile
 		Type string `json:"type"`
@@ -285,7 +443,7 @@ func (c *Client) CreateProfile(ctx context.Context, p *profile.NewProfile) (*pro
 	var result struct {
 		Data profile.ExistingProfile `json:"data"`
 	}
-	if err := c.doReq(ctx, http.MethodPost, profilesPath, nil, request, &result); err != nil {
+	if err := c.doReq(ctx, OperationCreateProfile, http.MethodPost, profilesPath, nil, request, &result); err != nil {
 		return nil, err
 	}
 
@@ -300,7 +458,7 @@ func (c *Client) GetProfile(ctx context.Context, profileID string) (*profile.Exi
 	var result struct {
 		Data profile.ExistingProfile `json:"data"`
 	}
-	if err := c.doReq(ctx, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+	if err := c.doReq(ctx, OperationGetProfile, http.MethodGet, endpoint, nil, nil, &result); err != nil {
 		return nil, err
 	}
 
@@ -308,6 +466,10 @@ func (c *Client) GetProfile(ctx context.Context, profileID string) (*profile.Exi
 }
 
 // UpdateProfile updates a specific profile by its ID in Klaviyo.
+//
+// If more properties are unset than a single request allows, they are unset by subsequent requests and the profile
+// returned by the last request is returned. If a subsequent request fails, a *PartialError is returned together
+// with the profile returned by the last successful request; unsetting properties is idempotent, so the update can be retried.
 func (c *Client) UpdateProfile(ctx context.Context, profileID string, updaters ...updater.Profile) (*profile.ExistingProfile, error) {
 	// Create an empty profile data to hold the updates
 	profileData := updater.NewProfileData()
@@ -316,7 +478,43 @@ func (c *Client) UpdateProfile(ctx context.Context, profileID string, updaters .
 	for _, u := range updaters {
 		u.Apply(profileData)
 	}
+	if err := c.prepareProfileData(OperationUpdateProfile, profileData); err != nil {
+		return nil, err
+	}
+
+	// Klaviyo limits how many properties can be unset by a single request,
+	// so the remaining properties are unset by subsequent requests.
+	chunks := chunkStrings(profileData.PropertiesToRemove, maxUnsetProperties)
+
+	var (
+		updated *profile.ExistingProfile
+		steps   []StepResult
+	)
+	for i, chunk := range chunks {
+		data, name := profileData, "update profile"
+		if i > 0 {
+			data = &updater.ProfileData{Attributes: map[string]interface{}{}}
+			name = fmt.Sprintf("unset properties %d-%d", i*maxUnsetProperties, i*maxUnsetProperties+len(chunk)-1)
+		}
+		data.PropertiesToRemove = chunk
+
+		p, err := c.patchProfile(ctx, profileID, data)
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			steps = append(steps, StepResult{Name: name, Err: err, Retryable: true})
+			return updated, &PartialError{Operation: "update profile", Steps: steps}
+		}
+		updated = p
+		steps = append(steps, StepResult{Name: name, Done: true})
+	}
+
+	return updated, nil
+}
 
+// patchProfile sends a single update of the profile.
+func (c *Client) patchProfile(ctx context.Context, profileID string, profileData *updater.ProfileData) (*profile.ExistingProfile, error) {
 	// Create the request data structure
 	type requestData struct {
 		Attributes map[string]interface{} `json:"attributes"`
@@ -325,15 +523,6 @@ func (c *Client) UpdateProfile(ctx context.Context, profileID string, updaters .
 		Meta       map[string]interface{} `json:"meta,omitempty"`
 	}
 
-	var meta map[string]interface{}
-	if propertiesToRemove := profileData.PropertiesToRemove; len(propertiesToRemove) > 0 {
-		meta = map[string]interface{}{
-			"patch_properties": map[string]interface{}{
-				"unset": propertiesToRemove,
-			},
-		}
-	}
-
 	request := struct {
 		Data requestData `json:"data"`
 	}{
@@ -341,7 +530,7 @@ func (c *Client) UpdateProfile(ctx context.Context, profileID string, updaters .
 			Attributes: profileData.Attributes,
 			Id:         profileID,
 			Type:       profileType,
-			Meta:       meta,
+			Meta:       patchPropertiesMeta(profileData),
 		},
 	}
 
@@ -350,60 +539,161 @@ func (c *Client) UpdateProfile(ctx context.Context, profileID string, updaters .
 	var result struct {
 		Data profile.ExistingProfile `json:"data"`
 	}
-	if err := c.doReq(ctx, http.MethodPatch, endpoint, nil, request, &result); err != nil {
+	if err := c.doReq(ctx, OperationUpdateProfile, http.MethodPatch, endpoint, nil, request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// chunkStrings splits the strings into chunks of at most size strings. It always returns at least one chunk.
+func chunkStrings(ss []string, size int) [][]string {
+	if len(ss) <= size {
+		return [][]string{ss}
+	}
+	var chunks [][]string
+	for len(ss) > size {
+		chunks = append(chunks, ss[:size])
+		ss = ss[size:]
+	}
+	return append(chunks, ss)
+}
+
+// CreateOrUpdateProfile creates a profile or updates the profile matching its identifiers (email, phone number
+// or external ID) in a single call. Besides setting attributes, the updaters can unset, append and unappend
+// properties; performing several of these operations on the same property returns ErrPropertyConflict.
+// Conflicts caused by concurrent writes of the same identifiers are retried if enabled by WithConflictRetries.
+// At least one identifier must be set, e.g. by profile.IdentifyByEmail; otherwise ErrMissingIdentifier
+// is returned without calling the API.
+func (c *Client) CreateOrUpdateProfile(ctx context.Context, updaters ...updater.Profile) (*profile.ExistingProfile, error) {
+	profileData := updater.NewProfileData()
+	for _, u := range updaters {
+		u.Apply(profileData)
+	}
+	if !hasProfileIdentifier(profileData.Attributes) {
+		return nil, ErrMissingIdentifier
+	}
+	if err := c.prepareProfileData(OperationCreateOrUpdateProfile, profileData); err != nil {
+		return nil, err
+	}
+
+	return c.importProfile(ctx, profileData)
+}
+
+// importProfile sends a single create-or-update of the profile matching the identifiers of the profile data.
+func (c *Client) importProfile(ctx context.Context, profileData *updater.ProfileData) (*profile.ExistingProfile, error) {
+	type requestData struct {
+		Attributes map[string]interface{} `json:"attributes"`
+		Type       string                 `json:"type"`
+		Meta       map[string]interface{} `json:"meta,omitempty"`
+	}
+
+	request := struct {
+		Data requestData `json:"data"`
+	}{
+		Data: requestData{
+			Attributes: profileData.Attributes,
+			Type:       profileType,
+			Meta:       patchPropertiesMeta(profileData),
+		},
+	}
+
+	var result struct {
+		Data profile.ExistingProfile `json:"data"`
+	}
+	err := c.retryConflicts(ctx, OperationCreateOrUpdateProfile, func() error {
+		return c.doReq(ctx, OperationCreateOrUpdateProfile, http.MethodPost, profileImportPath, nil, request, &result)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &result.Data, nil
 }
 
-func (c *Client) doReq(ctx context.Context, method, endpoint string, fields url.Values, bodyData, result interface{}) error {
-	uri := *c.restAPIURL
+func (c *Client) doReq(ctx context.Context, op Operation, method, endpoint string, fields url.Values, bodyData, result interface{}) error {
+	_, err := c.exchange(ctx, op, method, endpoint, fields, bodyData, result, false)
+	return err
+}
+
+// response holds the metadata of a completed API request.
+type response struct {
+	statusCode  int
+	header      http.Header
+	requestBody []byte
+}
+
+// do performs the API request and decodes the response body into result, returning the metadata of the request,
+// including the request body.
+func (c *Client) do(ctx context.Context, op Operation, method, endpoint string, fields url.Values, bodyData, result interface{}) (*response, error) {
+	return c.exchange(ctx, op, method, endpoint, fields, bodyData, result, true)
+}
+
+// exchange performs the API request and decodes the response body into result, returning the metadata of the request.
+// The request body is encoded into a pooled buffer, released once the response is read: the transport reads the body
+// into memory before the first attempt, so retries never read a released buffer. The metadata holds a copy of
+// the request body only if keepBody is set.
+func (c *Client) exchange(ctx context.Context, op Operation, method, endpoint string, fields url.Values, bodyData, result interface{}, keepBody bool) (*response, error) {
+	if c.options.err != nil {
+		return nil, c.options.err
+	}
+	if err := c.checkOperation(op); err != nil {
+		return nil, err
+	}
+
+	uri := c.baseURL(op)
 	uri.Path = path.Join(uri.Path, endpoint)
 	uri.RawQuery = fields.Encode()
 
-	var bodyBuffer io.Reader
+	var (
+		bodyBuffer io.Reader
+		jsonData   []byte
+	)
 
 	if bodyData != nil {
-		jsonData, err := json.Marshal(bodyData)
+		buf, err := encodeJSON(bodyData)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		bodyBuffer = bytes.NewBuffer(jsonData)
+		defer putBuffer(buf)
+		jsonData = buf.Bytes()
+		bodyBuffer = bytes.NewReader(jsonData)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, uri.String(), bodyBuffer)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	c.setCommonHeaders(req)
-	if method == http.MethodPost || method == http.MethodPatch || method == http.MethodPut {
+	if err := setContextHeaders(req); err != nil {
+		return nil, err
+	}
+	if bodyData != nil {
+		// DELETE requests of relationships carry a body too
 		req.Header.Set("content-type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(op, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-	defer func() {
-		// Drain and close the body to let the Transport reuse the connection
-		_, _ = io.Copy(io.Discard, resp.Body)
-	}()
+	body := resp.body
+
+	c.logPayload(op, uri.Path, resp.statusCode, jsonData, body)
+
+	if statusCode := resp.statusCode; statusCode < 200 || statusCode >= 300 {
+		c.logErrorBody(op, method, uri.Path, statusCode, body)
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	if statusCode := resp.StatusCode; statusCode < 200 || statusCode >= 300 {
 		var errs struct {
-			Errors []*APIError `json:"errors"`
+			Errors apiErrors `json:"errors"`
 		}
 		if jsErr := json.Unmarshal(body, &errs); jsErr != nil {
-			return &BadHTTPResponseError{
+			if op == OperationCreateEvent && statusCode == http.StatusRequestEntityTooLarge {
+				return nil, &ErrEventTooLarge{Cause: &APIError{Status: statusCode, Title: "Request Entity Too Large", Detail: string(body), Operation: op}}
+			}
+			return nil, &BadHTTPResponseError{
+				op:         op,
 				statusCode: statusCode,
 				body:       body,
 				cause:      jsErr,
@@ -412,52 +702,196 @@ func (c *Client) doReq(ctx context.Context, method, endpoint string, fields url.
 
 		err := &multierror.Error{}
 		for _, er := range errs.Errors {
+			if er.Status == 0 {
+				er.Status = statusCode
+			}
+			er.Operation = op
 			err = multierror.Append(err, er)
 		}
 		if len(err.Errors) == 0 {
-			return &APIError{
-				Status: statusCode,
-				Title:  "Bad HTTP status",
-				Detail: (string)(body),
+			return nil, &APIError{
+				Status:    statusCode,
+				Title:     "Bad HTTP status",
+				Detail:    (string)(body),
+				Operation: op,
 			}
 		}
 
-		return wrapAPIError(err.Unwrap())
+		return nil, wrapAPIError(op, err.Unwrap())
+	}
+
+	meta := &response{
+		statusCode: resp.statusCode,
+		header:     resp.header,
+	}
+	if keepBody && jsonData != nil {
+		meta.requestBody = append([]byte(nil), jsonData...)
 	}
 	if result != nil {
-		return json.Unmarshal(body, result)
+		if err := json.Unmarshal(body, result); err != nil {
+			return nil, err
+		}
+		if err := c.checkUnknownFields(op, body, result); err != nil {
+			return nil, err
+		}
 	}
-	return nil
+	return meta, nil
 }
 
-func errorHandler(resp *http.Response, err error, _ int) (*http.Response, error) {
+// send sends the request and reads the response. Identical GET requests of the operations coalesced
+// by WithRequestCoalescing share a single request while it is in flight. The shared request outlives
+// the cancellation of the context of any single caller, and is canceled once all the callers gave up.
+func (c *Client) send(op Operation, req *http.Request) (*rawResponse, error) {
+	if req.Method != http.MethodGet || !c.options.coalesces(op) {
+		return c.transport.roundTrip(op, req)
+	}
+
+	resp, _, err := c.inflight.Do(req.Context(), coalescingKey(req), func(ctx context.Context) (*rawResponse, error) {
+		return c.transport.roundTrip(op, req.WithContext(ctx))
+	})
+	return resp, err
+}
+
+// roundTrip sends the request, reads the response body and records the latency and the usage of the request.
+// It's the default transport of the client.
+func (c *Client) roundTrip(op Operation, req *http.Request) (*rawResponse, error) {
+	req = req.WithContext(withOperation(req.Context(), op))
+	start := c.options.clock.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.latency.observe(op, 0, c.options.clock.Now().Sub(start))
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	defer func() {
+		// Drain and close the body to let the Transport reuse the connection
+		_, _ = io.Copy(io.Discard, resp.Body)
+	}()
+
+	body, err := c.readBody(resp.Body)
+	duration := c.options.clock.Now().Sub(start)
+	c.latency.observe(op, resp.StatusCode, duration)
 	if err != nil {
-		return resp, err
+		return nil, err
+	}
+
+	if c.logger.DebugEnabled() {
+		c.logger.Debug("request completed",
+			LogFieldOperation, op,
+			LogFieldMethod, req.Method,
+			LogFieldEndpoint, req.URL.Path,
+			LogFieldStatus, resp.StatusCode,
+			LogFieldRequestID, requestID(resp.Header),
+			LogFieldDurationMs, duration.Milliseconds(),
+		)
+	}
+
+	return &rawResponse{
+		statusCode: resp.StatusCode,
+		header:     resp.Header,
+		body:       body,
+	}, nil
+}
+
+// readBody reads the response body, respecting the maximum response size.
+func (c *Client) readBody(r io.Reader) ([]byte, error) {
+	limit := c.options.maxResponseSize
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, &ErrResponseTooLarge{Limit: limit}
+	}
+	return body, nil
+}
+
+// requestID returns the ID of the request reported in the response headers, or an empty string if there is none.
+func requestID(header http.Header) string {
+	for _, h := range requestIDHeaders {
+		if id := header.Get(h); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// newErrorHandler creates the handler of the requests that failed after all the retries.
+func newErrorHandler(c clock.Clock) retryablehttp.ErrorHandler {
+	return func(resp *http.Response, err error, attempts int) (*http.Response, error) {
+		return errorHandler(c, resp, err, attempts)
+	}
+}
+
+func errorHandler(c clock.Clock, resp *http.Response, err error, _ int) (*http.Response, error) {
+	if err != nil {
+		if resp != nil {
+			// the response is not returned together with the error, so it must be closed here
+			_ = resp.Body.Close()
+		}
+		return nil, err
 	}
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return resp, ErrTooManyRequests
+		// the response is not returned together with the error, so it must be closed here
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		return nil, &ErrRateLimited{RetryAfter: parseRetryAfter(resp.Header, c.Now())}
+	}
+
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		// the response is not returned together with the error, so it must be closed here
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		return nil, newServiceUnavailableError(resp, c.Now())
 	}
 
 	return resp, err
 }
 
-func wrapAPIError(err error) error {
+// isProfileOperation reports whether the operation requests a single profile by its ID,
+// so a not found response means that the profile does not exist.
+func isProfileOperation(op Operation) bool {
+	switch op {
+	case OperationGetProfile, OperationUpdateProfile, OperationGetProfileLists, OperationGetProfileSegments:
+		return true
+	}
+	return false
+}
+
+func wrapAPIError(op Operation, err error) error {
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
+		if op == OperationCreateEvent {
+			if eventErr := wrapEventError(apiErr); eventErr != nil {
+				return eventErr
+			}
+		}
 		switch apiErr.Status {
 		case http.StatusConflict:
 			if apiErr.Code == "duplicate_profile" {
 				return &ErrProfileAlreadyExists{DuplicateProfileID: apiErr.Meta.DuplicateProfileID}
 			}
 		case http.StatusNotFound:
-			if apiErr.Code == "not_found" {
+			if apiErr.Code == "not_found" && isProfileOperation(op) {
 				return ErrProfileDoesNotExist
 			}
 		case http.StatusUnauthorized:
 			if apiErr.Code == "not_authenticated" || apiErr.Code == "authentication_failed" {
 				return ErrInvalidAPIKey
 			}
+		case http.StatusForbidden:
+			if apiErr.Code == "permission_denied" {
+				return &ErrMissingScope{Operation: op, Scope: RequiredScope(op), Cause: apiErr}
+			}
 		}
 	}
 	return err
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/dnaeon/go-vcr/cassette"
 	"github.com/dnaeon/go-vcr/recorder"
@@ -62,6 +63,20 @@ func TestClient_GetProfiles(t *testing.T) {
 		})
 	})
 
+	t.Run("get profiles exceeding maximum response size with valid API key", func(t *testing.T) {
+		withHTTPRecorder("tests/get_profiles_valid_api_key", func(c *http.Client) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithMaxResponseSize(100))
+
+			ctx := context.TODO()
+			ps, err := kc.GetProfiles(ctx)
+
+			var e *klaviyo.ErrResponseTooLarge
+			require.ErrorAs(t, err, &e)
+			require.EqualValues(t, 100, e.Limit)
+			require.Nil(t, ps)
+		})
+	})
+
 	t.Run("get profiles with email and phone using valid API key", func(t *testing.T) {
 		withHTTPRecorder("tests/get_profiles_with_email_and_phone_valid_api_key", func(c *http.Client) {
 			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
@@ -76,6 +91,27 @@ func TestClient_GetProfiles(t *testing.T) {
 			require.Len(t, ps, 3, "profiles len")
 		})
 	})
+
+	t.Run("get profiles with subscriptions using valid API key", func(t *testing.T) {
+		withHTTPRecorder("tests/get_profiles_with_subscriptions_valid_api_key", func(c *http.Client) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			ctx := context.TODO()
+			ps, err := kc.GetProfiles(ctx,
+				getprofiles.WithAdditionalFields("subscriptions"),
+			)
+
+			require.NoError(t, err)
+			require.Len(t, ps, 1, "profiles len")
+
+			attrs := ps[0].Attributes
+			require.True(t, attrs.IsEmailSuppressed())
+			suppressions := attrs.EmailSuppressions()
+			require.Len(t, suppressions, 1)
+			require.Equal(t, profile.SuppressionReasonHardBounce, suppressions[0].Reason)
+			require.Equal(t, time.Date(2023, 9, 1, 10, 0, 0, 0, time.UTC), suppressions[0].Timestamp.UTC())
+		})
+	})
 }
 
 var initialProfile = &profile.NewProfile{
@@ -478,12 +514,72 @@ func TestClient_Events(t *testing.T) {
 			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
 
 			ctx := context.TODO()
-			err := kc.CreateEvent(ctx, &inititalEvent, existingProfileID, metricName)
+			res, err := kc.CreateEvent(ctx, &inititalEvent, existingProfileID, metricName)
 
 			require.NoError(t, err)
+			require.NotNil(t, res)
+			require.Equal(t, "84e539fb8b34ac0a-KLD", res.RequestID, "Mismatch in field: RequestID")
+			require.Equal(t, http.StatusAccepted, res.StatusCode, "Mismatch in field: StatusCode")
+			require.Contains(t, string(res.Payload), `"name":"Reward"`, "Mismatch in field: Payload")
 		})
 	})
 
+	t.Run("create new event with wall-clock time in the configured location", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithEventTimeLocation(loc))
+
+		ctx := context.TODO()
+		_, err = kc.CreateEvent(ctx, &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", "Reward")
+
+		require.NoError(t, err)
+		require.Contains(t, body, `"time":"2024-01-30T05:10:00-05:00"`)
+		require.Equal(t, "2024-01-30T05:10:00", inititalEvent.Time, "event must not be modified")
+	})
+
+	t.Run("create new event with default properties", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithDefaultEventProperties(map[string]string{
+			"Service":   "loyalty",
+			"EventName": "Default",
+		}))
+
+		ctx := context.TODO()
+		_, err := kc.CreateEvent(ctx, &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", "Reward")
+
+		require.NoError(t, err)
+		require.Contains(t, body, `"properties":{"EventName":"EmailSent","PointClaimed":"1500","PointOverall":"20000","Service":"loyalty"}`)
+		require.Len(t, inititalEvent.Properties, 3, "event must not be modified")
+	})
+
+	t.Run("create new event with wall-clock time in strict mode", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithStrictEventTime())
+
+		ctx := context.TODO()
+		_, err := kc.CreateEvent(ctx, &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", "Reward")
+
+		require.ErrorIs(t, err, event.ErrAmbiguousTime)
+	})
+
 	t.Run("get existing profile with valid API key", func(t *testing.T) {
 		withHTTPRecorder("tests/get_existing_event_valid_api_key", func(c *http.Client) {
 
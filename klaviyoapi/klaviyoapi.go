@@ -0,0 +1,64 @@
+// Package klaviyoapi defines narrow interfaces of the Klaviyo client, so that consumer packages can depend
+// only on the part of the API they use and replace it by a fake in tests. *klaviyo.Client implements
+// all of them.
+//
+// The interfaces are frozen: methods are never added to or changed in an existing interface, so that the fakes
+// implementing it keep compiling. New methods of the client are exposed by new interfaces, e.g. BulkImportJobs.
+package klaviyoapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/bulkimport"
+	"github.com/monetha/go-klaviyo/models/event"
+	"github.com/monetha/go-klaviyo/models/list"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+	"github.com/monetha/go-klaviyo/operations/getevents"
+	"github.com/monetha/go-klaviyo/operations/getlists"
+	"github.com/monetha/go-klaviyo/operations/getprofiles"
+)
+
+// Profiles retrieves, creates and updates profiles.
+type Profiles interface {
+	GetProfile(ctx context.Context, profileID string) (*profile.ExistingProfile, error)
+	GetProfiles(ctx context.Context, params ...getprofiles.Param) ([]*profile.ExistingProfile, error)
+	GetProfilesByIDs(ctx context.Context, ids []string) ([]*profile.ExistingProfile, error)
+	GetProfileByEmail(ctx context.Context, email string) (*profile.ExistingProfile, error)
+	GetProfileByExternalID(ctx context.Context, externalID string) (*profile.ExistingProfile, error)
+	CreateProfile(ctx context.Context, p *profile.NewProfile) (*profile.ExistingProfile, error)
+	UpdateProfile(ctx context.Context, profileID string, updaters ...updater.Profile) (*profile.ExistingProfile, error)
+	CreateOrUpdateProfile(ctx context.Context, updaters ...updater.Profile) (*profile.ExistingProfile, error)
+}
+
+// Events creates and retrieves events.
+type Events interface {
+	CreateEvent(ctx context.Context, e *event.NewEvent, profileID string, metricName string) (*klaviyo.CreateEventResult, error)
+	GetEvents(ctx context.Context, params ...getevents.Param) ([]*event.ExistingEvent, error)
+	GetEventsByIDs(ctx context.Context, ids []string) ([]*event.ExistingEvent, error)
+	GetProfileEvents(ctx context.Context, profileID string, params ...getevents.Param) ([]*event.ExistingEvent, error)
+}
+
+// Lists retrieves lists and manages their members.
+type Lists interface {
+	GetList(ctx context.Context, listID string) (*list.ExistingList, error)
+	GetLists(ctx context.Context, params ...getlists.Param) ([]*list.ExistingList, error)
+	GetProfileLists(ctx context.Context, profileID string) ([]*list.ExistingList, error)
+	ResolveListID(ctx context.Context, name string) (string, error)
+	RemoveProfilesFromList(ctx context.Context, listID string, profileIDs []string) error
+}
+
+// BulkImportJobs retrieves profile bulk import jobs and waits for their completion.
+type BulkImportJobs interface {
+	GetBulkImportJob(ctx context.Context, jobID string) (*bulkimport.ExistingJob, error)
+	WaitForBulkImportJob(ctx context.Context, jobID string, interval time.Duration, opts ...klaviyo.WaitOption) (*bulkimport.ExistingJob, error)
+}
+
+var (
+	_ Profiles       = (*klaviyo.Client)(nil)
+	_ Events         = (*klaviyo.Client)(nil)
+	_ Lists          = (*klaviyo.Client)(nil)
+	_ BulkImportJobs = (*klaviyo.Client)(nil)
+)
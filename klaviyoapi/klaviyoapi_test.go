@@ -0,0 +1,45 @@
+package klaviyoapi_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/klaviyoapi"
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+// fakeProfiles implements the methods of klaviyoapi.Profiles used by the test; the embedded interface
+// panics if any other method is called.
+type fakeProfiles struct {
+	klaviyoapi.Profiles
+	byEmail map[string]*profile.ExistingProfile
+}
+
+func (f *fakeProfiles) GetProfileByEmail(_ context.Context, email string) (*profile.ExistingProfile, error) {
+	return f.byEmail[email], nil
+}
+
+// profileID is a consumer depending on the narrow interface only.
+func profileID(ctx context.Context, profiles klaviyoapi.Profiles, email string) (string, error) {
+	p, err := profiles.GetProfileByEmail(ctx, email)
+	if err != nil || p == nil {
+		return "", err
+	}
+	return p.Id, nil
+}
+
+func TestProfiles_Fake(t *testing.T) {
+	fake := &fakeProfiles{byEmail: map[string]*profile.ExistingProfile{
+		"sarah.mason@klaviyo-demo.com": {Id: "01GDDKASAP8TKDDA2GRZDSVP4H"},
+	}}
+
+	id, err := profileID(context.TODO(), fake, "sarah.mason@klaviyo-demo.com")
+	require.NoError(t, err)
+	require.Equal(t, "01GDDKASAP8TKDDA2GRZDSVP4H", id)
+
+	id, err = profileID(context.TODO(), fake, "john.doe@klaviyo-demo.com")
+	require.NoError(t, err)
+	require.Empty(t, id)
+}
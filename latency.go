@@ -0,0 +1,171 @@
+package klaviyo
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the buckets of the latency histograms.
+var latencyBuckets = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	800 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// LatencySLO declares a latency objective of an operation, e.g. 99% of GetProfile requests
+// complete in less than 800ms: LatencySLO{Operation: OperationGetProfile, Objective: 0.99, Threshold: 800 * time.Millisecond}.
+type LatencySLO struct {
+	Operation Operation
+	Objective float64
+	Threshold time.Duration
+}
+
+// SLOStatus holds the state of a latency SLO since the client was created.
+// Burn is the rate the error budget is consumed at: the share of slow requests divided by
+// the share allowed by the objective. A burn above 1 means the objective is violated.
+type SLOStatus struct {
+	SLO   LatencySLO
+	Total uint64
+	Slow  uint64
+	Burn  float64
+}
+
+// RequestMetrics describes a completed API request. StatusCode is zero if no response was received.
+// SLO is nil if no latency SLO is declared for the operation.
+type RequestMetrics struct {
+	Operation  Operation
+	StatusCode int
+	Duration   time.Duration
+	SLO        *SLOStatus
+}
+
+// MetricsHook is called after every API request, e.g. to export the latency and the SLO burn to a monitoring system.
+// It's called synchronously and must not block.
+type MetricsHook func(RequestMetrics)
+
+// LatencyBucket is a bucket of a latency histogram. Count is the number of requests that completed
+// in UpperBound or less; buckets are cumulative.
+type LatencyBucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// LatencyHistogram holds the latencies of the requests of an operation since the client was created.
+type LatencyHistogram struct {
+	Operation Operation
+	Buckets   []LatencyBucket
+	Count     uint64
+	Sum       time.Duration
+}
+
+// latencyTracker tracks the latencies of the requests per operation.
+type latencyTracker struct {
+	mu   sync.Mutex
+	ops  map[Operation]*operationLatency
+	slos map[Operation]LatencySLO
+	hook MetricsHook
+}
+
+type operationLatency struct {
+	buckets []uint64
+	count   uint64
+	sum     time.Duration
+	slow    uint64
+}
+
+func newLatencyTracker(o *Options) *latencyTracker {
+	return &latencyTracker{
+		ops:  make(map[Operation]*operationLatency),
+		slos: o.latencySLOs,
+		hook: o.metricsHook,
+	}
+}
+
+// observe records the latency of a request and calls the metrics hook.
+func (t *latencyTracker) observe(op Operation, statusCode int, d time.Duration) {
+	m := RequestMetrics{
+		Operation:  op,
+		StatusCode: statusCode,
+		Duration:   d,
+	}
+
+	t.mu.Lock()
+	l, ok := t.ops[op]
+	if !ok {
+		l = &operationLatency{buckets: make([]uint64, len(latencyBuckets))}
+		t.ops[op] = l
+	}
+	for i, upper := range latencyBuckets {
+		if d <= upper {
+			l.buckets[i]++
+		}
+	}
+	l.count++
+	l.sum += d
+
+	if slo, ok := t.slos[op]; ok {
+		if d >= slo.Threshold {
+			l.slow++
+		}
+		m.SLO = &SLOStatus{
+			SLO:   slo,
+			Total: l.count,
+			Slow:  l.slow,
+			Burn:  burn(slo.Objective, l.count, l.slow),
+		}
+	}
+	t.mu.Unlock()
+
+	if t.hook != nil {
+		t.hook(m)
+	}
+}
+
+// histograms returns the snapshots of the latency histograms sorted by operation.
+func (t *latencyTracker) histograms() []LatencyHistogram {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	hs := make([]LatencyHistogram, 0, len(t.ops))
+	for op, l := range t.ops {
+		h := LatencyHistogram{
+			Operation: op,
+			Buckets:   make([]LatencyBucket, len(latencyBuckets)),
+			Count:     l.count,
+			Sum:       l.sum,
+		}
+		for i, upper := range latencyBuckets {
+			h.Buckets[i] = LatencyBucket{UpperBound: upper, Count: l.buckets[i]}
+		}
+		hs = append(hs, h)
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i].Operation < hs[j].Operation })
+	return hs
+}
+
+func burn(objective float64, total, slow uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	if slow == 0 {
+		return 0
+	}
+	budget := 1 - objective
+	if budget <= 0 {
+		return math.Inf(1)
+	}
+	return float64(slow) / float64(total) / budget
+}
+
+// LatencyHistograms returns the latency histograms of the operations performed by the client, sorted by operation.
+func (c *Client) LatencyHistograms() []LatencyHistogram {
+	return c.latency.histograms()
+}
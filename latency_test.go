@@ -0,0 +1,74 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+func TestWithLatencySLO(t *testing.T) {
+	clk := clock.NewManual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	latencies := []time.Duration{100 * time.Millisecond, 900 * time.Millisecond, 300 * time.Millisecond, 200 * time.Millisecond}
+
+	var call int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		clk.Advance(latencies[call])
+		call++
+		return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01GVCF3FZ5W7YCE0MW4ZCBVWXM","attributes":{}}}`), nil
+	})}
+
+	var got []klaviyo.RequestMetrics
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c,
+		klaviyo.WithClock(clk),
+		klaviyo.WithLatencySLO(klaviyo.LatencySLO{
+			Operation: klaviyo.OperationGetProfile,
+			Objective: 0.9,
+			Threshold: 800 * time.Millisecond,
+		}),
+		klaviyo.WithMetricsHook(func(m klaviyo.RequestMetrics) {
+			got = append(got, m)
+		}),
+	)
+
+	for range latencies {
+		_, err := kc.GetProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM")
+		require.NoError(t, err)
+	}
+
+	require.Len(t, got, len(latencies))
+	for i, m := range got {
+		require.Equal(t, klaviyo.OperationGetProfile, m.Operation)
+		require.Equal(t, http.StatusOK, m.StatusCode)
+		require.Equal(t, latencies[i], m.Duration)
+		require.NotNil(t, m.SLO)
+	}
+
+	last := got[len(got)-1].SLO
+	require.Equal(t, uint64(4), last.Total)
+	require.Equal(t, uint64(1), last.Slow)
+	require.InDelta(t, 2.5, last.Burn, 1e-9)
+
+	hs := kc.LatencyHistograms()
+	require.Len(t, hs, 1)
+	h := hs[0]
+	require.Equal(t, klaviyo.OperationGetProfile, h.Operation)
+	require.Equal(t, uint64(4), h.Count)
+	require.Equal(t, 1500*time.Millisecond, h.Sum)
+	for _, b := range h.Buckets {
+		switch b.UpperBound {
+		case 100 * time.Millisecond:
+			require.Equal(t, uint64(1), b.Count)
+		case 500 * time.Millisecond:
+			require.Equal(t, uint64(3), b.Count)
+		case time.Second:
+			require.Equal(t, uint64(4), b.Count)
+		}
+	}
+}
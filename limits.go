@@ -0,0 +1,112 @@
+package klaviyo
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// Documented Klaviyo limits of profile properties.
+const (
+	// maxProperties is the maximum number of properties of a profile sent in a single request.
+	maxProperties = 400
+	// maxPropertyKeyLength is the maximum length of a property name in characters.
+	maxPropertyKeyLength = 255
+	// maxPropertyValueLength is the maximum length of a string property value in characters.
+	maxPropertyValueLength = 100000
+)
+
+// PropertyLimit names a Klaviyo limit of profile properties.
+type PropertyLimit string
+
+// Limits of profile properties.
+const (
+	PropertyLimitCount       PropertyLimit = "property count"
+	PropertyLimitKeyLength   PropertyLimit = "key length"
+	PropertyLimitValueLength PropertyLimit = "value length"
+)
+
+// ErrPropertyLimitExceeded indicates that the properties exceed a documented Klaviyo limit, which the API rejects
+// with a 400 Bad Request. Key is the dot-separated path of the offending property; it's empty if the limit
+// applies to the properties as a whole. Size is the measured size and Max the allowed one.
+type ErrPropertyLimitExceeded struct {
+	Key   string
+	Limit PropertyLimit
+	Size  int
+	Max   int
+}
+
+// Error returns a string representation of the ErrPropertyLimitExceeded error.
+// It conforms to the error interface.
+func (e *ErrPropertyLimitExceeded) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("klaviyo: properties exceed the %s limit: %d > %d", e.Limit, e.Size, e.Max)
+	}
+	return fmt.Sprintf("klaviyo: property %q exceeds the %s limit: %d > %d", e.Key, e.Limit, e.Size, e.Max)
+}
+
+// checkPropertyLimits returns the violations of the property limits, sorted by the property key.
+func checkPropertyLimits(properties map[string]interface{}) []*ErrPropertyLimitExceeded {
+	var problems []*ErrPropertyLimitExceeded
+	if len(properties) > maxProperties {
+		problems = append(problems, &ErrPropertyLimitExceeded{Limit: PropertyLimitCount, Size: len(properties), Max: maxProperties})
+	}
+	return append(problems, checkKeyLimits("", properties)...)
+}
+
+func checkKeyLimits(prefix string, properties map[string]interface{}) []*ErrPropertyLimitExceeded {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []*ErrPropertyLimitExceeded
+	for _, k := range keys {
+		if n := utf8.RuneCountInString(k); n > maxPropertyKeyLength {
+			problems = append(problems, &ErrPropertyLimitExceeded{Key: prefix + k, Limit: PropertyLimitKeyLength, Size: n, Max: maxPropertyKeyLength})
+		}
+		problems = append(problems, checkValueLimits(prefix+k, properties[k])...)
+	}
+	return problems
+}
+
+func checkValueLimits(key string, value interface{}) []*ErrPropertyLimitExceeded {
+	switch v := value.(type) {
+	case string:
+		if n := utf8.RuneCountInString(v); n > maxPropertyValueLength {
+			return []*ErrPropertyLimitExceeded{{Key: key, Limit: PropertyLimitValueLength, Size: n, Max: maxPropertyValueLength}}
+		}
+	case []string:
+		var problems []*ErrPropertyLimitExceeded
+		for i, s := range v {
+			if !withinValueLimits(s) {
+				problems = append(problems, checkValueLimits(fmt.Sprintf("%s[%d]", key, i), s)...)
+			}
+		}
+		return problems
+	case []interface{}:
+		var problems []*ErrPropertyLimitExceeded
+		for i, e := range v {
+			if !withinValueLimits(e) {
+				problems = append(problems, checkValueLimits(fmt.Sprintf("%s[%d]", key, i), e)...)
+			}
+		}
+		return problems
+	case map[string]interface{}:
+		return checkKeyLimits(key+".", v)
+	}
+	return nil
+}
+
+// withinValueLimits reports whether the value is a scalar within the limits, so the elements of collections
+// are only given keys, which is costly, when they need to be checked.
+func withinValueLimits(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return len(v) <= maxPropertyValueLength || utf8.RuneCountInString(v) <= maxPropertyValueLength
+	case []string, []interface{}, map[string]interface{}:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,159 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+const (
+	// maxListRelationshipProfiles is the maximum number of profiles added to or removed from a list in a single request.
+	maxListRelationshipProfiles = 1000
+
+	// ReasonCannotReceiveEmailMarketing is reported by CleanList for members that can't receive email marketing
+	// without being suppressed, e.g. because they never subscribed.
+	ReasonCannotReceiveEmailMarketing = "CANNOT_RECEIVE_EMAIL_MARKETING"
+)
+
+// UnreachableMember is a list member that can't receive emails sent to the list.
+type UnreachableMember struct {
+	ProfileID string
+	Email     string
+	// Reasons are the suppression reasons, e.g. profile.SuppressionReasonHardBounce,
+	// or ReasonCannotReceiveEmailMarketing.
+	Reasons []string
+}
+
+// ListCleaningReport is the result of CleanList.
+type ListCleaningReport struct {
+	ListID string
+	// Checked is the number of members checked.
+	Checked int
+	// Unreachable are the members that can't receive emails sent to the list.
+	Unreachable []UnreachableMember
+	// Removed is the number of unreachable members removed from the list; it's zero in a dry run.
+	Removed int
+}
+
+// CleanList pages through the members of the list with the given ID, checks their email suppressions and
+// consent, and removes the members that can't receive emails sent to the list, e.g. hard bounced or
+// unsubscribed profiles. In a dry run, the unreachable members are only reported.
+//
+// If removing the members fails after some were removed, a *PartialError is returned together with the report.
+func (c *Client) CleanList(ctx context.Context, listID string, dryRun bool) (*ListCleaningReport, error) {
+	endpoint := path.Join(listsPath, listID, membersPath)
+	fields := url.Values{}
+	fields.Set("page[size]", strconv.Itoa(maxMembersPageSize))
+	fields.Set("additional-fields[profile]", "subscriptions")
+
+	paginator := newPaginator(c, func(ctx context.Context, fields url.Values) ([]*profile.ExistingProfile, string, error) {
+		var result struct {
+			Data  []*profile.ExistingProfile `json:"data"`
+			Links links                      `json:"links"`
+		}
+		if err := c.doReq(ctx, OperationGetListProfiles, http.MethodGet, endpoint, fields, nil, &result); err != nil {
+			return nil, "", err
+		}
+		return result.Data, result.Links.nextCursor(), nil
+	}, fields)
+
+	report := &ListCleaningReport{ListID: listID}
+	for paginator.HasNext() {
+		ps, err := paginator.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range ps {
+			report.Checked++
+			if reasons := unreachableReasons(p, listID); len(reasons) > 0 {
+				report.Unreachable = append(report.Unreachable, UnreachableMember{
+					ProfileID: p.Id,
+					Email:     p.Attributes.Email,
+					Reasons:   reasons,
+				})
+			}
+		}
+	}
+
+	if dryRun || len(report.Unreachable) == 0 {
+		return report, nil
+	}
+
+	ids := make([]string, len(report.Unreachable))
+	for i, m := range report.Unreachable {
+		ids[i] = m.ProfileID
+	}
+	removed, err := c.removeProfilesFromList(ctx, listID, ids)
+	report.Removed = removed
+	return report, err
+}
+
+// unreachableReasons returns the reasons why the profile can't receive emails sent to the list.
+func unreachableReasons(p *profile.ExistingProfile, listID string) []string {
+	s := p.Attributes.Subscriptions
+	if s == nil || s.Email == nil || s.Email.Marketing == nil {
+		return nil
+	}
+	m := s.Email.Marketing
+
+	var reasons []string
+	for _, sup := range m.Suppressions {
+		reasons = append(reasons, sup.Reason)
+	}
+	for _, sup := range m.ListSuppressions {
+		if sup.ListID == listID {
+			reasons = append(reasons, sup.Reason)
+		}
+	}
+	if len(reasons) == 0 && m.CanReceiveEmailMarketing != nil && !*m.CanReceiveEmailMarketing {
+		reasons = append(reasons, ReasonCannotReceiveEmailMarketing)
+	}
+	return reasons
+}
+
+// RemoveProfilesFromList removes the profiles with the given IDs from the list with the given ID.
+// The profiles are removed in chunks of at most 1,000; if a chunk can't be removed after some were,
+// a *PartialError is returned.
+func (c *Client) RemoveProfilesFromList(ctx context.Context, listID string, profileIDs []string) error {
+	_, err := c.removeProfilesFromList(ctx, listID, profileIDs)
+	return err
+}
+
+// removeProfilesFromList removes the profiles from the list and returns the number of profiles removed.
+func (c *Client) removeProfilesFromList(ctx context.Context, listID string, profileIDs []string) (int, error) {
+	endpoint := path.Join(listsPath, listID, "relationships", membersPath)
+
+	var (
+		removed int
+		steps   []StepResult
+	)
+	for start := 0; start < len(profileIDs); start += maxListRelationshipProfiles {
+		end := start + maxListRelationshipProfiles
+		if end > len(profileIDs) {
+			end = len(profileIDs)
+		}
+
+		data := make([]map[string]string, 0, end-start)
+		for _, id := range profileIDs[start:end] {
+			data = append(data, map[string]string{"type": profileType, "id": id})
+		}
+		request := map[string]interface{}{"data": data}
+
+		name := fmt.Sprintf("remove profiles %d-%d", start, end-1)
+		if err := c.doReq(ctx, OperationRemoveProfilesFromList, http.MethodDelete, endpoint, nil, request, nil); err != nil {
+			if removed == 0 {
+				return 0, err
+			}
+			steps = append(steps, StepResult{Name: name, Err: err, Retryable: true})
+			return removed, &PartialError{Operation: "remove profiles from list", Steps: steps}
+		}
+		removed = end
+		steps = append(steps, StepResult{Name: name, Done: true})
+	}
+	return removed, nil
+}
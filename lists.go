@@ -0,0 +1,55 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/monetha/go-klaviyo/models/list"
+	"github.com/monetha/go-klaviyo/operations/getlists"
+)
+
+const listsPath = "lists"
+
+// GetLists retrieves a list of lists from Klaviyo. It returns a single page of lists.
+func (c *Client) GetLists(ctx context.Context, params ...getlists.Param) ([]*list.ExistingList, error) {
+	fields := url.Values{}
+	for _, p := range params {
+		p.Apply(fields)
+	}
+
+	ls, _, err := c.getListsPage(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return ls, nil
+}
+
+// GetList retrieves a specific list by its ID from Klaviyo.
+func (c *Client) GetList(ctx context.Context, listID string) (*list.ExistingList, error) {
+	endpoint := path.Join(listsPath, listID)
+
+	var result struct {
+		Data list.ExistingList `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetList, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// getListsPage retrieves a single page of lists and returns the cursor of the next page, if any.
+func (c *Client) getListsPage(ctx context.Context, fields url.Values) ([]*list.ExistingList, string, error) {
+	var result struct {
+		Data  []*list.ExistingList `json:"data"`
+		Links links                `json:"links"`
+	}
+	if err := c.doReq(ctx, OperationGetLists, http.MethodGet, listsPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data, result.Links.nextCursor(), nil
+}
@@ -0,0 +1,118 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_ResolveListID(t *testing.T) {
+	t.Run("resolve list ID by name with valid API key", func(t *testing.T) {
+		withHTTPRecorder("tests/resolve_list_id_valid_api_key", func(c *http.Client) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			ctx := context.TODO()
+			id, err := kc.ResolveListID(ctx, "Newsletter")
+
+			require.NoError(t, err)
+			require.Equal(t, "Y6nRLr", id)
+
+			// the second resolution is served from the cache
+			id, err = kc.ResolveListID(ctx, "Newsletter")
+
+			require.NoError(t, err)
+			require.Equal(t, "Y6nRLr", id)
+		})
+	})
+
+	t.Run("resolve ambiguous list name with valid API key", func(t *testing.T) {
+		withHTTPRecorder("tests/resolve_list_id_valid_api_key", func(c *http.Client) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			ctx := context.TODO()
+			id, err := kc.ResolveListID(ctx, "Customers")
+
+			var e *klaviyo.ErrAmbiguousName
+			require.ErrorAs(t, err, &e)
+			require.Equal(t, []string{"UTd5ui", "Xk8pQa"}, e.IDs)
+			require.Empty(t, id)
+		})
+	})
+}
+
+func TestClient_CleanList(t *testing.T) {
+	const members = `{"data":[` +
+		`{"type":"profile","id":"p1","attributes":{"email":"ok@example.com","subscriptions":{"email":{"marketing":{"consent":"SUBSCRIBED","can_receive_email_marketing":true,"suppressions":[],"list_suppressions":[]}}}}},` +
+		`{"type":"profile","id":"p2","attributes":{"email":"bounced@example.com","subscriptions":{"email":{"marketing":{"consent":"SUBSCRIBED","can_receive_email_marketing":false,"suppressions":[{"reason":"HARD_BOUNCE","timestamp":"2024-01-30T12:00:00Z"}]}}}}},` +
+		`{"type":"profile","id":"p3","attributes":{"email":"list@example.com","subscriptions":{"email":{"marketing":{"consent":"SUBSCRIBED","can_receive_email_marketing":true,"list_suppressions":[{"list_id":"Y6nRLr","reason":"UNSUBSCRIBE"},{"list_id":"other","reason":"UNSUBSCRIBE"}]}}}}},` +
+		`{"type":"profile","id":"p4","attributes":{"email":"never@example.com","subscriptions":{"email":{"marketing":{"consent":"NEVER_SUBSCRIBED","can_receive_email_marketing":false}}}}}` +
+		`],"links":{"next":null}}`
+
+	for _, dryRun := range []bool{true, false} {
+		var removed []string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch req.Method {
+			case http.MethodGet:
+				require.Equal(t, "/api/lists/Y6nRLr/profiles", req.URL.Path)
+				require.Equal(t, "subscriptions", req.URL.Query().Get("additional-fields[profile]"))
+				return jsonResponse(http.StatusOK, members), nil
+			case http.MethodDelete:
+				require.Equal(t, "/api/lists/Y6nRLr/relationships/profiles", req.URL.Path)
+				var body struct {
+					Data []struct {
+						ID string `json:"id"`
+					} `json:"data"`
+				}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+				for _, d := range body.Data {
+					removed = append(removed, d.ID)
+				}
+				return &http.Response{StatusCode: http.StatusNoContent, Header: http.Header{}, Body: http.NoBody}, nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL)
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		report, err := kc.CleanList(context.TODO(), "Y6nRLr", dryRun)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Checked)
+		require.Equal(t, []klaviyo.UnreachableMember{
+			{ProfileID: "p2", Email: "bounced@example.com", Reasons: []string{"HARD_BOUNCE"}},
+			{ProfileID: "p3", Email: "list@example.com", Reasons: []string{"UNSUBSCRIBE"}},
+			{ProfileID: "p4", Email: "never@example.com", Reasons: []string{klaviyo.ReasonCannotReceiveEmailMarketing}},
+		}, report.Unreachable)
+
+		if dryRun {
+			require.Zero(t, report.Removed)
+			require.Empty(t, removed)
+		} else {
+			require.Equal(t, 3, report.Removed)
+			require.Equal(t, []string{"p2", "p3", "p4"}, removed)
+		}
+	}
+}
+
+func TestClient_GetList_NotFound(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusNotFound, `{"errors":[{"id":"e1","status":404,"code":"not_found","title":"Not found.","detail":"A list with id Y6nRLr does not exist."}]}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	l, err := kc.GetList(context.TODO(), "Y6nRLr")
+
+	require.ErrorIs(t, err, klaviyo.ErrNotFound)
+	require.NotErrorIs(t, err, klaviyo.ErrProfileDoesNotExist)
+	var apiErr *klaviyo.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, klaviyo.OperationGetList, apiErr.Operation)
+	require.Nil(t, l)
+}
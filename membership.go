@@ -0,0 +1,125 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+const (
+	membersPath        = "profiles"
+	maxMembersPageSize = 100
+)
+
+// MembershipPoller detects profiles joining and leaving a list or a segment by periodically fetching
+// all its members and comparing them with the members fetched by the previous poll. It is a substitute
+// for webhooks on accounts without webhook access. Every poll pages through all the members,
+// so the interval should be chosen according to the size of the list or segment.
+//
+// A MembershipPoller is not safe for concurrent use.
+type MembershipPoller struct {
+	client   *Client
+	clock    clock.Clock
+	getPage  pageFunc[*profile.ExistingProfile]
+	interval time.Duration
+	members  map[string]struct{}
+}
+
+// NewListMembershipPoller creates a poller of the members of the list with the given ID.
+func (c *Client) NewListMembershipPoller(listID string, interval time.Duration) *MembershipPoller {
+	return c.newMembershipPoller(OperationGetListProfiles, path.Join(listsPath, listID, membersPath), interval)
+}
+
+// NewSegmentMembershipPoller creates a poller of the members of the segment with the given ID.
+func (c *Client) NewSegmentMembershipPoller(segmentID string, interval time.Duration) *MembershipPoller {
+	return c.newMembershipPoller(OperationGetSegmentProfiles, path.Join(segmentsPath, segmentID, membersPath), interval)
+}
+
+func (c *Client) newMembershipPoller(op Operation, endpoint string, interval time.Duration) *MembershipPoller {
+	return &MembershipPoller{
+		client:   c,
+		clock:    c.options.clock,
+		interval: interval,
+		getPage: func(ctx context.Context, fields url.Values) ([]*profile.ExistingProfile, string, error) {
+			var result struct {
+				Data  []*profile.ExistingProfile `json:"data"`
+				Links links                      `json:"links"`
+			}
+			if err := c.doReq(ctx, op, http.MethodGet, endpoint, fields, nil, &result); err != nil {
+				return nil, "", err
+			}
+			return result.Data, result.Links.nextCursor(), nil
+		},
+	}
+}
+
+// Poll fetches the current members and returns the IDs of the profiles that joined and left, sorted,
+// since the previous poll. The first poll only records the current members and returns no changes.
+// If the poll fails, the members recorded by the previous poll are kept.
+func (p *MembershipPoller) Poll(ctx context.Context) (joined, left []string, err error) {
+	fields := url.Values{}
+	fields.Set("page[size]", strconv.Itoa(maxMembersPageSize))
+
+	members := make(map[string]struct{})
+	paginator := newPaginator(p.client, p.getPage, fields)
+	for paginator.HasNext() {
+		ps, err := paginator.Next(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, pr := range ps {
+			members[pr.Id] = struct{}{}
+		}
+	}
+
+	if p.members != nil {
+		for id := range members {
+			if _, ok := p.members[id]; !ok {
+				joined = append(joined, id)
+			}
+		}
+		for id := range p.members {
+			if _, ok := members[id]; !ok {
+				left = append(left, id)
+			}
+		}
+		sort.Strings(joined)
+		sort.Strings(left)
+	}
+	p.members = members
+
+	return joined, left, nil
+}
+
+// Run polls the members every interval until the context is done or a poll fails, calling onJoined and onLeft
+// for every profile that joined or left. Either callback can be nil. Run returns the error of the failed poll
+// or the error of the context.
+func (p *MembershipPoller) Run(ctx context.Context, onJoined, onLeft func(profileID string)) error {
+	for {
+		joined, left, err := p.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, id := range joined {
+			if onJoined != nil {
+				onJoined(id)
+			}
+		}
+		for _, id := range left {
+			if onLeft != nil {
+				onLeft(id)
+			}
+		}
+
+		if err := p.clock.Sleep(ctx, p.interval); err != nil {
+			return err
+		}
+	}
+}
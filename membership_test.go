@@ -0,0 +1,101 @@
+package klaviyo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+func membersResponse(ids ...string) *http.Response {
+	data := make([]string, 0, len(ids))
+	for _, id := range ids {
+		data = append(data, fmt.Sprintf(`{"type":"profile","id":%q,"attributes":{}}`, id))
+	}
+	return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"links":{"next":null}}`)
+}
+
+func TestMembershipPoller(t *testing.T) {
+	snapshots := [][]string{
+		{"01HN6AFEHGF6F77WJRKT1C9JHA", "01HN6AFEHGF6F77WJRKT1C9JHB"},
+		{"01HN6AFEHGF6F77WJRKT1C9JHB", "01HN6AFEHGF6F77WJRKT1C9JHC"},
+		{"01HN6AFEHGF6F77WJRKT1C9JHB", "01HN6AFEHGF6F77WJRKT1C9JHC"},
+	}
+
+	t.Run("poll list membership", func(t *testing.T) {
+		var poll int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/api/lists/Y6nRLr/profiles", req.URL.Path)
+			resp := membersResponse(snapshots[poll]...)
+			poll++
+			return resp, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+		p := kc.NewListMembershipPoller("Y6nRLr", time.Minute)
+		ctx := context.TODO()
+
+		joined, left, err := p.Poll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, joined, "first poll records the members")
+		require.Empty(t, left, "first poll records the members")
+
+		joined, left, err = p.Poll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"01HN6AFEHGF6F77WJRKT1C9JHC"}, joined)
+		require.Equal(t, []string{"01HN6AFEHGF6F77WJRKT1C9JHA"}, left)
+
+		joined, left, err = p.Poll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, joined)
+		require.Empty(t, left)
+	})
+
+	t.Run("run segment membership poller", func(t *testing.T) {
+		var poll int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/api/segments/Xp4Qw2/profiles", req.URL.Path)
+			resp := membersResponse(snapshots[poll]...)
+			poll++
+			return resp, nil
+		})}
+
+		clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+		p := kc.NewSegmentMembershipPoller("Xp4Qw2", time.Minute)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var joined, left []string
+		done := make(chan error)
+		go func() {
+			done <- p.Run(ctx,
+				func(id string) { joined = append(joined, id) },
+				func(id string) { left = append(left, id) },
+			)
+		}()
+
+		waitForSleeper(t, clk)
+		clk.Advance(time.Minute)
+		waitForSleeper(t, clk)
+		cancel()
+
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.Equal(t, []string{"01HN6AFEHGF6F77WJRKT1C9JHC"}, joined)
+		require.Equal(t, []string{"01HN6AFEHGF6F77WJRKT1C9JHA"}, left)
+	})
+}
+
+func waitForSleeper(t *testing.T, clk *clock.Manual) {
+	t.Helper()
+	require.Eventually(t, func() bool { return clk.Sleepers() == 1 }, time.Second, time.Millisecond)
+}
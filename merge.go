@@ -0,0 +1,50 @@
+package klaviyo
+
+import (
+	"context"
+	"sort"
+
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+// MergePlan describes the effect of merging a source profile into a destination profile, so that operators
+// can review the destructive merge of duplicates before performing it.
+type MergePlan struct {
+	Destination *profile.ExistingProfile
+	Source      *profile.ExistingProfile
+	// Conflicts are the fields set to different values in both profiles; A is the value of the destination
+	// and B the value of the source. Only one of the values is kept by the merge.
+	Conflicts []profile.Conflict
+	// Added are the fields, keyed as by profile.Flatten, that are set only in the source profile
+	// and are added to the destination by the merge.
+	Added []string
+}
+
+// PlanMerge retrieves the destination and the source profiles with the given IDs and reports their
+// conflicting attributes, e.g. differing first names or phone numbers. It doesn't modify the profiles.
+func (c *Client) PlanMerge(ctx context.Context, destinationID, sourceID string) (*MergePlan, error) {
+	destination, err := c.GetProfile(ctx, destinationID)
+	if err != nil {
+		return nil, err
+	}
+	source, err := c.GetProfile(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	fd := profile.Flatten(destination, profile.FlattenOptions{})
+	var added []string
+	for k := range profile.Flatten(source, profile.FlattenOptions{}) {
+		if _, ok := fd[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+
+	return &MergePlan{
+		Destination: destination,
+		Source:      source,
+		Conflicts:   profile.Conflicts(destination, source),
+		Added:       added,
+	}, nil
+}
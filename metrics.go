@@ -0,0 +1,110 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/monetha/go-klaviyo/models/metric"
+)
+
+const metricsPath = "metrics"
+
+// GetMetrics retrieves a list of metrics from Klaviyo.
+func (c *Client) GetMetrics(ctx context.Context) ([]*metric.ExistingMetric, error) {
+	ms, _, err := c.getMetricsPage(ctx, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+// GetMetric retrieves a specific metric by its ID from Klaviyo.
+func (c *Client) GetMetric(ctx context.Context, metricID string) (*metric.ExistingMetric, error) {
+	endpoint := path.Join(metricsPath, metricID)
+
+	var result struct {
+		Data metric.ExistingMetric `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetMetric, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// GetMetricByName returns the metric with exactly the given name (case-sensitive), paging through all
+// the metrics of the account, because the API doesn't allow filtering metrics by name.
+// If there is no such metric, ErrNameNotFound is returned; if several metrics have the name
+// (e.g. metrics of different integrations), ErrAmbiguousName is returned.
+func (c *Client) GetMetricByName(ctx context.Context, name string) (*metric.ExistingMetric, error) {
+	paginator := newPaginator(c, c.getMetricsPage, url.Values{})
+
+	var found []*metric.ExistingMetric
+	for paginator.HasNext() {
+		ms, err := paginator.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range ms {
+			if m.Attributes.Name == name {
+				found = append(found, m)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, &ErrNameNotFound{Kind: "metric", Name: name}
+	case 1:
+		return found[0], nil
+	default:
+		ids := make([]string, 0, len(found))
+		for _, m := range found {
+			ids = append(ids, m.ID)
+		}
+		return nil, &ErrAmbiguousName{Kind: "metric", Name: name, IDs: ids}
+	}
+}
+
+// checkMetricName logs a warning if known metric names are registered with WithKnownMetrics and the name
+// is not one of them. If the name differs from a known one only in case or surrounding whitespace,
+// the known name is suggested, catching e.g. "Placed order" vs "Placed Order".
+func (c *Client) checkMetricName(name string) {
+	known := c.options.knownMetrics
+	if len(known) == 0 {
+		return
+	}
+	if _, ok := known[name]; ok {
+		return
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	for k := range known {
+		if strings.ToLower(strings.TrimSpace(k)) == normalized {
+			c.logger.Warn("misspelled metric name",
+				"metric", name,
+				"known_metric", k,
+			)
+			return
+		}
+	}
+
+	c.logger.Warn("unknown metric name", "metric", name)
+}
+
+// getMetricsPage retrieves a single page of metrics and returns the cursor of the next page, if any.
+func (c *Client) getMetricsPage(ctx context.Context, fields url.Values) ([]*metric.ExistingMetric, string, error) {
+	var result struct {
+		Data  []*metric.ExistingMetric `json:"data"`
+		Links links                    `json:"links"`
+	}
+	if err := c.doReq(ctx, OperationGetMetrics, http.MethodGet, metricsPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data, result.Links.nextCursor(), nil
+}
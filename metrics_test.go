@@ -0,0 +1,95 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_GetMetricByName(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("page[cursor]") == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"metric","id":"Xj3Zw4","attributes":{"name":"Reward"}},{"type":"metric","id":"Rk2Pq1","attributes":{"name":"Placed Order","integration":{"id":"0eMvjm","name":"Shopify","category":"eCommerce"}}}],"links":{"next":"https://a.klaviyo.com/api/metrics/?page%5Bcursor%5D=bmV4dA"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"metric","id":"Tn7Ye3","attributes":{"name":"Placed Order","integration":{"id":"7FtS4J","name":"API","category":"API"}}},{"type":"metric","id":"Lm9Bv2","attributes":{"name":"Placed order"}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	t.Run("get metric by exact name", func(t *testing.T) {
+		m, err := kc.GetMetricByName(ctx, "Placed order")
+
+		require.NoError(t, err)
+		require.Equal(t, "Lm9Bv2", m.ID)
+	})
+
+	t.Run("get metric by ambiguous name", func(t *testing.T) {
+		m, err := kc.GetMetricByName(ctx, "Placed Order")
+
+		var e *klaviyo.ErrAmbiguousName
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, []string{"Rk2Pq1", "Tn7Ye3"}, e.IDs)
+		require.Nil(t, m)
+	})
+
+	t.Run("get metric by unknown name", func(t *testing.T) {
+		m, err := kc.GetMetricByName(ctx, "Viewed Product")
+
+		var e *klaviyo.ErrNameNotFound
+		require.ErrorAs(t, err, &e)
+		require.Nil(t, m)
+	})
+}
+
+func TestWithKnownMetrics(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusAccepted, ""), nil
+	})}
+
+	tests := []struct {
+		name       string
+		metricName string
+		warning    string
+		fields     map[string]interface{}
+	}{
+		{name: "known metric", metricName: "Placed Order"},
+		{
+			name:       "misspelled metric",
+			metricName: "Placed order",
+			warning:    "misspelled metric name",
+			fields:     map[string]interface{}{"metric": "Placed order", "known_metric": "Placed Order"},
+		},
+		{
+			name:       "unknown metric",
+			metricName: "Placed Ordr",
+			warning:    "unknown metric name",
+			fields:     map[string]interface{}{"metric": "Placed Ordr"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.WarnLevel)
+			kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithKnownMetrics("Placed Order", "Reward"))
+
+			_, err := kc.CreateEvent(context.TODO(), &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", tt.metricName)
+			require.NoError(t, err)
+
+			if tt.warning == "" {
+				require.Zero(t, logs.Len())
+				return
+			}
+			entries := logs.FilterMessage(tt.warning).All()
+			require.Len(t, entries, 1)
+			require.Equal(t, tt.fields, entries[0].ContextMap())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package bulkimport
+
+import (
+	"time"
+)
+
+// Status is the status of a bulk import job.
+type Status string
+
+// Statuses of bulk import jobs.
+const (
+	StatusQueued     Status = "queued"
+	StatusProcessing Status = "processing"
+	StatusComplete   Status = "complete"
+	StatusCancelled  Status = "cancelled"
+)
+
+// IsFinal reports whether the status is final, i.e. the job doesn't change anymore.
+func (s Status) IsFinal() bool {
+	return s == StatusComplete || s == StatusCancelled
+}
+
+// ExistingJob represents the data structure for a profile bulk import job that is already created.
+type ExistingJob struct {
+	ID         string     `json:"id"`
+	Attributes Attributes `json:"attributes"`
+}
+
+// Attributes contains attributes of a profile bulk import job.
+type Attributes struct {
+	Status         Status     `json:"status"`
+	CreatedAt      *time.Time `json:"created_at"`
+	TotalCount     int        `json:"total_count"`
+	CompletedCount int        `json:"completed_count"`
+	FailedCount    int        `json:"failed_count"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	StartedAt      *time.Time `json:"started_at"`
+}
@@ -0,0 +1,234 @@
+package campaign
+
+import (
+	"time"
+
+	"github.com/monetha/go-klaviyo/internal/deepcopy"
+)
+
+// NewCampaign represents the data structure for a campaign that is not yet created.
+type NewCampaign struct {
+	Attributes NewAttributes `json:"attributes"`
+}
+
+// ExistingCampaign represents the data structure for a campaign that is already created.
+type ExistingCampaign struct {
+	ID         string             `json:"id"`
+	Attributes ExistingAttributes `json:"attributes"`
+	// Messages holds the campaign messages included with the campaign, if they were requested.
+	Messages []*ExistingMessage `json:"-"`
+}
+
+// NewAttributes contains common attributes for a campaign.
+type NewAttributes struct {
+	Name            string           `json:"name"`
+	Channel         string           `json:"channel,omitempty"`
+	Audiences       Audiences        `json:"audiences"`
+	SendStrategy    *SendStrategy    `json:"send_strategy,omitempty"`
+	SendOptions     *SendOptions     `json:"send_options,omitempty"`
+	TrackingOptions *TrackingOptions `json:"tracking_options,omitempty"`
+	Messages        *NewMessages     `json:"campaign-messages,omitempty"`
+}
+
+// ExistingAttributes contains attributes for a campaign that is already created, including timestamps.
+type ExistingAttributes struct {
+	Name            string           `json:"name"`
+	Status          string           `json:"status"`
+	Archived        bool             `json:"archived"`
+	Channel         string           `json:"channel"`
+	Audiences       Audiences        `json:"audiences"`
+	SendStrategy    *SendStrategy    `json:"send_strategy"`
+	SendOptions     *SendOptions     `json:"send_options"`
+	TrackingOptions *TrackingOptions `json:"tracking_options"`
+	CreatedAt       *time.Time       `json:"created_at"`
+	ScheduledAt     *time.Time       `json:"scheduled_at"`
+	UpdatedAt       *time.Time       `json:"updated_at"`
+	SendTime        *time.Time       `json:"send_time"`
+}
+
+// Audiences holds the IDs of the lists and segments a campaign is sent to or excluded from.
+// No excluded audiences are encoded as a missing field rather than null, which the API rejects.
+type Audiences struct {
+	Included []string `json:"included"`
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// SendStrategy describes how and when a campaign is sent.
+type SendStrategy struct {
+	Method           string                 `json:"method"`
+	OptionsStatic    *StaticSendOptions     `json:"options_static,omitempty"`
+	OptionsThrottled map[string]interface{} `json:"options_throttled,omitempty"`
+	OptionsSTO       map[string]interface{} `json:"options_sto,omitempty"`
+}
+
+// StaticSendOptions holds the options of the static send strategy.
+type StaticSendOptions struct {
+	Datetime                      time.Time `json:"datetime"`
+	IsLocal                       bool      `json:"is_local"`
+	SendPastRecipientsImmediately *bool     `json:"send_past_recipients_immediately,omitempty"`
+}
+
+// SendOptions holds the send options of a campaign.
+type SendOptions struct {
+	UseSmartSending    bool  `json:"use_smart_sending"`
+	IgnoreUnsubscribes *bool `json:"ignore_unsubscribes,omitempty"`
+}
+
+// TrackingOptions holds the tracking options of a campaign.
+type TrackingOptions struct {
+	IsAddUTM         bool                     `json:"is_add_utm"`
+	UTMParams        []map[string]interface{} `json:"utm_params,omitempty"`
+	IsTrackingClicks bool                     `json:"is_tracking_clicks"`
+	IsTrackingOpens  bool                     `json:"is_tracking_opens"`
+}
+
+// NewMessages wraps the messages of a campaign that is not yet created.
+type NewMessages struct {
+	Data []*NewMessage `json:"data"`
+}
+
+// NewMessage represents the data structure for a campaign message that is not yet created.
+type NewMessage struct {
+	Type       string            `json:"type"`
+	Attributes MessageAttributes `json:"attributes"`
+}
+
+// ExistingMessage represents the data structure for a campaign message that is already created.
+type ExistingMessage struct {
+	ID         string            `json:"id"`
+	Attributes MessageAttributes `json:"attributes"`
+}
+
+// MessageAttributes contains attributes of a campaign message.
+type MessageAttributes struct {
+	Channel       string                 `json:"channel"`
+	Label         string                 `json:"label,omitempty"`
+	Content       map[string]interface{} `json:"content,omitempty"`
+	RenderOptions map[string]interface{} `json:"render_options,omitempty"`
+}
+
+// Override is an interface that any campaign override should implement.
+type Override interface {
+	Apply(*NewCampaign)
+}
+
+// OverrideFunc is a function type that implements the Override interface.
+type OverrideFunc func(*NewCampaign)
+
+// Apply calls the underlying function to update the campaign.
+func (f OverrideFunc) Apply(c *NewCampaign) {
+	f(c)
+}
+
+// WithName sets the name of the campaign.
+func WithName(name string) Override {
+	return OverrideFunc(func(c *NewCampaign) {
+		c.Attributes.Name = name
+	})
+}
+
+// WithAudiences sets the included and excluded audiences (list or segment IDs) of the campaign.
+func WithAudiences(included, excluded []string) Override {
+	return OverrideFunc(func(c *NewCampaign) {
+		c.Attributes.Audiences = Audiences{
+			Included: included,
+			Excluded: excluded,
+		}
+	})
+}
+
+// WithSendTime schedules the campaign to be sent statically at the given time.
+func WithSendTime(sendTime time.Time) Override {
+	return OverrideFunc(func(c *NewCampaign) {
+		c.Attributes.SendStrategy = &SendStrategy{
+			Method: "static",
+			OptionsStatic: &StaticSendOptions{
+				Datetime: sendTime,
+			},
+		}
+	})
+}
+
+// ToNewCampaign creates a NewCampaign that copies the settings, audiences and messages of the existing campaign.
+// The new campaign shares no data with the existing one, so either can be modified without affecting the other.
+func (c *ExistingCampaign) ToNewCampaign() *NewCampaign {
+	if c == nil {
+		return nil
+	}
+
+	attr := c.Attributes
+	nc := &NewCampaign{
+		Attributes: NewAttributes{
+			Name:    attr.Name,
+			Channel: attr.Channel,
+			Audiences: Audiences{
+				Included: append([]string{}, attr.Audiences.Included...),
+				Excluded: append([]string(nil), attr.Audiences.Excluded...),
+			},
+			SendStrategy:    attr.SendStrategy.clone(),
+			SendOptions:     attr.SendOptions.clone(),
+			TrackingOptions: attr.TrackingOptions.clone(),
+		},
+	}
+
+	if len(c.Messages) > 0 {
+		messages := &NewMessages{}
+		for _, m := range c.Messages {
+			messages.Data = append(messages.Data, &NewMessage{
+				Type:       "campaign-message",
+				Attributes: m.Attributes.clone(),
+			})
+		}
+		nc.Attributes.Messages = messages
+	}
+
+	return nc
+}
+
+// clone returns a deep copy of the send strategy, or nil if s is nil.
+func (s *SendStrategy) clone() *SendStrategy {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	if s.OptionsStatic != nil {
+		static := *s.OptionsStatic
+		static.SendPastRecipientsImmediately = deepcopy.Ptr(static.SendPastRecipientsImmediately)
+		c.OptionsStatic = &static
+	}
+	c.OptionsThrottled = deepcopy.Map(s.OptionsThrottled)
+	c.OptionsSTO = deepcopy.Map(s.OptionsSTO)
+	return &c
+}
+
+// clone returns a deep copy of the send options, or nil if o is nil.
+func (o *SendOptions) clone() *SendOptions {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	c.IgnoreUnsubscribes = deepcopy.Ptr(o.IgnoreUnsubscribes)
+	return &c
+}
+
+// clone returns a deep copy of the tracking options, or nil if o is nil.
+func (o *TrackingOptions) clone() *TrackingOptions {
+	if o == nil {
+		return nil
+	}
+	c := *o
+	if o.UTMParams != nil {
+		c.UTMParams = make([]map[string]interface{}, len(o.UTMParams))
+		for i, p := range o.UTMParams {
+			c.UTMParams[i] = deepcopy.Map(p)
+		}
+	}
+	return &c
+}
+
+// clone returns a deep copy of the message attributes.
+func (a MessageAttributes) clone() MessageAttributes {
+	a.Content = deepcopy.Map(a.Content)
+	a.RenderOptions = deepcopy.Map(a.RenderOptions)
+	return a
+}
@@ -0,0 +1,64 @@
+package campaign_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/campaign"
+)
+
+func TestExistingCampaign_ToNewCampaign(t *testing.T) {
+	pastRecipients, ignoreUnsubscribes := true, false
+	existing := &campaign.ExistingCampaign{
+		ID: "01HN6AFEHGF6F77WJRKT1C9JHA",
+		Attributes: campaign.ExistingAttributes{
+			Name:      "Winter sale",
+			Channel:   "email",
+			Audiences: campaign.Audiences{Included: []string{"Y6nRLr"}},
+			SendStrategy: &campaign.SendStrategy{
+				Method: "static",
+				OptionsStatic: &campaign.StaticSendOptions{
+					Datetime:                      time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC),
+					SendPastRecipientsImmediately: &pastRecipients,
+				},
+			},
+			SendOptions: &campaign.SendOptions{UseSmartSending: true, IgnoreUnsubscribes: &ignoreUnsubscribes},
+			TrackingOptions: &campaign.TrackingOptions{
+				IsAddUTM:  true,
+				UTMParams: []map[string]interface{}{{"name": "utm_source", "value": "klaviyo"}},
+			},
+		},
+		Messages: []*campaign.ExistingMessage{{
+			ID:         "01HN6AFEHGF6F77WJRKT1C9JHB",
+			Attributes: campaign.MessageAttributes{Channel: "email", Content: map[string]interface{}{"subject": "Sale"}},
+		}},
+	}
+
+	nc := existing.ToNewCampaign()
+
+	t.Run("the copy shares no data with the existing campaign", func(t *testing.T) {
+		*nc.Attributes.SendStrategy.OptionsStatic.SendPastRecipientsImmediately = false
+		nc.Attributes.SendStrategy.Method = "throttled"
+		*nc.Attributes.SendOptions.IgnoreUnsubscribes = true
+		nc.Attributes.TrackingOptions.UTMParams[0]["value"] = "copy"
+		nc.Attributes.Audiences.Included[0] = "XyZ123"
+		nc.Attributes.Messages.Data[0].Attributes.Content["subject"] = "Copy"
+
+		a := existing.Attributes
+		require.Equal(t, "static", a.SendStrategy.Method)
+		require.True(t, *a.SendStrategy.OptionsStatic.SendPastRecipientsImmediately)
+		require.False(t, *a.SendOptions.IgnoreUnsubscribes)
+		require.Equal(t, "klaviyo", a.TrackingOptions.UTMParams[0]["value"])
+		require.Equal(t, []string{"Y6nRLr"}, a.Audiences.Included)
+		require.Equal(t, "Sale", existing.Messages[0].Attributes.Content["subject"])
+	})
+
+	t.Run("no excluded audiences are not encoded as null", func(t *testing.T) {
+		b, err := json.Marshal(nc.Attributes.Audiences)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"included":["XyZ123"]}`, string(b))
+	})
+}
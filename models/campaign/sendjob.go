@@ -0,0 +1,28 @@
+package campaign
+
+// SendJobStatus is the status of a campaign send job.
+type SendJobStatus string
+
+// Statuses of campaign send jobs.
+const (
+	SendJobStatusQueued     SendJobStatus = "queued"
+	SendJobStatusProcessing SendJobStatus = "processing"
+	SendJobStatusComplete   SendJobStatus = "complete"
+	SendJobStatusCancelled  SendJobStatus = "cancelled"
+)
+
+// IsFinal reports whether the status is final, i.e. the send job is no longer running.
+func (s SendJobStatus) IsFinal() bool {
+	return s == SendJobStatusComplete || s == SendJobStatusCancelled
+}
+
+// SendJob represents the data structure for a campaign send job. The ID of a send job is the ID of its campaign.
+type SendJob struct {
+	ID         string            `json:"id"`
+	Attributes SendJobAttributes `json:"attributes"`
+}
+
+// SendJobAttributes contains attributes of a campaign send job.
+type SendJobAttributes struct {
+	Status SendJobStatus `json:"status"`
+}
@@ -0,0 +1,81 @@
+package event
+
+import (
+	"time"
+)
+
+// Metric names of the canonical browse events, as expected by Klaviyo's prebuilt browse and cart abandonment flows.
+const (
+	MetricViewedProduct = "Viewed Product"
+	MetricAddedToCart   = "Added to Cart"
+)
+
+// ProductView is a view of a product page.
+type ProductView struct {
+	// Time is the time the product was viewed. If zero, Klaviyo uses the time the event is received.
+	Time        time.Time
+	ProductID   string
+	SKU         string
+	ProductName string
+	// URL is the URL of the product page.
+	URL      string
+	ImageURL string
+	Price    float64
+	// CompareAtPrice is the price of the product before a discount, if any.
+	CompareAtPrice float64
+	Categories     []string
+	Brand          string
+}
+
+// CartAddition is an addition of an item to a cart.
+type CartAddition struct {
+	// Time is the time the item was added. If zero, Klaviyo uses the time the event is received.
+	Time time.Time
+	// AddedItem is the item added to the cart.
+	AddedItem Item
+	// Items are all the items in the cart, including the added one.
+	Items []Item
+	// Value is the total value of the cart.
+	Value       float64
+	CheckoutURL string
+}
+
+// ViewedProduct returns the canonical "Viewed Product" event of the product view, to be created with
+// the MetricViewedProduct metric. The price of the product is used as the value of the event.
+func ViewedProduct(v ProductView) *NewEvent {
+	properties := map[string]interface{}{
+		"ProductID":   v.ProductID,
+		"SKU":         v.SKU,
+		"ProductName": v.ProductName,
+		"Price":       v.Price,
+		"Categories":  stringsOrEmpty(v.Categories),
+	}
+	setString(properties, "URL", v.URL)
+	setString(properties, "ImageURL", v.ImageURL)
+	setString(properties, "Brand", v.Brand)
+	if v.CompareAtPrice != 0 {
+		properties["CompareAtPrice"] = v.CompareAtPrice
+	}
+	return newEvent(v.Time, v.Price, "", properties)
+}
+
+// AddedToCart returns the canonical "Added to Cart" event of the cart addition, to be created with
+// the MetricAddedToCart metric. The total value of the cart is used as the value of the event.
+func AddedToCart(a CartAddition) *NewEvent {
+	it := a.AddedItem
+	properties := map[string]interface{}{
+		"$value":               a.Value,
+		"AddedItemProductID":   it.ProductID,
+		"AddedItemSKU":         it.SKU,
+		"AddedItemProductName": it.ProductName,
+		"AddedItemPrice":       it.itemPrice(),
+		"AddedItemQuantity":    it.Quantity,
+		"AddedItemCategories":  stringsOrEmpty(it.Categories),
+		"ItemNames":            itemNames(a.Items),
+		"Items":                items(a.Items),
+	}
+	setString(properties, "AddedItemURL", it.ProductURL)
+	setString(properties, "AddedItemImageURL", it.ImageURL)
+	setString(properties, "CheckoutURL", a.CheckoutURL)
+	return newEvent(a.Time, a.Value, "", properties)
+}
@@ -0,0 +1,65 @@
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+func TestViewedProduct(t *testing.T) {
+	e := event.ViewedProduct(event.ProductView{
+		ProductID:      "p2",
+		SKU:            "sku-2",
+		ProductName:    "Tea",
+		URL:            "https://example.com/tea",
+		ImageURL:       "https://example.com/tea.png",
+		Price:          10,
+		CompareAtPrice: 12.5,
+		Categories:     []string{"Food"},
+	})
+
+	require.Empty(t, e.Time)
+	require.Equal(t, 10.0, e.Value)
+
+	b, err := json.Marshal(e.StructuredProperties)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"ProductID": "p2", "SKU": "sku-2", "ProductName": "Tea", "URL": "https://example.com/tea",
+		"ImageURL": "https://example.com/tea.png", "Price": 10, "CompareAtPrice": 12.5, "Categories": ["Food"]}`, string(b))
+}
+
+func TestAddedToCart(t *testing.T) {
+	e := event.AddedToCart(event.CartAddition{
+		Time:        order.Time,
+		AddedItem:   order.Items[1],
+		Items:       order.Items,
+		Value:       29.98,
+		CheckoutURL: "https://example.com/cart",
+	})
+
+	require.Equal(t, "2024-01-30T12:00:00Z", e.Time)
+	require.Equal(t, 29.98, e.Value)
+
+	b, err := json.Marshal(e.StructuredProperties)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"$value": 29.98,
+		"AddedItemProductID": "p2",
+		"AddedItemSKU": "sku-2",
+		"AddedItemProductName": "Tea",
+		"AddedItemPrice": 10,
+		"AddedItemQuantity": 1,
+		"AddedItemCategories": ["Kitchen", "Food"],
+		"AddedItemImageURL": "https://example.com/tea.png",
+		"CheckoutURL": "https://example.com/cart",
+		"ItemNames": ["Mug", "Tea"],
+		"Items": [
+			{"ProductID": "p1", "SKU": "sku-1", "ProductName": "Mug", "Quantity": 2, "ItemPrice": 9.99, "RowTotal": 19.98,
+				"Categories": ["Kitchen"], "Brand": "Acme"},
+			{"ProductID": "p2", "SKU": "sku-2", "ProductName": "Tea", "Quantity": 1, "ItemPrice": 10, "RowTotal": 10,
+				"Categories": ["Kitchen", "Food"], "ImageURL": "https://example.com/tea.png"}
+		]
+	}`, string(b))
+}
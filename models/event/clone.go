@@ -0,0 +1,19 @@
+package event
+
+import (
+	"github.com/monetha/go-klaviyo/internal/deepcopy"
+)
+
+// Clone returns a deep copy of the event, so that the copy can be modified without affecting the original,
+// e.g. when a template event is used by several goroutines.
+func (e *NewEvent) Clone() *NewEvent {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.Properties = deepcopy.StringMap(e.Properties)
+	c.StructuredProperties = deepcopy.Map(e.StructuredProperties)
+	c.Profile = deepcopy.Value(e.Profile)
+	c.Metric = deepcopy.Value(e.Metric)
+	return &c
+}
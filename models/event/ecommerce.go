@@ -0,0 +1,269 @@
+package event
+
+import (
+	"strconv"
+	"time"
+)
+
+// Metric names of the canonical ecommerce events, as expected by Klaviyo's prebuilt flows and reports.
+const (
+	MetricPlacedOrder     = "Placed Order"
+	MetricOrderedProduct  = "Ordered Product"
+	MetricStartedCheckout = "Started Checkout"
+)
+
+// Item is a line item of an order or a checkout.
+type Item struct {
+	ProductID   string
+	SKU         string
+	ProductName string
+	Quantity    int
+	// ItemPrice is the price of a single unit of the item.
+	ItemPrice float64
+	// RowTotal is the total price of the line item. If zero, ItemPrice multiplied by Quantity is used.
+	RowTotal float64
+	// UnitPrice is the price of a single unit of the item in minor units. If set, it takes precedence over
+	// ItemPrice, and the row total is UnitPrice multiplied by Quantity unless RowTotal is set.
+	UnitPrice  Money
+	ProductURL string
+	ImageURL   string
+	Categories []string
+	Brand      string
+}
+
+// Order is a placed order.
+type Order struct {
+	OrderID string
+	// Time is the time the order was placed. If zero, Klaviyo uses the time the event is received.
+	Time time.Time
+	// Value is the total value of the order.
+	Value         float64
+	DiscountCode  string
+	DiscountValue float64
+	Items         []Item
+	// Total is the total value of the order in minor units. If set, it takes precedence over Value,
+	// and its currency is sent as the currency of the event value.
+	Total Money
+	// Discount is the discount of the order in minor units. If set, it takes precedence over DiscountValue.
+	Discount Money
+}
+
+// Checkout is a started checkout.
+type Checkout struct {
+	// CheckoutID identifies the checkout, it's used to deduplicate the event.
+	CheckoutID string
+	// Time is the time the checkout was started. If zero, Klaviyo uses the time the event is received.
+	Time time.Time
+	// Value is the total value of the checkout.
+	Value       float64
+	CheckoutURL string
+	Items       []Item
+	// Total is the total value of the checkout in minor units. If set, it takes precedence over Value,
+	// and its currency is sent as the currency of the event value.
+	Total Money
+}
+
+// Validate returns an error if an amount of money of the order is invalid, or the amounts are in different currencies.
+func (o Order) Validate() error {
+	return validateMoney(append([]Money{o.Total, o.Discount}, unitPrices(o.Items)...)...)
+}
+
+// Validate returns an error if an amount of money of the checkout is invalid, or the amounts are in different currencies.
+func (c Checkout) Validate() error {
+	return validateMoney(append([]Money{c.Total}, unitPrices(c.Items)...)...)
+}
+
+// PlacedOrder returns the canonical "Placed Order" event of the order, to be created with the MetricPlacedOrder metric.
+// The order ID is used as the unique ID of the event. Amounts of money are rendered as decimal numbers in the
+// major units of their currency; use Validate to check their consistency first.
+func PlacedOrder(o Order) *NewEvent {
+	properties := map[string]interface{}{
+		"OrderId":    o.OrderID,
+		"Categories": itemCategories(o.Items),
+		"ItemNames":  itemNames(o.Items),
+		"Brands":     itemBrands(o.Items),
+		"Items":      items(o.Items),
+	}
+	if o.DiscountCode != "" {
+		properties["DiscountCode"] = o.DiscountCode
+	}
+	if discount := amount(o.Discount, o.DiscountValue); discount != 0 {
+		properties["DiscountValue"] = discount
+	}
+	e := newEvent(o.Time, amount(o.Total, o.Value), o.OrderID, properties)
+	e.ValueCurrency = o.Total.Currency
+	return e
+}
+
+// OrderedProducts returns the canonical "Ordered Product" events of the order, one per line item,
+// to be created with the MetricOrderedProduct metric.
+// The order ID and the position of the item in the order are used as the unique ID of each event.
+func OrderedProducts(o Order) []*NewEvent {
+	events := make([]*NewEvent, 0, len(o.Items))
+	for i, it := range o.Items {
+		properties := map[string]interface{}{
+			"OrderId":     o.OrderID,
+			"ProductID":   it.ProductID,
+			"SKU":         it.SKU,
+			"ProductName": it.ProductName,
+			"Quantity":    it.Quantity,
+			"Categories":  stringsOrEmpty(it.Categories),
+		}
+		setString(properties, "ProductURL", it.ProductURL)
+		setString(properties, "ImageURL", it.ImageURL)
+		setString(properties, "ProductBrand", it.Brand)
+
+		uniqueID := ""
+		if o.OrderID != "" {
+			uniqueID = o.OrderID + "-" + strconv.Itoa(i)
+		}
+		e := newEvent(o.Time, it.rowTotal(), uniqueID, properties)
+		e.ValueCurrency = it.UnitPrice.Currency
+		if e.ValueCurrency == "" {
+			e.ValueCurrency = o.Total.Currency
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// StartedCheckout returns the canonical "Started Checkout" event of the checkout, to be created with
+// the MetricStartedCheckout metric. The checkout ID is used as the unique ID of the event.
+func StartedCheckout(c Checkout) *NewEvent {
+	properties := map[string]interface{}{
+		"Categories": itemCategories(c.Items),
+		"ItemNames":  itemNames(c.Items),
+		"Items":      items(c.Items),
+	}
+	setString(properties, "CheckoutURL", c.CheckoutURL)
+	e := newEvent(c.Time, amount(c.Total, c.Value), c.CheckoutID, properties)
+	e.ValueCurrency = c.Total.Currency
+	return e
+}
+
+// newEvent returns a new event with the given structured properties.
+func newEvent(t time.Time, value float64, uniqueID string, properties map[string]interface{}) *NewEvent {
+	e := &NewEvent{NewAttributes: NewAttributes{
+		Value:                value,
+		UniqueID:             uniqueID,
+		StructuredProperties: properties,
+	}}
+	if !t.IsZero() {
+		e.SetTime(t)
+	}
+	return e
+}
+
+// amount returns the decimal amount of money if it's set, or the given value otherwise.
+func amount(m Money, value float64) float64 {
+	if m.IsZero() {
+		return value
+	}
+	return m.Decimal()
+}
+
+// itemPrice returns the price of a single unit of the item.
+func (it Item) itemPrice() float64 {
+	return amount(it.UnitPrice, it.ItemPrice)
+}
+
+// rowTotal returns the total price of the line item.
+func (it Item) rowTotal() float64 {
+	if it.RowTotal != 0 {
+		return it.RowTotal
+	}
+	if !it.UnitPrice.IsZero() {
+		return it.UnitPrice.Times(it.Quantity).Decimal()
+	}
+	return it.ItemPrice * float64(it.Quantity)
+}
+
+// unitPrices returns the unit prices of the items.
+func unitPrices(its []Item) []Money {
+	prices := make([]Money, 0, len(its))
+	for _, it := range its {
+		prices = append(prices, it.UnitPrice)
+	}
+	return prices
+}
+
+// properties returns the item serialized with the canonical property names.
+func (it Item) properties() map[string]interface{} {
+	m := map[string]interface{}{
+		"ProductID":   it.ProductID,
+		"SKU":         it.SKU,
+		"ProductName": it.ProductName,
+		"Quantity":    it.Quantity,
+		"ItemPrice":   it.itemPrice(),
+		"RowTotal":    it.rowTotal(),
+		"Categories":  stringsOrEmpty(it.Categories),
+	}
+	setString(m, "ProductURL", it.ProductURL)
+	setString(m, "ImageURL", it.ImageURL)
+	setString(m, "Brand", it.Brand)
+	return m
+}
+
+// items returns the items serialized with the canonical property names.
+func items(its []Item) []interface{} {
+	s := make([]interface{}, 0, len(its))
+	for _, it := range its {
+		s = append(s, it.properties())
+	}
+	return s
+}
+
+// itemNames returns the names of the items.
+func itemNames(its []Item) []string {
+	names := make([]string, 0, len(its))
+	for _, it := range its {
+		names = append(names, it.ProductName)
+	}
+	return names
+}
+
+// itemCategories returns the distinct categories of the items.
+func itemCategories(its []Item) []string {
+	var categories []string
+	for _, it := range its {
+		categories = append(categories, it.Categories...)
+	}
+	return distinct(categories)
+}
+
+// itemBrands returns the distinct brands of the items.
+func itemBrands(its []Item) []string {
+	var brands []string
+	for _, it := range its {
+		if it.Brand != "" {
+			brands = append(brands, it.Brand)
+		}
+	}
+	return distinct(brands)
+}
+
+// distinct returns the strings without duplicates, keeping the order of their first occurrence.
+func distinct(ss []string) []string {
+	seen := make(map[string]struct{}, len(ss))
+	unique := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		unique = append(unique, s)
+	}
+	return unique
+}
+
+// stringsOrEmpty returns a copy of the strings, serialized as an empty array rather than null if there are none.
+func stringsOrEmpty(ss []string) []string {
+	return append(make([]string, 0, len(ss)), ss...)
+}
+
+// setString sets the property unless the value is empty.
+func setString(m map[string]interface{}, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
@@ -0,0 +1,79 @@
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+var order = event.Order{
+	OrderID:      "1001",
+	Time:         time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC),
+	Value:        29.98,
+	DiscountCode: "WINTER",
+	Items: []event.Item{
+		{ProductID: "p1", SKU: "sku-1", ProductName: "Mug", Quantity: 2, ItemPrice: 9.99, Categories: []string{"Kitchen"}, Brand: "Acme"},
+		{ProductID: "p2", SKU: "sku-2", ProductName: "Tea", Quantity: 1, ItemPrice: 10, ImageURL: "https://example.com/tea.png", Categories: []string{"Kitchen", "Food"}},
+	},
+}
+
+func TestPlacedOrder(t *testing.T) {
+	e := event.PlacedOrder(order)
+
+	require.Equal(t, "2024-01-30T12:00:00Z", e.Time)
+	require.Equal(t, 29.98, e.Value)
+	require.Equal(t, "1001", e.UniqueID)
+
+	b, err := json.Marshal(e.StructuredProperties)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"OrderId": "1001",
+		"DiscountCode": "WINTER",
+		"Categories": ["Kitchen", "Food"],
+		"ItemNames": ["Mug", "Tea"],
+		"Brands": ["Acme"],
+		"Items": [
+			{"ProductID": "p1", "SKU": "sku-1", "ProductName": "Mug", "Quantity": 2, "ItemPrice": 9.99, "RowTotal": 19.98,
+				"Categories": ["Kitchen"], "Brand": "Acme"},
+			{"ProductID": "p2", "SKU": "sku-2", "ProductName": "Tea", "Quantity": 1, "ItemPrice": 10, "RowTotal": 10,
+				"Categories": ["Kitchen", "Food"], "ImageURL": "https://example.com/tea.png"}
+		]
+	}`, string(b))
+}
+
+func TestOrderedProducts(t *testing.T) {
+	events := event.OrderedProducts(order)
+	require.Len(t, events, 2)
+
+	require.Equal(t, "1001-0", events[0].UniqueID)
+	require.Equal(t, 19.98, events[0].Value)
+	require.Equal(t, "1001-1", events[1].UniqueID)
+
+	b, err := json.Marshal(events[1].StructuredProperties)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"OrderId": "1001", "ProductID": "p2", "SKU": "sku-2", "ProductName": "Tea", "Quantity": 1,
+		"Categories": ["Kitchen", "Food"], "ImageURL": "https://example.com/tea.png"}`, string(b))
+}
+
+func TestStartedCheckout(t *testing.T) {
+	e := event.StartedCheckout(event.Checkout{
+		CheckoutID:  "chk-1",
+		Value:       10,
+		CheckoutURL: "https://example.com/checkout/chk-1",
+		Items:       order.Items[1:],
+	})
+
+	require.Empty(t, e.Time)
+	require.Equal(t, "chk-1", e.UniqueID)
+	require.Equal(t, "https://example.com/checkout/chk-1", e.StructuredProperties["CheckoutURL"])
+	require.Equal(t, []string{"Tea"}, e.StructuredProperties["ItemNames"])
+
+	// the clone doesn't share the items with the original event
+	c := e.Clone()
+	c.StructuredProperties["Items"].([]interface{})[0].(map[string]interface{})["SKU"] = "changed"
+	require.Equal(t, "sku-2", e.StructuredProperties["Items"].([]interface{})[0].(map[string]interface{})["SKU"])
+}
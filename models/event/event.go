@@ -1,5 +1,14 @@
 package event
 
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/monetha/go-klaviyo/models/metric"
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
 // NewEvent represents the data structure for an event that is not yet created.
 type NewEvent struct {
 	NewAttributes `json:"attributes"`
@@ -12,18 +21,62 @@ type ExistingProfile struct {
 
 // ExistingEvent represents the data structure for an existing event.
 type ExistingEvent struct {
-	ID         string `json:"id"`
-	EventType  string `json:"type"`
-	Attributes Attributes
+	ID            string `json:"id"`
+	EventType     string `json:"type"`
+	Attributes    Attributes
+	Relationships Relationships `json:"relationships"`
+	// Profile is the profile of the event, only set when requested with the "profile" include.
+	Profile *profile.ExistingProfile `json:"-"`
+	// Metric is the metric of the event, only set when requested with the "metric" include.
+	Metric *metric.ExistingMetric `json:"-"`
+}
+
+// Relationships holds the resources related to an existing event.
+type Relationships struct {
+	Profile Relationship `json:"profile"`
+	Metric  Relationship `json:"metric"`
+}
+
+// Relationship references a related resource.
+type Relationship struct {
+	Data *ResourceIdentifier `json:"data"`
+}
+
+// ResourceIdentifier identifies a resource by its type and ID.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// ProfileID returns the ID of the profile of the event, or an empty string if it's unknown.
+func (e *ExistingEvent) ProfileID() string {
+	if e.Relationships.Profile.Data == nil {
+		return ""
+	}
+	return e.Relationships.Profile.Data.ID
+}
+
+// MetricID returns the ID of the metric of the event, or an empty string if it's unknown.
+func (e *ExistingEvent) MetricID() string {
+	if e.Relationships.Metric.Data == nil {
+		return ""
+	}
+	return e.Relationships.Metric.Data.ID
 }
 
 // NewAttributes represents the data structure for an attributes of event that is not yet created.
 type NewAttributes struct {
 	Time       string            `json:"time"`
 	Value      float64           `json:"value"`
+	UniqueID   string            `json:"unique_id,omitempty"`
 	Properties map[string]string `json:"properties"`
 	Profile    interface{}       `json:"profile"`
 	Metric     interface{}       `json:"metric"`
+	// ValueCurrency is the ISO 4217 code of the currency of the value, if any.
+	ValueCurrency string `json:"value_currency,omitempty"`
+	// StructuredProperties holds the event properties with non-string values, e.g. the items of an order.
+	// They're sent along with Properties, which take precedence on duplicate keys.
+	StructuredProperties map[string]interface{} `json:"-"`
 }
 
 // Attributes represents the data structure for an existing attributes.
@@ -34,6 +87,18 @@ type Attributes struct {
 	EventProperties map[string]interface{} `json:"event_properties"`
 }
 
+// Time returns the time of the event, parsed from Datetime or, if it's missing or malformed, from Timestamp.
+// It returns the zero time if neither is set.
+func (a Attributes) Time() time.Time {
+	if t, err := time.Parse(time.RFC3339Nano, a.Datetime); err == nil {
+		return t
+	}
+	if a.Timestamp != 0 {
+		return time.Unix(a.Timestamp, 0).UTC()
+	}
+	return time.Time{}
+}
+
 // NewMetric represents the data structure for a metric that is not yet created.
 type NewMetric struct {
 	Attributes MetricAttributes `json:"attributes"`
@@ -43,3 +108,47 @@ type NewMetric struct {
 type MetricAttributes struct {
 	Name string `json:"name"`
 }
+
+// ErrAmbiguousTime indicates that an event time is a local wall-clock time without a UTC offset,
+// and no location to interpret it in was provided.
+var ErrAmbiguousTime = errors.New("klaviyo: event time has no UTC offset")
+
+// wallClockLayouts lists the supported layouts of event times given without a UTC offset.
+var wallClockLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// SetTime sets the time of the event, serialized with an explicit UTC offset.
+func (a *NewAttributes) SetTime(t time.Time) {
+	a.Time = t.Format(time.RFC3339Nano)
+}
+
+// NormalizeTime converts the event time to a time with an explicit UTC offset.
+//
+// Times that already have a UTC offset are returned unchanged. Local wall-clock times
+// (e.g. "2024-01-30T05:10:00") are interpreted in the given location; if the location is nil,
+// ErrAmbiguousTime is returned. An empty time is returned unchanged, so that Klaviyo uses the current time.
+func NormalizeTime(value string, loc *time.Location) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+	if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return value, nil
+	}
+
+	for _, layout := range wallClockLayouts {
+		t, err := time.ParseInLocation(layout, value, time.UTC)
+		if err != nil {
+			continue
+		}
+		if loc == nil {
+			return "", fmt.Errorf("%w: %q", ErrAmbiguousTime, value)
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+		return t.Format(time.RFC3339Nano), nil
+	}
+
+	return "", fmt.Errorf("klaviyo: invalid event time %q", value)
+}
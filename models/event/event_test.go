@@ -0,0 +1,19 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+func TestAttributes_Time(t *testing.T) {
+	want := time.Date(2024, 1, 30, 5, 10, 0, 0, time.UTC)
+
+	require.True(t, want.Equal(event.Attributes{Datetime: "2024-01-30T05:10:00+00:00"}.Time()))
+	require.True(t, want.Equal(event.Attributes{Timestamp: want.Unix()}.Time()))
+	require.True(t, want.Equal(event.Attributes{Datetime: "30/01/2024", Timestamp: want.Unix()}.Time()))
+	require.True(t, event.Attributes{}.Time().IsZero())
+}
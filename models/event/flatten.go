@@ -0,0 +1,78 @@
+package event
+
+import (
+	"time"
+
+	"github.com/monetha/go-klaviyo/internal/flatten"
+)
+
+// FlattenOptions configures how an event is flattened.
+type FlattenOptions struct {
+	// MaxDepth limits how deep nested properties are flattened. Zero means no limit.
+	MaxDepth int
+	// Separator joins the keys of nested values. The default is ".".
+	Separator string
+}
+
+// Flatten returns the event as a flat map of strings, e.g. for CSV or data warehouse exports.
+//
+// The columns are "id", "uuid", "timestamp" (RFC 3339, UTC), "datetime", "metric_id", "profile_id" and the event
+// properties prefixed with "properties", with nested property maps joined with the separator
+// (e.g. "properties.shipping.city"). Unset values are omitted, arrays and properties nested deeper than
+// the maximum depth are serialized as JSON, e.g. the items of an order are a single "properties.Items" column.
+func Flatten(e *ExistingEvent, opts FlattenOptions) map[string]string {
+	if e == nil {
+		return nil
+	}
+
+	m := make(map[string]string)
+	for k, v := range columns(e) {
+		switch v := v.(type) {
+		case string:
+			m[k] = v
+		case time.Time:
+			m[k] = v.Format(time.RFC3339)
+		}
+	}
+	flatten.String(m, "properties", e.Attributes.EventProperties, separator(opts), 0, opts.MaxDepth)
+	return m
+}
+
+// ToMap returns the event as a flat map with the same columns as Flatten, but keeping the types of the values,
+// e.g. for loaders of typed warehouse tables: the timestamp is a time.Time and property values are stored as decoded.
+// Properties nested deeper than the maximum depth are stored as maps.
+func ToMap(e *ExistingEvent, opts FlattenOptions) map[string]interface{} {
+	if e == nil {
+		return nil
+	}
+
+	m := columns(e)
+	flatten.Value(m, "properties", e.Attributes.EventProperties, separator(opts), 0, opts.MaxDepth)
+	return m
+}
+
+// columns returns the set top-level columns of the event.
+func columns(e *ExistingEvent) map[string]interface{} {
+	m := map[string]interface{}{"id": e.ID}
+	setString := func(key, value string) {
+		if value != "" {
+			m[key] = value
+		}
+	}
+
+	setString("uuid", e.Attributes.UUID)
+	if e.Attributes.Timestamp != 0 {
+		m["timestamp"] = time.Unix(e.Attributes.Timestamp, 0).UTC()
+	}
+	setString("datetime", e.Attributes.Datetime)
+	setString("metric_id", e.MetricID())
+	setString("profile_id", e.ProfileID())
+	return m
+}
+
+func separator(opts FlattenOptions) string {
+	if opts.Separator == "" {
+		return "."
+	}
+	return opts.Separator
+}
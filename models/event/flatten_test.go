@@ -0,0 +1,72 @@
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+const existingEvent = `{
+	"type": "event",
+	"id": "4vRpBT2BYkR",
+	"attributes": {
+		"timestamp": 1706616000,
+		"datetime": "2024-01-30T12:00:00+00:00",
+		"uuid": "a6c5b1e0-bf6b-11ee-8001-43a3b1c4a0d9",
+		"event_properties": {
+			"value": 29.98,
+			"gift": false,
+			"order": {"id": "1001", "shipping": {"method": "express"}},
+			"items": ["sku-1", "sku-2"]
+		}
+	},
+	"relationships": {
+		"profile": {"data": {"type": "profile", "id": "01H8HKMDG8F4MN7PSRZ4YQYNVQ"}},
+		"metric": {"data": {"type": "metric", "id": "Y6nRLr"}}
+	}
+}`
+
+func TestFlatten(t *testing.T) {
+	var e event.ExistingEvent
+	require.NoError(t, json.Unmarshal([]byte(existingEvent), &e))
+
+	t.Run("flatten without depth limit", func(t *testing.T) {
+		require.Equal(t, map[string]string{
+			"id":                               "4vRpBT2BYkR",
+			"uuid":                             "a6c5b1e0-bf6b-11ee-8001-43a3b1c4a0d9",
+			"timestamp":                        "2024-01-30T12:00:00Z",
+			"datetime":                         "2024-01-30T12:00:00+00:00",
+			"metric_id":                        "Y6nRLr",
+			"profile_id":                       "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			"properties.value":                 "29.98",
+			"properties.gift":                  "false",
+			"properties.order.id":              "1001",
+			"properties.order.shipping.method": "express",
+			"properties.items":                 `["sku-1","sku-2"]`,
+		}, event.Flatten(&e, event.FlattenOptions{}))
+	})
+
+	t.Run("flatten with depth limit and separator", func(t *testing.T) {
+		m := event.Flatten(&e, event.FlattenOptions{MaxDepth: 2, Separator: "__"})
+		require.Equal(t, `{"method":"express"}`, m["properties__order__shipping"])
+		require.Equal(t, "1001", m["properties__order__id"])
+	})
+
+	t.Run("to map keeps the value types", func(t *testing.T) {
+		m := event.ToMap(&e, event.FlattenOptions{MaxDepth: 2})
+		require.Equal(t, time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC), m["timestamp"])
+		require.Equal(t, 29.98, m["properties.value"])
+		require.Equal(t, false, m["properties.gift"])
+		require.Equal(t, []interface{}{"sku-1", "sku-2"}, m["properties.items"])
+		require.Equal(t, map[string]interface{}{"method": "express"}, m["properties.order.shipping"])
+	})
+
+	t.Run("nil event", func(t *testing.T) {
+		require.Nil(t, event.Flatten(nil, event.FlattenOptions{}))
+		require.Nil(t, event.ToMap(nil, event.FlattenOptions{}))
+	})
+}
@@ -0,0 +1,93 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidMoney indicates that an amount of money has an invalid currency code or a negative amount.
+var ErrInvalidMoney = errors.New("klaviyo: invalid amount of money")
+
+// ErrCurrencyMismatch indicates that the amounts of an order or a checkout are in different currencies.
+var ErrCurrencyMismatch = errors.New("klaviyo: amounts are in different currencies")
+
+// Money is an amount of money in the minor units of an ISO 4217 currency, e.g. 1999 USD is $19.99.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// currencyExponents lists the ISO 4217 currencies that don't have two decimal places.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// IsZero reports whether the amount of money is unset.
+func (m Money) IsZero() bool {
+	return m == Money{}
+}
+
+// Decimal returns the amount in the major units of the currency, e.g. 19.99 for 1999 USD.
+func (m Money) Decimal() float64 {
+	return float64(m.Amount) / math.Pow10(currencyExponent(m.Currency))
+}
+
+// Times returns the amount of money multiplied by n, e.g. the row total of n units.
+func (m Money) Times(n int) Money {
+	return Money{Amount: m.Amount * int64(n), Currency: m.Currency}
+}
+
+// Validate returns an error if the currency isn't a three-letter ISO 4217 code or the amount is negative.
+func (m Money) Validate() error {
+	if !isCurrencyCode(m.Currency) {
+		return fmt.Errorf("%w: currency %q", ErrInvalidMoney, m.Currency)
+	}
+	if m.Amount < 0 {
+		return fmt.Errorf("%w: negative amount %d %s", ErrInvalidMoney, m.Amount, m.Currency)
+	}
+	return nil
+}
+
+// currencyExponent returns the number of decimal places of the currency.
+func currencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// isCurrencyCode reports whether the string looks like an ISO 4217 currency code.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMoney validates the amounts that are set and checks they're all in the same currency.
+func validateMoney(amounts ...Money) error {
+	currency := ""
+	for _, m := range amounts {
+		if m.IsZero() {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			return err
+		}
+		if currency == "" {
+			currency = m.Currency
+		} else if m.Currency != currency {
+			return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, currency, m.Currency)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package event_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+func TestMoney_Decimal(t *testing.T) {
+	require.Equal(t, 19.99, event.Money{Amount: 1999, Currency: "USD"}.Decimal())
+	require.Equal(t, 1999.0, event.Money{Amount: 1999, Currency: "JPY"}.Decimal())
+	require.Equal(t, 1.999, event.Money{Amount: 1999, Currency: "KWD"}.Decimal())
+	require.Equal(t, 59.97, event.Money{Amount: 1999, Currency: "EUR"}.Times(3).Decimal())
+}
+
+func TestOrder_Validate(t *testing.T) {
+	o := event.Order{
+		OrderID:  "1002",
+		Total:    event.Money{Amount: 2498, Currency: "EUR"},
+		Discount: event.Money{Amount: 500, Currency: "EUR"},
+		Items: []event.Item{
+			{ProductID: "p1", ProductName: "Mug", Quantity: 2, UnitPrice: event.Money{Amount: 999, Currency: "EUR"}},
+			{ProductID: "p2", ProductName: "Tea", Quantity: 1, UnitPrice: event.Money{Amount: 1000, Currency: "EUR"}},
+		},
+	}
+	require.NoError(t, o.Validate())
+
+	e := event.PlacedOrder(o)
+	require.Equal(t, 24.98, e.Value)
+	require.Equal(t, "EUR", e.ValueCurrency)
+	require.Equal(t, 5.0, e.StructuredProperties["DiscountValue"])
+	item := e.StructuredProperties["Items"].([]interface{})[0].(map[string]interface{})
+	require.Equal(t, 9.99, item["ItemPrice"])
+	require.Equal(t, 19.98, item["RowTotal"])
+
+	products := event.OrderedProducts(o)
+	require.Equal(t, 19.98, products[0].Value)
+	require.Equal(t, "EUR", products[0].ValueCurrency)
+
+	mismatch := o
+	mismatch.Items = []event.Item{{UnitPrice: event.Money{Amount: 999, Currency: "USD"}}}
+	require.ErrorIs(t, mismatch.Validate(), event.ErrCurrencyMismatch)
+
+	invalid := o
+	invalid.Total = event.Money{Amount: 2498, Currency: "eur"}
+	require.ErrorIs(t, invalid.Validate(), event.ErrInvalidMoney)
+
+	negative := event.Checkout{Total: event.Money{Amount: -1, Currency: "EUR"}}
+	require.ErrorIs(t, negative.Validate(), event.ErrInvalidMoney)
+}
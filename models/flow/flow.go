@@ -0,0 +1,75 @@
+package flow
+
+import (
+	"time"
+)
+
+// ActionTypeWebhook is the action type of flow actions that send a webhook request.
+const ActionTypeWebhook = "WEBHOOK"
+
+// ExistingFlow represents the data structure for a flow that is already created.
+type ExistingFlow struct {
+	ID         string     `json:"id"`
+	Attributes Attributes `json:"attributes"`
+}
+
+// Attributes contains attributes of a flow.
+type Attributes struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Archived    bool       `json:"archived"`
+	TriggerType string     `json:"trigger_type"`
+	Created     *time.Time `json:"created"`
+	Updated     *time.Time `json:"updated"`
+}
+
+// ExistingAction represents the data structure for an action of a flow.
+type ExistingAction struct {
+	ID         string           `json:"id"`
+	Attributes ActionAttributes `json:"attributes"`
+}
+
+// ActionAttributes contains attributes of a flow action, including its configuration details.
+type ActionAttributes struct {
+	ActionType      string                 `json:"action_type"`
+	Status          string                 `json:"status"`
+	Created         *time.Time             `json:"created"`
+	Updated         *time.Time             `json:"updated"`
+	Settings        map[string]interface{} `json:"settings"`
+	TrackingOptions map[string]interface{} `json:"tracking_options"`
+	SendOptions     map[string]interface{} `json:"send_options"`
+	RenderOptions   map[string]interface{} `json:"render_options"`
+}
+
+// IsWebhook reports whether the action sends a webhook request.
+func (a *ExistingAction) IsWebhook() bool {
+	return a != nil && a.Attributes.ActionType == ActionTypeWebhook
+}
+
+// WebhookURL returns the URL the webhook action posts data to, or an empty string
+// if the action is not a webhook action or the URL is not present in its settings.
+func (a *ExistingAction) WebhookURL() string {
+	if !a.IsWebhook() {
+		return ""
+	}
+	return settingString(a.Attributes.Settings, "url")
+}
+
+// WebhookTemplate returns the body template of the webhook action, or an empty string
+// if the action is not a webhook action or the template is not present in its settings.
+func (a *ExistingAction) WebhookTemplate() string {
+	if !a.IsWebhook() {
+		return ""
+	}
+	if body := settingString(a.Attributes.Settings, "body"); body != "" {
+		return body
+	}
+	return settingString(a.Attributes.Settings, "template")
+}
+
+func settingString(settings map[string]interface{}, key string) string {
+	if s, ok := settings[key].(string); ok {
+		return s
+	}
+	return ""
+}
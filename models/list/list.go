@@ -0,0 +1,18 @@
+package list
+
+import (
+	"time"
+)
+
+// ExistingList represents the data structure for a list that is already created.
+type ExistingList struct {
+	ID         string     `json:"id"`
+	Attributes Attributes `json:"attributes"`
+}
+
+// Attributes contains attributes of a list.
+type Attributes struct {
+	Name    string     `json:"name"`
+	Created *time.Time `json:"created"`
+	Updated *time.Time `json:"updated"`
+}
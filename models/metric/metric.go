@@ -0,0 +1,26 @@
+package metric
+
+import (
+	"time"
+)
+
+// ExistingMetric represents the data structure for a metric that is already created.
+type ExistingMetric struct {
+	ID         string     `json:"id"`
+	Attributes Attributes `json:"attributes"`
+}
+
+// Attributes contains attributes of a metric.
+type Attributes struct {
+	Name        string       `json:"name"`
+	Created     *time.Time   `json:"created"`
+	Updated     *time.Time   `json:"updated"`
+	Integration *Integration `json:"integration"`
+}
+
+// Integration describes the integration a metric originates from.
+type Integration struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
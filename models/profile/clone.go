@@ -0,0 +1,129 @@
+package profile
+
+import (
+	"github.com/monetha/go-klaviyo/internal/deepcopy"
+)
+
+// Clone returns a deep copy of the profile, so that the copy can be modified without affecting the original,
+// e.g. when a template profile is used by several goroutines.
+func (p *NewProfile) Clone() *NewProfile {
+	if p == nil {
+		return nil
+	}
+	return &NewProfile{Attributes: p.Attributes.clone()}
+}
+
+// Clone returns a deep copy of the profile, so that the copy can be modified without affecting the original.
+func (p *ExistingProfile) Clone() *ExistingProfile {
+	if p == nil {
+		return nil
+	}
+	return &ExistingProfile{
+		Id: p.Id,
+		Attributes: ExistingAttributes{
+			NewAttributes:       p.Attributes.NewAttributes.clone(),
+			Created:             p.Attributes.Created,
+			Updated:             p.Attributes.Updated,
+			LastEventDate:       deepcopy.Ptr(p.Attributes.LastEventDate),
+			Subscriptions:       p.Attributes.Subscriptions.clone(),
+			PredictiveAnalytics: p.Attributes.PredictiveAnalytics.clone(),
+		},
+	}
+}
+
+func (a NewAttributes) clone() NewAttributes {
+	a.PhoneNumber = deepcopy.Ptr(a.PhoneNumber)
+	a.ExternalId = deepcopy.Ptr(a.ExternalId)
+	a.AnonymousId = deepcopy.Ptr(a.AnonymousId)
+	a.FirstName = deepcopy.Ptr(a.FirstName)
+	a.LastName = deepcopy.Ptr(a.LastName)
+	a.Organization = deepcopy.Ptr(a.Organization)
+	a.Title = deepcopy.Ptr(a.Title)
+	a.Image = deepcopy.Ptr(a.Image)
+	a.Location = a.Location.clone()
+	a.Properties = deepcopy.Map(a.Properties)
+	return a
+}
+
+func (l Location) clone() Location {
+	l.Address1 = deepcopy.Ptr(l.Address1)
+	l.Address2 = deepcopy.Ptr(l.Address2)
+	l.City = deepcopy.Ptr(l.City)
+	l.Country = deepcopy.Ptr(l.Country)
+	l.Latitude = deepcopy.Ptr(l.Latitude)
+	l.Longitude = deepcopy.Ptr(l.Longitude)
+	l.Region = deepcopy.Ptr(l.Region)
+	l.Zip = deepcopy.Ptr(l.Zip)
+	l.Timezone = deepcopy.Ptr(l.Timezone)
+	return l
+}
+
+func (s *Subscriptions) clone() *Subscriptions {
+	if s == nil {
+		return nil
+	}
+	c := &Subscriptions{}
+	if s.Email != nil {
+		c.Email = &EmailSubscriptions{Marketing: s.Email.Marketing.clone()}
+	}
+	if s.SMS != nil {
+		c.SMS = &SMSSubscriptions{Marketing: s.SMS.Marketing.clone()}
+	}
+	return c
+}
+
+func (m *Marketing) clone() *Marketing {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.Timestamp = deepcopy.Ptr(m.Timestamp)
+	c.Method = deepcopy.Ptr(m.Method)
+	c.MethodDetail = deepcopy.Ptr(m.MethodDetail)
+	return &c
+}
+
+func (m *EmailMarketing) clone() *EmailMarketing {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.Marketing = *m.Marketing.clone()
+	c.CanReceiveEmailMarketing = deepcopy.Ptr(m.CanReceiveEmailMarketing)
+	c.CustomMethodDetail = deepcopy.Ptr(m.CustomMethodDetail)
+	c.DoubleOptin = deepcopy.Ptr(m.DoubleOptin)
+	if m.Suppressions != nil {
+		c.Suppressions = make([]*Suppression, len(m.Suppressions))
+		for i, s := range m.Suppressions {
+			if s != nil {
+				c.Suppressions[i] = &Suppression{Reason: s.Reason, Timestamp: deepcopy.Ptr(s.Timestamp)}
+			}
+		}
+	}
+	if m.ListSuppressions != nil {
+		c.ListSuppressions = make([]*ListSuppression, len(m.ListSuppressions))
+		for i, s := range m.ListSuppressions {
+			if s != nil {
+				c.ListSuppressions[i] = &ListSuppression{ListID: s.ListID, Reason: s.Reason, Timestamp: deepcopy.Ptr(s.Timestamp)}
+			}
+		}
+	}
+	return &c
+}
+
+func (a *PredictiveAnalytics) clone() *PredictiveAnalytics {
+	if a == nil {
+		return nil
+	}
+	return &PredictiveAnalytics{
+		HistoricCLV:              deepcopy.Ptr(a.HistoricCLV),
+		PredictedCLV:             deepcopy.Ptr(a.PredictedCLV),
+		TotalCLV:                 deepcopy.Ptr(a.TotalCLV),
+		HistoricNumberOfOrders:   deepcopy.Ptr(a.HistoricNumberOfOrders),
+		PredictedNumberOfOrders:  deepcopy.Ptr(a.PredictedNumberOfOrders),
+		AverageDaysBetweenOrders: deepcopy.Ptr(a.AverageDaysBetweenOrders),
+		AverageOrderValue:        deepcopy.Ptr(a.AverageOrderValue),
+		ChurnProbability:         deepcopy.Ptr(a.ChurnProbability),
+		ExpectedDateOfNextOrder:  deepcopy.Ptr(a.ExpectedDateOfNextOrder),
+	}
+}
@@ -0,0 +1,56 @@
+package profile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+func TestExistingProfile_Clone(t *testing.T) {
+	city := "New York"
+	method := "FORM"
+	ts := time.Date(2024, 1, 30, 5, 10, 0, 0, time.UTC)
+	p := &profile.ExistingProfile{
+		Id: "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+		Attributes: profile.ExistingAttributes{
+			NewAttributes: profile.NewAttributes{
+				Email:    "sarah.mason@klaviyo-demo.com",
+				Location: profile.Location{City: &city},
+				Properties: map[string]interface{}{
+					"tags":    []interface{}{"vip"},
+					"loyalty": map[string]interface{}{"tier": "gold"},
+				},
+			},
+			LastEventDate: &ts,
+			Subscriptions: &profile.Subscriptions{
+				Email: &profile.EmailSubscriptions{Marketing: &profile.EmailMarketing{
+					Marketing:    profile.Marketing{Consent: "SUBSCRIBED", Method: &method},
+					Suppressions: []*profile.Suppression{{Reason: profile.SuppressionReasonHardBounce, Timestamp: &ts}},
+				}},
+			},
+		},
+	}
+
+	c := p.Clone()
+	require.Equal(t, p, c)
+
+	*c.Attributes.Location.City = "Boston"
+	c.Attributes.Properties["tags"].([]interface{})[0] = "regular"
+	c.Attributes.Properties["loyalty"].(map[string]interface{})["tier"] = "silver"
+	*c.Attributes.LastEventDate = ts.Add(time.Hour)
+	*c.Attributes.Subscriptions.Email.Marketing.Method = "API"
+	c.Attributes.Subscriptions.Email.Marketing.Suppressions[0].Reason = profile.SuppressionReasonUnsubscribe
+
+	require.Equal(t, "New York", city)
+	require.Equal(t, []interface{}{"vip"}, p.Attributes.Properties["tags"])
+	require.Equal(t, map[string]interface{}{"tier": "gold"}, p.Attributes.Properties["loyalty"])
+	require.Equal(t, time.Date(2024, 1, 30, 5, 10, 0, 0, time.UTC), ts)
+	require.Equal(t, "FORM", method)
+	require.Equal(t, profile.SuppressionReasonHardBounce, p.Attributes.Subscriptions.Email.Marketing.Suppressions[0].Reason)
+
+	require.Nil(t, (*profile.ExistingProfile)(nil).Clone())
+	require.Nil(t, (*profile.NewProfile)(nil).Clone())
+}
@@ -0,0 +1,108 @@
+package profile
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// floatTolerance is the relative tolerance used to compare numeric values.
+const floatTolerance = 1e-9
+
+// Equal reports whether the profiles have the same ID and attributes. See DiffFields for how values are compared.
+func Equal(a, b *ExistingProfile) bool {
+	return len(DiffFields(a, b)) == 0
+}
+
+// DiffFields returns the sorted keys of the fields that differ between the profiles, keyed as by Flatten,
+// e.g. "first_name", "location.city" or "properties.address.city".
+//
+// Values are compared property-aware: numbers are equal within a relative tolerance regardless of how they
+// were decoded (e.g. 1 and 1.0000000000001), and times are equal if they denote the same instant regardless
+// of the time zone they are formatted in. Unset and missing fields are equal. A nil profile differs from
+// any non-nil profile in all of its fields.
+func DiffFields(a, b *ExistingProfile) []string {
+	fa := Flatten(a, FlattenOptions{})
+	fb := Flatten(b, FlattenOptions{})
+
+	var diff []string
+	for k, va := range fa {
+		if vb, ok := fb[k]; !ok || !equalValues(va, vb) {
+			diff = append(diff, k)
+		}
+	}
+	for k := range fb {
+		if _, ok := fa[k]; !ok {
+			diff = append(diff, k)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// equalValues compares the flattened values, treating numbers and times semantically.
+func equalValues(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	// integers, e.g. IDs or phone numbers, are compared exactly
+	if ia, err := strconv.ParseInt(a, 10, 64); err == nil {
+		if ib, err := strconv.ParseInt(b, 10, 64); err == nil {
+			return ia == ib
+		}
+	}
+
+	if fa, err := strconv.ParseFloat(a, 64); err == nil {
+		if fb, err := strconv.ParseFloat(b, 64); err == nil {
+			return math.Abs(fa-fb) <= floatTolerance*math.Max(1, math.Max(math.Abs(fa), math.Abs(fb)))
+		}
+		return false
+	}
+
+	if ta, err := time.Parse(time.RFC3339Nano, a); err == nil {
+		if tb, err := time.Parse(time.RFC3339Nano, b); err == nil {
+			return ta.Equal(tb)
+		}
+	}
+
+	return false
+}
+
+// Conflict is a field set to different values in two profiles.
+type Conflict struct {
+	// Field is the key of the field as by Flatten, e.g. "first_name" or "properties.tier".
+	Field string
+	A     string
+	B     string
+}
+
+// metadataFields are the fields managed by Klaviyo, which are not reported as conflicts.
+var metadataFields = map[string]struct{}{
+	"id":              {},
+	"created":         {},
+	"updated":         {},
+	"last_event_date": {},
+}
+
+// Conflicts returns the fields set in both profiles to different values, sorted by the field, e.g. to review
+// a merge of duplicate profiles. Fields set in only one of the profiles and the fields managed by Klaviyo
+// (ID and timestamps) are not conflicts. Values are compared as by DiffFields.
+func Conflicts(a, b *ExistingProfile) []Conflict {
+	fa := Flatten(a, FlattenOptions{})
+	fb := Flatten(b, FlattenOptions{})
+
+	var conflicts []Conflict
+	for _, k := range DiffFields(a, b) {
+		if _, ok := metadataFields[k]; ok {
+			continue
+		}
+		va, okA := fa[k]
+		vb, okB := fb[k]
+		if okA && okB {
+			conflicts = append(conflicts, Conflict{Field: k, A: va, B: vb})
+		}
+	}
+	return conflicts
+}
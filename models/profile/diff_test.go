@@ -0,0 +1,94 @@
+package profile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+func TestDiffFields(t *testing.T) {
+	city := "New York"
+	created := time.Date(2024, 1, 30, 5, 10, 0, 0, time.UTC)
+	a := &profile.ExistingProfile{
+		Id: "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+		Attributes: profile.ExistingAttributes{
+			NewAttributes: profile.NewAttributes{
+				Email:    "sarah.mason@klaviyo-demo.com",
+				Location: profile.Location{City: &city},
+				Properties: map[string]interface{}{
+					"points":      1500.0,
+					"ratio":       0.1 + 0.2,
+					"signed_up":   "2024-01-30T05:10:00Z",
+					"preferences": map[string]interface{}{"channel": "email"},
+				},
+			},
+			Created: created,
+		},
+	}
+
+	t.Run("equal profiles", func(t *testing.T) {
+		b := a.Clone()
+		b.Attributes.Created = created.In(time.FixedZone("CET", 3600))
+		b.Attributes.Properties["points"] = 1500
+		b.Attributes.Properties["ratio"] = 0.3
+		b.Attributes.Properties["signed_up"] = "2024-01-30T06:10:00+01:00"
+
+		require.Empty(t, profile.DiffFields(a, b))
+		require.True(t, profile.Equal(a, b))
+	})
+
+	t.Run("different profiles", func(t *testing.T) {
+		b := a.Clone()
+		otherCity := "Boston"
+		b.Attributes.Location.City = &otherCity
+		b.Attributes.Properties["points"] = 1501.0
+		b.Attributes.Properties["preferences"] = map[string]interface{}{"channel": "sms"}
+		delete(b.Attributes.Properties, "signed_up")
+		b.Attributes.Properties["tier"] = "gold"
+
+		require.Equal(t, []string{
+			"location.city",
+			"properties.points",
+			"properties.preferences.channel",
+			"properties.signed_up",
+			"properties.tier",
+		}, profile.DiffFields(a, b))
+		require.False(t, profile.Equal(a, b))
+	})
+}
+
+func TestConflicts(t *testing.T) {
+	first, other := "Sarah", "Sara"
+	phone := "+15005550006"
+	a := &profile.ExistingProfile{
+		Id: "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+		Attributes: profile.ExistingAttributes{
+			NewAttributes: profile.NewAttributes{
+				Email:      "sarah.mason@klaviyo-demo.com",
+				FirstName:  &first,
+				Properties: map[string]interface{}{"tier": "gold", "points": 10.0},
+			},
+			Created: time.Date(2024, 1, 30, 5, 10, 0, 0, time.UTC),
+		},
+	}
+	b := &profile.ExistingProfile{
+		Id: "01HN6AFEHGF6F77WJRKT1C9JHG",
+		Attributes: profile.ExistingAttributes{
+			NewAttributes: profile.NewAttributes{
+				Email:       "sarah.mason@klaviyo-demo.com",
+				FirstName:   &other,
+				PhoneNumber: &phone,
+				Properties:  map[string]interface{}{"tier": "silver", "points": 10},
+			},
+			Created: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	require.Equal(t, []profile.Conflict{
+		{Field: "first_name", A: "Sarah", B: "Sara"},
+		{Field: "properties.tier", A: "gold", B: "silver"},
+	}, profile.Conflicts(a, b))
+}
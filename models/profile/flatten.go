@@ -0,0 +1,86 @@
+package profile
+
+import (
+	"time"
+
+	"github.com/monetha/go-klaviyo/internal/flatten"
+)
+
+// FlattenOptions configures how a profile is flattened.
+type FlattenOptions struct {
+	// MaxDepth limits how deep nested properties are flattened. Values nested deeper are serialized as JSON.
+	// Zero means no limit.
+	MaxDepth int
+	// Separator joins the keys of nested values. The default is ".".
+	Separator string
+}
+
+// Flatten returns the profile as a flat map, e.g. for CSV or data warehouse exports.
+//
+// Attributes are keyed by their JSON names, location fields are prefixed with "location", custom properties
+// are prefixed with "properties" and nested property maps are joined with the separator (e.g. "properties.address.city").
+// Unset attributes are omitted, arrays are serialized as JSON and times are formatted as RFC 3339.
+func Flatten(p *ExistingProfile, opts FlattenOptions) map[string]string {
+	if p == nil {
+		return nil
+	}
+
+	sep := opts.Separator
+	if sep == "" {
+		sep = "."
+	}
+
+	attr := p.Attributes
+	m := map[string]string{"id": p.Id}
+
+	setString := func(key, value string) {
+		if value != "" {
+			m[key] = value
+		}
+	}
+	setStringPtr := func(key string, value *string) {
+		if value != nil {
+			m[key] = *value
+		}
+	}
+	setFloatPtr := func(key string, value *float64) {
+		if value != nil {
+			m[key] = flatten.Float(*value)
+		}
+	}
+	setTime := func(key string, value time.Time) {
+		if !value.IsZero() {
+			m[key] = value.Format(time.RFC3339)
+		}
+	}
+
+	setString("email", attr.Email)
+	setStringPtr("phone_number", attr.PhoneNumber)
+	setStringPtr("external_id", attr.ExternalId)
+	setStringPtr("anonymous_id", attr.AnonymousId)
+	setStringPtr("first_name", attr.FirstName)
+	setStringPtr("last_name", attr.LastName)
+	setStringPtr("organization", attr.Organization)
+	setStringPtr("title", attr.Title)
+	setStringPtr("image", attr.Image)
+	setTime("created", attr.Created)
+	setTime("updated", attr.Updated)
+	if attr.LastEventDate != nil {
+		setTime("last_event_date", *attr.LastEventDate)
+	}
+
+	loc := attr.Location
+	setStringPtr("location"+sep+"address1", loc.Address1)
+	setStringPtr("location"+sep+"address2", loc.Address2)
+	setStringPtr("location"+sep+"city", loc.City)
+	setStringPtr("location"+sep+"country", loc.Country)
+	setFloatPtr("location"+sep+"latitude", loc.Latitude)
+	setFloatPtr("location"+sep+"longitude", loc.Longitude)
+	setStringPtr("location"+sep+"region", loc.Region)
+	setStringPtr("location"+sep+"zip", loc.Zip)
+	setStringPtr("location"+sep+"timezone", loc.Timezone)
+
+	flatten.String(m, "properties", attr.Properties, sep, 0, opts.MaxDepth)
+
+	return m
+}
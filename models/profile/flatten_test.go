@@ -0,0 +1,58 @@
+package profile_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+func TestFlatten(t *testing.T) {
+	city := "New York"
+	lat := 40.7128
+	p := &profile.ExistingProfile{
+		Id: "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+		Attributes: profile.ExistingAttributes{
+			NewAttributes: profile.NewAttributes{
+				Email: "sarah.mason@klaviyo-demo.com",
+				Location: profile.Location{
+					City:     &city,
+					Latitude: &lat,
+				},
+				Properties: map[string]interface{}{
+					"order_number": float64(1234567),
+					"vip":          true,
+					"address": map[string]interface{}{
+						"street": "89 E 42nd St",
+						"geo": map[string]interface{}{
+							"lat": 40.75,
+						},
+					},
+					"tags": []interface{}{"a", "b"},
+				},
+			},
+		},
+	}
+
+	t.Run("flatten without depth limit", func(t *testing.T) {
+		require.Equal(t, map[string]string{
+			"id":                         "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			"email":                      "sarah.mason@klaviyo-demo.com",
+			"location.city":              "New York",
+			"location.latitude":          "40.7128",
+			"properties.order_number":    "1234567",
+			"properties.vip":             "true",
+			"properties.address.street":  "89 E 42nd St",
+			"properties.address.geo.lat": "40.75",
+			"properties.tags":            `["a","b"]`,
+		}, profile.Flatten(p, profile.FlattenOptions{}))
+	})
+
+	t.Run("flatten with depth limit", func(t *testing.T) {
+		m := profile.Flatten(p, profile.FlattenOptions{MaxDepth: 2})
+
+		require.Equal(t, "89 E 42nd St", m["properties.address.street"])
+		require.Equal(t, `{"lat":40.75}`, m["properties.address.geo"])
+	})
+}
@@ -0,0 +1,40 @@
+package profile
+
+import "github.com/monetha/go-klaviyo/models/profile/updater"
+
+// Identifier is an attribute identifying a profile, e.g. its email address. It's an updater setting the attribute,
+// so it can be passed along the other updaters of CreateOrUpdateProfile, which requires at least one identifier.
+type Identifier struct {
+	name  string
+	value string
+}
+
+// IdentifyByEmail identifies the profile by its email address.
+func IdentifyByEmail(email string) Identifier {
+	return Identifier{name: "email", value: email}
+}
+
+// IdentifyByPhoneNumber identifies the profile by its phone number in E.164 format, e.g. "+15005550006".
+func IdentifyByPhoneNumber(phoneNumber string) Identifier {
+	return Identifier{name: "phone_number", value: phoneNumber}
+}
+
+// IdentifyByExternalID identifies the profile by its ID in an external system.
+func IdentifyByExternalID(externalID string) Identifier {
+	return Identifier{name: "external_id", value: externalID}
+}
+
+// Name returns the name of the identifying attribute, e.g. "email".
+func (i Identifier) Name() string {
+	return i.name
+}
+
+// Value returns the value of the identifying attribute.
+func (i Identifier) Value() string {
+	return i.value
+}
+
+// Apply sets the identifying attribute of the profile.
+func (i Identifier) Apply(profile *updater.ProfileData) {
+	profile.Attributes[i.name] = i.value
+}
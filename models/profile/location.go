@@ -0,0 +1,67 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalJSON decodes the location. Klaviyo sometimes returns latitude and longitude as decimal strings,
+// so the coordinates are accepted both as numbers and as strings.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	type location Location
+	aux := struct {
+		*location
+		Latitude  coordinate `json:"latitude"`
+		Longitude coordinate `json:"longitude"`
+	}{
+		location: (*location)(l),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	l.Latitude = aux.Latitude.value
+	l.Longitude = aux.Longitude.value
+	return nil
+}
+
+// coordinate is a float value that can be decoded from a JSON number or a decimal string.
+type coordinate struct {
+	value *float64
+}
+
+// UnmarshalJSON decodes the coordinate from a JSON number, a decimal string or null.
+func (c *coordinate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		c.value = nil
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			c.value = nil
+			return nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		c.value = &f
+		return nil
+	}
+
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	c.value = &f
+	return nil
+}
@@ -0,0 +1,141 @@
+package location
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Format is the form to which country and region values are normalized.
+type Format int
+
+const (
+	// FormatCode normalizes values to ISO 3166 codes, e.g. "US" and "NY".
+	FormatCode Format = iota + 1
+	// FormatName normalizes values to English names, e.g. "United States" and "New York".
+	FormatName
+)
+
+// ErrUnknownCountry indicates that a country value can't be mapped to an ISO 3166-1 country.
+type ErrUnknownCountry struct {
+	Value string
+}
+
+// Error returns a string representation of the ErrUnknownCountry error.
+// It conforms to the error interface.
+func (e *ErrUnknownCountry) Error() string {
+	return fmt.Sprintf("klaviyo: unknown country %q", e.Value)
+}
+
+// ErrUnknownRegion indicates that a region value can't be mapped to an ISO 3166-2 subdivision of the country.
+type ErrUnknownRegion struct {
+	Country string
+	Value   string
+}
+
+// Error returns a string representation of the ErrUnknownRegion error.
+// It conforms to the error interface.
+func (e *ErrUnknownRegion) Error() string {
+	return fmt.Sprintf("klaviyo: unknown region %q of country %s", e.Value, e.Country)
+}
+
+type country struct {
+	Code    string
+	Name    string
+	Aliases []string
+}
+
+type region struct {
+	Code string
+	Name string
+}
+
+// extraCountryAliases lists commonly used country names that are not part of ISO 3166.
+var extraCountryAliases = map[string]string{
+	"UK":            "GB",
+	"Great Britain": "GB",
+	"Russia":        "RU",
+}
+
+var (
+	lookupOnce      sync.Once
+	countriesByKey  map[string]*country
+	regionsByKey    map[string]map[string]*region
+	countriesByCode map[string]*country
+)
+
+func buildLookups() {
+	countriesByKey = map[string]*country{}
+	countriesByCode = map[string]*country{}
+	for i := range countries {
+		c := &countries[i]
+		countriesByCode[c.Code] = c
+		countriesByKey[key(c.Code)] = c
+		countriesByKey[key(c.Name)] = c
+		for _, a := range c.Aliases {
+			countriesByKey[key(a)] = c
+		}
+	}
+	for alias, code := range extraCountryAliases {
+		countriesByKey[key(alias)] = countriesByCode[code]
+	}
+
+	regionsByKey = map[string]map[string]*region{}
+	for code, rs := range regions {
+		byKey := map[string]*region{}
+		for i := range rs {
+			r := &rs[i]
+			byKey[key(r.Code)] = r
+			byKey[key(r.Name)] = r
+		}
+		regionsByKey[code] = byKey
+	}
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func lookupCountry(value string) (*country, bool) {
+	lookupOnce.Do(buildLookups)
+	c, ok := countriesByKey[key(value)]
+	return c, ok
+}
+
+// NormalizeCountry maps the country value (an ISO 3166-1 alpha-2 or alpha-3 code, or an English name,
+// case-insensitive) to the given format. It returns ErrUnknownCountry if the value can't be mapped.
+func NormalizeCountry(value string, format Format) (string, error) {
+	c, ok := lookupCountry(value)
+	if !ok {
+		return "", &ErrUnknownCountry{Value: value}
+	}
+	if format == FormatName {
+		return c.Name, nil
+	}
+	return c.Code, nil
+}
+
+// NormalizeRegion maps the region value (an ISO 3166-2 subdivision code without the country prefix,
+// or an English name, case-insensitive) of the given country to the given format.
+// Regions are only normalized for the United States and Canada; regions of other countries are returned unchanged.
+// It returns ErrUnknownCountry if the country can't be mapped and ErrUnknownRegion if the region can't be mapped.
+func NormalizeRegion(countryValue, value string, format Format) (string, error) {
+	c, ok := lookupCountry(countryValue)
+	if !ok {
+		return "", &ErrUnknownCountry{Value: countryValue}
+	}
+
+	byKey, ok := regionsByKey[c.Code]
+	if !ok {
+		return value, nil
+	}
+
+	r, ok := byKey[key(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), c.Code+"-"))]
+	if !ok {
+		return "", &ErrUnknownRegion{Country: c.Code, Value: value}
+	}
+	if format == FormatName {
+		return r.Name, nil
+	}
+	return r.Code, nil
+}
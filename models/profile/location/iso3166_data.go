@@ -0,0 +1,335 @@
+package location
+
+// The data below is taken from the ISO 3166 data of the Debian iso-codes project
+// (https://salsa.debian.org/iso-codes-team/iso-codes), trimmed to the names used for lookups.
+
+// countries lists the ISO 3166-1 countries with their alpha-2 codes, common names and alternative names.
+var countries = []country{
+	{Code: "AD", Name: "Andorra", Aliases: []string{"AND", "Principality of Andorra"}},
+	{Code: "AE", Name: "United Arab Emirates", Aliases: []string{"ARE"}},
+	{Code: "AF", Name: "Afghanistan", Aliases: []string{"AFG", "Islamic Republic of Afghanistan"}},
+	{Code: "AG", Name: "Antigua and Barbuda", Aliases: []string{"ATG"}},
+	{Code: "AI", Name: "Anguilla", Aliases: []string{"AIA"}},
+	{Code: "AL", Name: "Albania", Aliases: []string{"ALB", "Republic of Albania"}},
+	{Code: "AM", Name: "Armenia", Aliases: []string{"ARM", "Republic of Armenia"}},
+	{Code: "AO", Name: "Angola", Aliases: []string{"AGO", "Republic of Angola"}},
+	{Code: "AQ", Name: "Antarctica", Aliases: []string{"ATA"}},
+	{Code: "AR", Name: "Argentina", Aliases: []string{"ARG", "Argentine Republic"}},
+	{Code: "AS", Name: "American Samoa", Aliases: []string{"ASM"}},
+	{Code: "AT", Name: "Austria", Aliases: []string{"AUT", "Republic of Austria"}},
+	{Code: "AU", Name: "Australia", Aliases: []string{"AUS"}},
+	{Code: "AW", Name: "Aruba", Aliases: []string{"ABW"}},
+	{Code: "AX", Name: "Åland Islands", Aliases: []string{"ALA"}},
+	{Code: "AZ", Name: "Azerbaijan", Aliases: []string{"AZE", "Republic of Azerbaijan"}},
+	{Code: "BA", Name: "Bosnia and Herzegovina", Aliases: []string{"BIH", "Republic of Bosnia and Herzegovina"}},
+	{Code: "BB", Name: "Barbados", Aliases: []string{"BRB"}},
+	{Code: "BD", Name: "Bangladesh", Aliases: []string{"BGD", "People's Republic of Bangladesh"}},
+	{Code: "BE", Name: "Belgium", Aliases: []string{"BEL", "Kingdom of Belgium"}},
+	{Code: "BF", Name: "Burkina Faso", Aliases: []string{"BFA"}},
+	{Code: "BG", Name: "Bulgaria", Aliases: []string{"BGR", "Republic of Bulgaria"}},
+	{Code: "BH", Name: "Bahrain", Aliases: []string{"BHR", "Kingdom of Bahrain"}},
+	{Code: "BI", Name: "Burundi", Aliases: []string{"BDI", "Republic of Burundi"}},
+	{Code: "BJ", Name: "Benin", Aliases: []string{"BEN", "Republic of Benin"}},
+	{Code: "BL", Name: "Saint Barthélemy", Aliases: []string{"BLM"}},
+	{Code: "BM", Name: "Bermuda", Aliases: []string{"BMU"}},
+	{Code: "BN", Name: "Brunei Darussalam", Aliases: []string{"BRN"}},
+	{Code: "BO", Name: "Bolivia", Aliases: []string{"BOL", "Bolivia, Plurinational State of", "Plurinational State of Bolivia"}},
+	{Code: "BQ", Name: "Bonaire, Sint Eustatius and Saba", Aliases: []string{"BES"}},
+	{Code: "BR", Name: "Brazil", Aliases: []string{"BRA", "Federative Republic of Brazil"}},
+	{Code: "BS", Name: "Bahamas", Aliases: []string{"BHS", "Commonwealth of the Bahamas"}},
+	{Code: "BT", Name: "Bhutan", Aliases: []string{"BTN", "Kingdom of Bhutan"}},
+	{Code: "BV", Name: "Bouvet Island", Aliases: []string{"BVT"}},
+	{Code: "BW", Name: "Botswana", Aliases: []string{"BWA", "Republic of Botswana"}},
+	{Code: "BY", Name: "Belarus", Aliases: []string{"BLR", "Republic of Belarus"}},
+	{Code: "BZ", Name: "Belize", Aliases: []string{"BLZ"}},
+	{Code: "CA", Name: "Canada", Aliases: []string{"CAN"}},
+	{Code: "CC", Name: "Cocos (Keeling) Islands", Aliases: []string{"CCK"}},
+	{Code: "CD", Name: "Congo, The Democratic Republic of the", Aliases: []string{"COD"}},
+	{Code: "CF", Name: "Central African Republic", Aliases: []string{"CAF"}},
+	{Code: "CG", Name: "Congo", Aliases: []string{"COG", "Republic of the Congo"}},
+	{Code: "CH", Name: "Switzerland", Aliases: []string{"CHE", "Swiss Confederation"}},
+	{Code: "CI", Name: "Côte d'Ivoire", Aliases: []string{"CIV", "Republic of Côte d'Ivoire"}},
+	{Code: "CK", Name: "Cook Islands", Aliases: []string{"COK"}},
+	{Code: "CL", Name: "Chile", Aliases: []string{"CHL", "Republic of Chile"}},
+	{Code: "CM", Name: "Cameroon", Aliases: []string{"CMR", "Republic of Cameroon"}},
+	{Code: "CN", Name: "China", Aliases: []string{"CHN", "People's Republic of China"}},
+	{Code: "CO", Name: "Colombia", Aliases: []string{"COL", "Republic of Colombia"}},
+	{Code: "CR", Name: "Costa Rica", Aliases: []string{"CRI", "Republic of Costa Rica"}},
+	{Code: "CU", Name: "Cuba", Aliases: []string{"CUB", "Republic of Cuba"}},
+	{Code: "CV", Name: "Cabo Verde", Aliases: []string{"CPV", "Republic of Cabo Verde"}},
+	{Code: "CW", Name: "Curaçao", Aliases: []string{"CUW"}},
+	{Code: "CX", Name: "Christmas Island", Aliases: []string{"CXR"}},
+	{Code: "CY", Name: "Cyprus", Aliases: []string{"CYP", "Republic of Cyprus"}},
+	{Code: "CZ", Name: "Czechia", Aliases: []string{"CZE", "Czech Republic"}},
+	{Code: "DE", Name: "Germany", Aliases: []string{"DEU", "Federal Republic of Germany"}},
+	{Code: "DJ", Name: "Djibouti", Aliases: []string{"DJI", "Republic of Djibouti"}},
+	{Code: "DK", Name: "Denmark", Aliases: []string{"DNK", "Kingdom of Denmark"}},
+	{Code: "DM", Name: "Dominica", Aliases: []string{"DMA", "Commonwealth of Dominica"}},
+	{Code: "DO", Name: "Dominican Republic", Aliases: []string{"DOM"}},
+	{Code: "DZ", Name: "Algeria", Aliases: []string{"DZA", "People's Democratic Republic of Algeria"}},
+	{Code: "EC", Name: "Ecuador", Aliases: []string{"ECU", "Republic of Ecuador"}},
+	{Code: "EE", Name: "Estonia", Aliases: []string{"EST", "Republic of Estonia"}},
+	{Code: "EG", Name: "Egypt", Aliases: []string{"EGY", "Arab Republic of Egypt"}},
+	{Code: "EH", Name: "Western Sahara", Aliases: []string{"ESH"}},
+	{Code: "ER", Name: "Eritrea", Aliases: []string{"ERI", "the State of Eritrea"}},
+	{Code: "ES", Name: "Spain", Aliases: []string{"ESP", "Kingdom of Spain"}},
+	{Code: "ET", Name: "Ethiopia", Aliases: []string{"ETH", "Federal Democratic Republic of Ethiopia"}},
+	{Code: "FI", Name: "Finland", Aliases: []string{"FIN", "Republic of Finland"}},
+	{Code: "FJ", Name: "Fiji", Aliases: []string{"FJI", "Republic of Fiji"}},
+	{Code: "FK", Name: "Falkland Islands (Malvinas)", Aliases: []string{"FLK"}},
+	{Code: "FM", Name: "Micronesia, Federated States of", Aliases: []string{"FSM", "Federated States of Micronesia"}},
+	{Code: "FO", Name: "Faroe Islands", Aliases: []string{"FRO"}},
+	{Code: "FR", Name: "France", Aliases: []string{"FRA", "French Republic"}},
+	{Code: "GA", Name: "Gabon", Aliases: []string{"GAB", "Gabonese Republic"}},
+	{Code: "GB", Name: "United Kingdom", Aliases: []string{"GBR", "United Kingdom of Great Britain and Northern Ireland"}},
+	{Code: "GD", Name: "Grenada", Aliases: []string{"GRD"}},
+	{Code: "GE", Name: "Georgia", Aliases: []string{"GEO"}},
+	{Code: "GF", Name: "French Guiana", Aliases: []string{"GUF"}},
+	{Code: "GG", Name: "Guernsey", Aliases: []string{"GGY"}},
+	{Code: "GH", Name: "Ghana", Aliases: []string{"GHA", "Republic of Ghana"}},
+	{Code: "GI", Name: "Gibraltar", Aliases: []string{"GIB"}},
+	{Code: "GL", Name: "Greenland", Aliases: []string{"GRL"}},
+	{Code: "GM", Name: "Gambia", Aliases: []string{"GMB", "Republic of the Gambia"}},
+	{Code: "GN", Name: "Guinea", Aliases: []string{"GIN", "Republic of Guinea"}},
+	{Code: "GP", Name: "Guadeloupe", Aliases: []string{"GLP"}},
+	{Code: "GQ", Name: "Equatorial Guinea", Aliases: []string{"GNQ", "Republic of Equatorial Guinea"}},
+	{Code: "GR", Name: "Greece", Aliases: []string{"GRC", "Hellenic Republic"}},
+	{Code: "GS", Name: "South Georgia and the South Sandwich Islands", Aliases: []string{"SGS"}},
+	{Code: "GT", Name: "Guatemala", Aliases: []string{"GTM", "Republic of Guatemala"}},
+	{Code: "GU", Name: "Guam", Aliases: []string{"GUM"}},
+	{Code: "GW", Name: "Guinea-Bissau", Aliases: []string{"GNB", "Republic of Guinea-Bissau"}},
+	{Code: "GY", Name: "Guyana", Aliases: []string{"GUY", "Republic of Guyana"}},
+	{Code: "HK", Name: "Hong Kong", Aliases: []string{"HKG", "Hong Kong Special Administrative Region of China"}},
+	{Code: "HM", Name: "Heard Island and McDonald Islands", Aliases: []string{"HMD"}},
+	{Code: "HN", Name: "Honduras", Aliases: []string{"HND", "Republic of Honduras"}},
+	{Code: "HR", Name: "Croatia", Aliases: []string{"HRV", "Republic of Croatia"}},
+	{Code: "HT", Name: "Haiti", Aliases: []string{"HTI", "Republic of Haiti"}},
+	{Code: "HU", Name: "Hungary", Aliases: []string{"HUN"}},
+	{Code: "ID", Name: "Indonesia", Aliases: []string{"IDN", "Republic of Indonesia"}},
+	{Code: "IE", Name: "Ireland", Aliases: []string{"IRL"}},
+	{Code: "IL", Name: "Israel", Aliases: []string{"ISR", "State of Israel"}},
+	{Code: "IM", Name: "Isle of Man", Aliases: []string{"IMN"}},
+	{Code: "IN", Name: "India", Aliases: []string{"IND", "Republic of India"}},
+	{Code: "IO", Name: "British Indian Ocean Territory", Aliases: []string{"IOT"}},
+	{Code: "IQ", Name: "Iraq", Aliases: []string{"IRQ", "Republic of Iraq"}},
+	{Code: "IR", Name: "Iran", Aliases: []string{"IRN", "Iran, Islamic Republic of", "Islamic Republic of Iran"}},
+	{Code: "IS", Name: "Iceland", Aliases: []string{"ISL", "Republic of Iceland"}},
+	{Code: "IT", Name: "Italy", Aliases: []string{"ITA", "Italian Republic"}},
+	{Code: "JE", Name: "Jersey", Aliases: []string{"JEY"}},
+	{Code: "JM", Name: "Jamaica", Aliases: []string{"JAM"}},
+	{Code: "JO", Name: "Jordan", Aliases: []string{"JOR", "Hashemite Kingdom of Jordan"}},
+	{Code: "JP", Name: "Japan", Aliases: []string{"JPN"}},
+	{Code: "KE", Name: "Kenya", Aliases: []string{"KEN", "Republic of Kenya"}},
+	{Code: "KG", Name: "Kyrgyzstan", Aliases: []string{"KGZ", "Kyrgyz Republic"}},
+	{Code: "KH", Name: "Cambodia", Aliases: []string{"KHM", "Kingdom of Cambodia"}},
+	{Code: "KI", Name: "Kiribati", Aliases: []string{"KIR", "Republic of Kiribati"}},
+	{Code: "KM", Name: "Comoros", Aliases: []string{"COM", "Union of the Comoros"}},
+	{Code: "KN", Name: "Saint Kitts and Nevis", Aliases: []string{"KNA"}},
+	{Code: "KP", Name: "North Korea", Aliases: []string{"PRK", "Korea, Democratic People's Republic of", "Democratic People's Republic of Korea"}},
+	{Code: "KR", Name: "South Korea", Aliases: []string{"KOR", "Korea, Republic of"}},
+	{Code: "KW", Name: "Kuwait", Aliases: []string{"KWT", "State of Kuwait"}},
+	{Code: "KY", Name: "Cayman Islands", Aliases: []string{"CYM"}},
+	{Code: "KZ", Name: "Kazakhstan", Aliases: []string{"KAZ", "Republic of Kazakhstan"}},
+	{Code: "LA", Name: "Laos", Aliases: []string{"LAO", "Lao People's Democratic Republic"}},
+	{Code: "LB", Name: "Lebanon", Aliases: []string{"LBN", "Lebanese Republic"}},
+	{Code: "LC", Name: "Saint Lucia", Aliases: []string{"LCA"}},
+	{Code: "LI", Name: "Liechtenstein", Aliases: []string{"LIE", "Principality of Liechtenstein"}},
+	{Code: "LK", Name: "Sri Lanka", Aliases: []string{"LKA", "Democratic Socialist Republic of Sri Lanka"}},
+	{Code: "LR", Name: "Liberia", Aliases: []string{"LBR", "Republic of Liberia"}},
+	{Code: "LS", Name: "Lesotho", Aliases: []string{"LSO", "Kingdom of Lesotho"}},
+	{Code: "LT", Name: "Lithuania", Aliases: []string{"LTU", "Republic of Lithuania"}},
+	{Code: "LU", Name: "Luxembourg", Aliases: []string{"LUX", "Grand Duchy of Luxembourg"}},
+	{Code: "LV", Name: "Latvia", Aliases: []string{"LVA", "Republic of Latvia"}},
+	{Code: "LY", Name: "Libya", Aliases: []string{"LBY"}},
+	{Code: "MA", Name: "Morocco", Aliases: []string{"MAR", "Kingdom of Morocco"}},
+	{Code: "MC", Name: "Monaco", Aliases: []string{"MCO", "Principality of Monaco"}},
+	{Code: "MD", Name: "Moldova", Aliases: []string{"MDA", "Moldova, Republic of", "Republic of Moldova"}},
+	{Code: "ME", Name: "Montenegro", Aliases: []string{"MNE"}},
+	{Code: "MF", Name: "Saint Martin (French part)", Aliases: []string{"MAF"}},
+	{Code: "MG", Name: "Madagascar", Aliases: []string{"MDG", "Republic of Madagascar"}},
+	{Code: "MH", Name: "Marshall Islands", Aliases: []string{"MHL", "Republic of the Marshall Islands"}},
+	{Code: "MK", Name: "North Macedonia", Aliases: []string{"MKD", "Republic of North Macedonia"}},
+	{Code: "ML", Name: "Mali", Aliases: []string{"MLI", "Republic of Mali"}},
+	{Code: "MM", Name: "Myanmar", Aliases: []string{"MMR", "Republic of Myanmar"}},
+	{Code: "MN", Name: "Mongolia", Aliases: []string{"MNG"}},
+	{Code: "MO", Name: "Macao", Aliases: []string{"MAC", "Macao Special Administrative Region of China"}},
+	{Code: "MP", Name: "Northern Mariana Islands", Aliases: []string{"MNP", "Commonwealth of the Northern Mariana Islands"}},
+	{Code: "MQ", Name: "Martinique", Aliases: []string{"MTQ"}},
+	{Code: "MR", Name: "Mauritania", Aliases: []string{"MRT", "Islamic Republic of Mauritania"}},
+	{Code: "MS", Name: "Montserrat", Aliases: []string{"MSR"}},
+	{Code: "MT", Name: "Malta", Aliases: []string{"MLT", "Republic of Malta"}},
+	{Code: "MU", Name: "Mauritius", Aliases: []string{"MUS", "Republic of Mauritius"}},
+	{Code: "MV", Name: "Maldives", Aliases: []string{"MDV", "Republic of Maldives"}},
+	{Code: "MW", Name: "Malawi", Aliases: []string{"MWI", "Republic of Malawi"}},
+	{Code: "MX", Name: "Mexico", Aliases: []string{"MEX", "United Mexican States"}},
+	{Code: "MY", Name: "Malaysia", Aliases: []string{"MYS"}},
+	{Code: "MZ", Name: "Mozambique", Aliases: []string{"MOZ", "Republic of Mozambique"}},
+	{Code: "NA", Name: "Namibia", Aliases: []string{"NAM", "Republic of Namibia"}},
+	{Code: "NC", Name: "New Caledonia", Aliases: []string{"NCL"}},
+	{Code: "NE", Name: "Niger", Aliases: []string{"NER", "Republic of the Niger"}},
+	{Code: "NF", Name: "Norfolk Island", Aliases: []string{"NFK"}},
+	{Code: "NG", Name: "Nigeria", Aliases: []string{"NGA", "Federal Republic of Nigeria"}},
+	{Code: "NI", Name: "Nicaragua", Aliases: []string{"NIC", "Republic of Nicaragua"}},
+	{Code: "NL", Name: "Netherlands", Aliases: []string{"NLD", "Kingdom of the Netherlands"}},
+	{Code: "NO", Name: "Norway", Aliases: []string{"NOR", "Kingdom of Norway"}},
+	{Code: "NP", Name: "Nepal", Aliases: []string{"NPL", "Federal Democratic Republic of Nepal"}},
+	{Code: "NR", Name: "Nauru", Aliases: []string{"NRU", "Republic of Nauru"}},
+	{Code: "NU", Name: "Niue", Aliases: []string{"NIU"}},
+	{Code: "NZ", Name: "New Zealand", Aliases: []string{"NZL"}},
+	{Code: "OM", Name: "Oman", Aliases: []string{"OMN", "Sultanate of Oman"}},
+	{Code: "PA", Name: "Panama", Aliases: []string{"PAN", "Republic of Panama"}},
+	{Code: "PE", Name: "Peru", Aliases: []string{"PER", "Republic of Peru"}},
+	{Code: "PF", Name: "French Polynesia", Aliases: []string{"PYF"}},
+	{Code: "PG", Name: "Papua New Guinea", Aliases: []string{"PNG", "Independent State of Papua New Guinea"}},
+	{Code: "PH", Name: "Philippines", Aliases: []string{"PHL", "Republic of the Philippines"}},
+	{Code: "PK", Name: "Pakistan", Aliases: []string{"PAK", "Islamic Republic of Pakistan"}},
+	{Code: "PL", Name: "Poland", Aliases: []string{"POL", "Republic of Poland"}},
+	{Code: "PM", Name: "Saint Pierre and Miquelon", Aliases: []string{"SPM"}},
+	{Code: "PN", Name: "Pitcairn", Aliases: []string{"PCN"}},
+	{Code: "PR", Name: "Puerto Rico", Aliases: []string{"PRI"}},
+	{Code: "PS", Name: "Palestine, State of", Aliases: []string{"PSE", "the State of Palestine"}},
+	{Code: "PT", Name: "Portugal", Aliases: []string{"PRT", "Portuguese Republic"}},
+	{Code: "PW", Name: "Palau", Aliases: []string{"PLW", "Republic of Palau"}},
+	{Code: "PY", Name: "Paraguay", Aliases: []string{"PRY", "Republic of Paraguay"}},
+	{Code: "QA", Name: "Qatar", Aliases: []string{"QAT", "State of Qatar"}},
+	{Code: "RE", Name: "Réunion", Aliases: []string{"REU"}},
+	{Code: "RO", Name: "Romania", Aliases: []string{"ROU"}},
+	{Code: "RS", Name: "Serbia", Aliases: []string{"SRB", "Republic of Serbia"}},
+	{Code: "RU", Name: "Russian Federation", Aliases: []string{"RUS"}},
+	{Code: "RW", Name: "Rwanda", Aliases: []string{"RWA", "Rwandese Republic"}},
+	{Code: "SA", Name: "Saudi Arabia", Aliases: []string{"SAU", "Kingdom of Saudi Arabia"}},
+	{Code: "SB", Name: "Solomon Islands", Aliases: []string{"SLB"}},
+	{Code: "SC", Name: "Seychelles", Aliases: []string{"SYC", "Republic of Seychelles"}},
+	{Code: "SD", Name: "Sudan", Aliases: []string{"SDN", "Republic of the Sudan"}},
+	{Code: "SE", Name: "Sweden", Aliases: []string{"SWE", "Kingdom of Sweden"}},
+	{Code: "SG", Name: "Singapore", Aliases: []string{"SGP", "Republic of Singapore"}},
+	{Code: "SH", Name: "Saint Helena, Ascension and Tristan da Cunha", Aliases: []string{"SHN"}},
+	{Code: "SI", Name: "Slovenia", Aliases: []string{"SVN", "Republic of Slovenia"}},
+	{Code: "SJ", Name: "Svalbard and Jan Mayen", Aliases: []string{"SJM"}},
+	{Code: "SK", Name: "Slovakia", Aliases: []string{"SVK", "Slovak Republic"}},
+	{Code: "SL", Name: "Sierra Leone", Aliases: []string{"SLE", "Republic of Sierra Leone"}},
+	{Code: "SM", Name: "San Marino", Aliases: []string{"SMR", "Republic of San Marino"}},
+	{Code: "SN", Name: "Senegal", Aliases: []string{"SEN", "Republic of Senegal"}},
+	{Code: "SO", Name: "Somalia", Aliases: []string{"SOM", "Federal Republic of Somalia"}},
+	{Code: "SR", Name: "Suriname", Aliases: []string{"SUR", "Republic of Suriname"}},
+	{Code: "SS", Name: "South Sudan", Aliases: []string{"SSD", "Republic of South Sudan"}},
+	{Code: "ST", Name: "Sao Tome and Principe", Aliases: []string{"STP", "Democratic Republic of Sao Tome and Principe"}},
+	{Code: "SV", Name: "El Salvador", Aliases: []string{"SLV", "Republic of El Salvador"}},
+	{Code: "SX", Name: "Sint Maarten (Dutch part)", Aliases: []string{"SXM"}},
+	{Code: "SY", Name: "Syria", Aliases: []string{"SYR", "Syrian Arab Republic"}},
+	{Code: "SZ", Name: "Eswatini", Aliases: []string{"SWZ", "Kingdom of Eswatini"}},
+	{Code: "TC", Name: "Turks and Caicos Islands", Aliases: []string{"TCA"}},
+	{Code: "TD", Name: "Chad", Aliases: []string{"TCD", "Republic of Chad"}},
+	{Code: "TF", Name: "French Southern Territories", Aliases: []string{"ATF"}},
+	{Code: "TG", Name: "Togo", Aliases: []string{"TGO", "Togolese Republic"}},
+	{Code: "TH", Name: "Thailand", Aliases: []string{"THA", "Kingdom of Thailand"}},
+	{Code: "TJ", Name: "Tajikistan", Aliases: []string{"TJK", "Republic of Tajikistan"}},
+	{Code: "TK", Name: "Tokelau", Aliases: []string{"TKL"}},
+	{Code: "TL", Name: "Timor-Leste", Aliases: []string{"TLS", "Democratic Republic of Timor-Leste"}},
+	{Code: "TM", Name: "Turkmenistan", Aliases: []string{"TKM"}},
+	{Code: "TN", Name: "Tunisia", Aliases: []string{"TUN", "Republic of Tunisia"}},
+	{Code: "TO", Name: "Tonga", Aliases: []string{"TON", "Kingdom of Tonga"}},
+	{Code: "TR", Name: "Türkiye", Aliases: []string{"TUR", "Republic of Türkiye"}},
+	{Code: "TT", Name: "Trinidad and Tobago", Aliases: []string{"TTO", "Republic of Trinidad and Tobago"}},
+	{Code: "TV", Name: "Tuvalu", Aliases: []string{"TUV"}},
+	{Code: "TW", Name: "Taiwan", Aliases: []string{"TWN", "Taiwan, Province of China"}},
+	{Code: "TZ", Name: "Tanzania", Aliases: []string{"TZA", "Tanzania, United Republic of", "United Republic of Tanzania"}},
+	{Code: "UA", Name: "Ukraine", Aliases: []string{"UKR"}},
+	{Code: "UG", Name: "Uganda", Aliases: []string{"UGA", "Republic of Uganda"}},
+	{Code: "UM", Name: "United States Minor Outlying Islands", Aliases: []string{"UMI"}},
+	{Code: "US", Name: "United States", Aliases: []string{"USA", "United States of America"}},
+	{Code: "UY", Name: "Uruguay", Aliases: []string{"URY", "Eastern Republic of Uruguay"}},
+	{Code: "UZ", Name: "Uzbekistan", Aliases: []string{"UZB", "Republic of Uzbekistan"}},
+	{Code: "VA", Name: "Holy See (Vatican City State)", Aliases: []string{"VAT"}},
+	{Code: "VC", Name: "Saint Vincent and the Grenadines", Aliases: []string{"VCT"}},
+	{Code: "VE", Name: "Venezuela", Aliases: []string{"VEN", "Venezuela, Bolivarian Republic of", "Bolivarian Republic of Venezuela"}},
+	{Code: "VG", Name: "Virgin Islands, British", Aliases: []string{"VGB", "British Virgin Islands"}},
+	{Code: "VI", Name: "Virgin Islands, U.S.", Aliases: []string{"VIR", "Virgin Islands of the United States"}},
+	{Code: "VN", Name: "Vietnam", Aliases: []string{"VNM", "Viet Nam", "Socialist Republic of Viet Nam"}},
+	{Code: "VU", Name: "Vanuatu", Aliases: []string{"VUT", "Republic of Vanuatu"}},
+	{Code: "WF", Name: "Wallis and Futuna", Aliases: []string{"WLF"}},
+	{Code: "WS", Name: "Samoa", Aliases: []string{"WSM", "Independent State of Samoa"}},
+	{Code: "YE", Name: "Yemen", Aliases: []string{"YEM", "Republic of Yemen"}},
+	{Code: "YT", Name: "Mayotte", Aliases: []string{"MYT"}},
+	{Code: "ZA", Name: "South Africa", Aliases: []string{"ZAF", "Republic of South Africa"}},
+	{Code: "ZM", Name: "Zambia", Aliases: []string{"ZMB", "Republic of Zambia"}},
+	{Code: "ZW", Name: "Zimbabwe", Aliases: []string{"ZWE", "Republic of Zimbabwe"}},
+}
+
+// regions lists the ISO 3166-2 subdivisions of the countries whose regions are normalized, keyed by the country code.
+var regions = map[string][]region{
+	"CA": {
+		{Code: "AB", Name: "Alberta"},
+		{Code: "BC", Name: "British Columbia"},
+		{Code: "MB", Name: "Manitoba"},
+		{Code: "NB", Name: "New Brunswick"},
+		{Code: "NL", Name: "Newfoundland and Labrador"},
+		{Code: "NS", Name: "Nova Scotia"},
+		{Code: "NT", Name: "Northwest Territories"},
+		{Code: "NU", Name: "Nunavut"},
+		{Code: "ON", Name: "Ontario"},
+		{Code: "PE", Name: "Prince Edward Island"},
+		{Code: "QC", Name: "Quebec"},
+		{Code: "SK", Name: "Saskatchewan"},
+		{Code: "YT", Name: "Yukon"},
+	},
+	"US": {
+		{Code: "AK", Name: "Alaska"},
+		{Code: "AL", Name: "Alabama"},
+		{Code: "AR", Name: "Arkansas"},
+		{Code: "AS", Name: "American Samoa"},
+		{Code: "AZ", Name: "Arizona"},
+		{Code: "CA", Name: "California"},
+		{Code: "CO", Name: "Colorado"},
+		{Code: "CT", Name: "Connecticut"},
+		{Code: "DC", Name: "District of Columbia"},
+		{Code: "DE", Name: "Delaware"},
+		{Code: "FL", Name: "Florida"},
+		{Code: "GA", Name: "Georgia"},
+		{Code: "GU", Name: "Guam"},
+		{Code: "HI", Name: "Hawaii"},
+		{Code: "IA", Name: "Iowa"},
+		{Code: "ID", Name: "Idaho"},
+		{Code: "IL", Name: "Illinois"},
+		{Code: "IN", Name: "Indiana"},
+		{Code: "KS", Name: "Kansas"},
+		{Code: "KY", Name: "Kentucky"},
+		{Code: "LA", Name: "Louisiana"},
+		{Code: "MA", Name: "Massachusetts"},
+		{Code: "MD", Name: "Maryland"},
+		{Code: "ME", Name: "Maine"},
+		{Code: "MI", Name: "Michigan"},
+		{Code: "MN", Name: "Minnesota"},
+		{Code: "MO", Name: "Missouri"},
+		{Code: "MP", Name: "Northern Mariana Islands"},
+		{Code: "MS", Name: "Mississippi"},
+		{Code: "MT", Name: "Montana"},
+		{Code: "NC", Name: "North Carolina"},
+		{Code: "ND", Name: "North Dakota"},
+		{Code: "NE", Name: "Nebraska"},
+		{Code: "NH", Name: "New Hampshire"},
+		{Code: "NJ", Name: "New Jersey"},
+		{Code: "NM", Name: "New Mexico"},
+		{Code: "NV", Name: "Nevada"},
+		{Code: "NY", Name: "New York"},
+		{Code: "OH", Name: "Ohio"},
+		{Code: "OK", Name: "Oklahoma"},
+		{Code: "OR", Name: "Oregon"},
+		{Code: "PA", Name: "Pennsylvania"},
+		{Code: "PR", Name: "Puerto Rico"},
+		{Code: "RI", Name: "Rhode Island"},
+		{Code: "SC", Name: "South Carolina"},
+		{Code: "SD", Name: "South Dakota"},
+		{Code: "TN", Name: "Tennessee"},
+		{Code: "TX", Name: "Texas"},
+		{Code: "UM", Name: "United States Minor Outlying Islands"},
+		{Code: "UT", Name: "Utah"},
+		{Code: "VA", Name: "Virginia"},
+		{Code: "VI", Name: "Virgin Islands, U.S."},
+		{Code: "VT", Name: "Vermont"},
+		{Code: "WA", Name: "Washington"},
+		{Code: "WI", Name: "Wisconsin"},
+		{Code: "WV", Name: "West Virginia"},
+		{Code: "WY", Name: "Wyoming"},
+	},
+}
@@ -0,0 +1,69 @@
+package location_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/profile/location"
+)
+
+func TestNormalizeCountry(t *testing.T) {
+	tests := []struct {
+		value string
+		code  string
+		name  string
+	}{
+		{"US", "US", "United States"},
+		{"united states", "US", "United States"},
+		{"United States of America", "US", "United States"},
+		{"USA", "US", "United States"},
+		{"  UK ", "GB", "United Kingdom"},
+		{"Bolivia", "BO", "Bolivia"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			code, err := location.NormalizeCountry(tt.value, location.FormatCode)
+			require.NoError(t, err)
+			require.Equal(t, tt.code, code)
+
+			name, err := location.NormalizeCountry(tt.value, location.FormatName)
+			require.NoError(t, err)
+			require.Equal(t, tt.name, name)
+		})
+	}
+
+	t.Run("unknown country", func(t *testing.T) {
+		_, err := location.NormalizeCountry("Atlantis", location.FormatCode)
+
+		var e *location.ErrUnknownCountry
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "Atlantis", e.Value)
+	})
+
+	t.Run("continent is not a country", func(t *testing.T) {
+		_, err := location.NormalizeCountry("America", location.FormatCode)
+
+		var e *location.ErrUnknownCountry
+		require.ErrorAs(t, err, &e)
+	})
+}
+
+func TestNormalizeRegion(t *testing.T) {
+	code, err := location.NormalizeRegion("United States", "new york", location.FormatCode)
+	require.NoError(t, err)
+	require.Equal(t, "NY", code)
+
+	name, err := location.NormalizeRegion("US", "US-NY", location.FormatName)
+	require.NoError(t, err)
+	require.Equal(t, "New York", name)
+
+	region, err := location.NormalizeRegion("Lithuania", "Vilnius County", location.FormatCode)
+	require.NoError(t, err)
+	require.Equal(t, "Vilnius County", region, "regions of other countries are not normalized")
+
+	_, err = location.NormalizeRegion("US", "Ontario", location.FormatCode)
+	var e *location.ErrUnknownRegion
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, "US", e.Country)
+}
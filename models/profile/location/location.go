@@ -66,3 +66,11 @@ func WithTimezone(timezone string) updater.Location {
 		location["timezone"] = timezone
 	})
 }
+
+// WithCoordinates sets the latitude and longitude for the location.
+func WithCoordinates(latitude, longitude float64) updater.Location {
+	return updater.LocationFunc(func(location map[string]interface{}) {
+		location["latitude"] = latitude
+		location["longitude"] = longitude
+	})
+}
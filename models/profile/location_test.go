@@ -0,0 +1,42 @@
+package profile_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/models/profile"
+)
+
+func TestLocation_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		json      string
+		latitude  *float64
+		longitude *float64
+	}{
+		{"numbers", `{"city":"New York","latitude":40.7128,"longitude":-74.006}`, pVal(40.7128), pVal(-74.006)},
+		{"decimal strings", `{"city":"New York","latitude":"40.7128","longitude":"-74.006"}`, pVal(40.7128), pVal(-74.006)},
+		{"empty strings", `{"city":"New York","latitude":"","longitude":""}`, nil, nil},
+		{"nulls", `{"city":"New York","latitude":null,"longitude":null}`, nil, nil},
+		{"missing", `{"city":"New York"}`, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loc profile.Location
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &loc))
+
+			require.Equal(t, pVal("New York"), loc.City)
+			require.Equal(t, tt.latitude, loc.Latitude)
+			require.Equal(t, tt.longitude, loc.Longitude)
+		})
+	}
+
+	t.Run("invalid string", func(t *testing.T) {
+		var loc profile.Location
+		require.Error(t, json.Unmarshal([]byte(`{"latitude":"north"}`), &loc))
+	})
+}
+
+func pVal[T any](val T) *T { return &val }
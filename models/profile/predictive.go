@@ -0,0 +1,20 @@
+package profile
+
+import (
+	"time"
+)
+
+// PredictiveAnalytics contains the predictive analytics of a profile, e.g. its customer lifetime value (CLV).
+// It is only returned when requested with the "predictive_analytics" additional field. The values are unset
+// if Klaviyo has not enough data to compute them.
+type PredictiveAnalytics struct {
+	HistoricCLV              *float64   `json:"historic_clv"`
+	PredictedCLV             *float64   `json:"predicted_clv"`
+	TotalCLV                 *float64   `json:"total_clv"`
+	HistoricNumberOfOrders   *float64   `json:"historic_number_of_orders"`
+	PredictedNumberOfOrders  *float64   `json:"predicted_number_of_orders"`
+	AverageDaysBetweenOrders *float64   `json:"average_days_between_orders"`
+	AverageOrderValue        *float64   `json:"average_order_value"`
+	ChurnProbability         *float64   `json:"churn_probability"`
+	ExpectedDateOfNextOrder  *time.Time `json:"expected_date_of_next_order"`
+}
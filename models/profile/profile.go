@@ -40,6 +40,10 @@ type ExistingAttributes struct {
 	Created       time.Time  `json:"created"`
 	Updated       time.Time  `json:"updated"`
 	LastEventDate *time.Time `json:"last_event_date"`
+	// Subscriptions is only set when requested with the "subscriptions" additional field.
+	Subscriptions *Subscriptions `json:"subscriptions,omitempty"`
+	// PredictiveAnalytics is only set when requested with the "predictive_analytics" additional field.
+	PredictiveAnalytics *PredictiveAnalytics `json:"predictive_analytics,omitempty"`
 }
 
 // Location represents the geographical location details for a profile.
@@ -129,6 +133,12 @@ func WithLocation(updaters ...updater.Location) updater.Profile {
 	})
 }
 
+// WithLocationFrom sets the location for the profile from a single struct.
+// Only the non-nil fields of the location are set.
+func WithLocationFrom(loc Location) updater.Profile {
+	return WithLocation(loc.toUpdaters()...)
+}
+
 // WithProperties sets the properties for the profile.
 //
 // It accepts a variable number of updaters that each set a specific property.
@@ -150,6 +160,26 @@ func UnsetProperties(propertyNames ...string) updater.Profile {
 	})
 }
 
+// AppendProperty appends a value to the list stored in the property. If the property doesn't exist, it is created.
+func AppendProperty(propertyName string, value interface{}) updater.Profile {
+	return updater.ProfileFunc(func(profile *updater.ProfileData) {
+		if profile.PropertiesToAppend == nil {
+			profile.PropertiesToAppend = make(map[string]interface{})
+		}
+		profile.PropertiesToAppend[propertyName] = value
+	})
+}
+
+// UnappendProperty removes a value from the list stored in the property.
+func UnappendProperty(propertyName string, value interface{}) updater.Profile {
+	return updater.ProfileFunc(func(profile *updater.ProfileData) {
+		if profile.PropertiesToUnappend == nil {
+			profile.PropertiesToUnappend = make(map[string]interface{})
+		}
+		profile.PropertiesToUnappend[propertyName] = value
+	})
+}
+
 // ToUpdaters takes a NewProfile and transforms it into a slice of updater.Profile.
 // This function facilitates the conversion of a profile's fields into a series of updaters,
 // which can be used to modify a profile in a more granular manner. Importantly, it creates updaters
@@ -211,7 +241,24 @@ func (p *NewProfile) ToUpdaters() []updater.Profile {
 	}
 
 	// Location
-	loc := attr.Location
+	if locationUpdaters := attr.Location.toUpdaters(); len(locationUpdaters) > 0 {
+		updaters = append(updaters, WithLocation(locationUpdaters...))
+	}
+
+	// Properties
+	var propertiesUpdaters []updater.Properties
+	for key, value := range attr.Properties {
+		propertiesUpdaters = append(propertiesUpdaters, property.WithValue(key, value))
+	}
+	if len(propertiesUpdaters) > 0 {
+		updaters = append(updaters, WithProperties(propertiesUpdaters...))
+	}
+
+	return updaters
+}
+
+// toUpdaters transforms the non-nil fields of the location into a slice of updater.Location.
+func (loc Location) toUpdaters() []updater.Location {
 	var locationUpdaters []updater.Location
 	if loc.Address1 != nil {
 		locationUpdaters = append(locationUpdaters, location.WithAddress1(*loc.Address1))
@@ -240,18 +287,5 @@ func (p *NewProfile) ToUpdaters() []updater.Profile {
 	if loc.Timezone != nil {
 		locationUpdaters = append(locationUpdaters, location.WithTimezone(*loc.Timezone))
 	}
-	if len(locationUpdaters) > 0 {
-		updaters = append(updaters, WithLocation(locationUpdaters...))
-	}
-
-	// Properties
-	var propertiesUpdaters []updater.Properties
-	for key, value := range attr.Properties {
-		propertiesUpdaters = append(propertiesUpdaters, property.WithValue(key, value))
-	}
-	if len(propertiesUpdaters) > 0 {
-		updaters = append(updaters, WithProperties(propertiesUpdaters...))
-	}
-
-	return updaters
+	return locationUpdaters
 }
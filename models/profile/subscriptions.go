@@ -0,0 +1,76 @@
+package profile
+
+import (
+	"time"
+)
+
+// Known reasons of email suppressions.
+const (
+	SuppressionReasonHardBounce     = "HARD_BOUNCE"
+	SuppressionReasonSpamComplaint  = "SPAM_COMPLAINT"
+	SuppressionReasonUserSuppressed = "USER_SUPPRESSED"
+	SuppressionReasonUnsubscribe    = "UNSUBSCRIBE"
+	SuppressionReasonInvalidEmail   = "INVALID_EMAIL"
+)
+
+// Subscriptions contains the subscription (consent) state of a profile. It is only returned
+// when requested with the "subscriptions" additional field.
+type Subscriptions struct {
+	Email *EmailSubscriptions `json:"email"`
+	SMS   *SMSSubscriptions   `json:"sms"`
+}
+
+// EmailSubscriptions contains the email subscription state of a profile.
+type EmailSubscriptions struct {
+	Marketing *EmailMarketing `json:"marketing"`
+}
+
+// SMSSubscriptions contains the SMS subscription state of a profile.
+type SMSSubscriptions struct {
+	Marketing *Marketing `json:"marketing"`
+}
+
+// Marketing contains the marketing consent of a profile for a channel.
+type Marketing struct {
+	Consent      string     `json:"consent"`
+	Timestamp    *time.Time `json:"timestamp"`
+	Method       *string    `json:"method"`
+	MethodDetail *string    `json:"method_detail"`
+}
+
+// EmailMarketing contains the email marketing consent and suppressions of a profile.
+type EmailMarketing struct {
+	Marketing
+	CanReceiveEmailMarketing *bool              `json:"can_receive_email_marketing"`
+	CustomMethodDetail       *string            `json:"custom_method_detail"`
+	DoubleOptin              *bool              `json:"double_optin"`
+	Suppressions             []*Suppression     `json:"suppressions"`
+	ListSuppressions         []*ListSuppression `json:"list_suppressions"`
+}
+
+// Suppression describes why and when a profile was suppressed from receiving emails.
+type Suppression struct {
+	Reason    string     `json:"reason"`
+	Timestamp *time.Time `json:"timestamp"`
+}
+
+// ListSuppression describes why and when a profile was suppressed from receiving emails sent to a list.
+type ListSuppression struct {
+	ListID    string     `json:"list_id"`
+	Reason    string     `json:"reason"`
+	Timestamp *time.Time `json:"timestamp"`
+}
+
+// EmailSuppressions returns the global email suppressions of the profile, or nil if there are none
+// or the subscriptions additional field was not requested.
+func (a *ExistingAttributes) EmailSuppressions() []*Suppression {
+	if s := a.Subscriptions; s != nil && s.Email != nil && s.Email.Marketing != nil {
+		return s.Email.Marketing.Suppressions
+	}
+	return nil
+}
+
+// IsEmailSuppressed reports whether the profile is suppressed from receiving emails.
+func (a *ExistingAttributes) IsEmailSuppressed() bool {
+	return len(a.EmailSuppressions()) > 0
+}
@@ -2,8 +2,10 @@ package updater
 
 // ProfileData holds all the data needed to update the profile
 type ProfileData struct {
-	Attributes         map[string]interface{}
-	PropertiesToRemove []string
+	Attributes           map[string]interface{}
+	PropertiesToRemove   []string
+	PropertiesToAppend   map[string]interface{}
+	PropertiesToUnappend map[string]interface{}
 }
 
 // NewProfileData creates new instance of ProfileData
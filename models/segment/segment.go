@@ -0,0 +1,18 @@
+package segment
+
+import (
+	"time"
+)
+
+// ExistingSegment represents the data structure for a segment that is already created.
+type ExistingSegment struct {
+	ID         string     `json:"id"`
+	Attributes Attributes `json:"attributes"`
+}
+
+// Attributes contains attributes of a segment.
+type Attributes struct {
+	Name    string     `json:"name"`
+	Created *time.Time `json:"created"`
+	Updated *time.Time `json:"updated"`
+}
@@ -0,0 +1,72 @@
+package klaviyo
+
+import (
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/location"
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+)
+
+// normalizeNewProfile returns the profile with its location country and region normalized
+// to the format set by WithLocationNormalization. The given profile is not modified.
+func (c *Client) normalizeNewProfile(p *profile.NewProfile) (*profile.NewProfile, error) {
+	format := c.options.locationFormat
+	if format == 0 || p == nil {
+		return p, nil
+	}
+
+	np := *p
+	loc := &np.Attributes.Location
+	if loc.Country == nil {
+		return &np, nil
+	}
+
+	country, err := location.NormalizeCountry(*loc.Country, format)
+	if err != nil {
+		return nil, err
+	}
+	loc.Country = &country
+
+	if loc.Region != nil {
+		region, err := location.NormalizeRegion(country, *loc.Region, format)
+		if err != nil {
+			return nil, err
+		}
+		loc.Region = &region
+	}
+
+	return &np, nil
+}
+
+// normalizeProfileData normalizes the location country and region of the profile update
+// to the format set by WithLocationNormalization. The region is only normalized if the country is updated too.
+func (c *Client) normalizeProfileData(data *updater.ProfileData) error {
+	format := c.options.locationFormat
+	if format == 0 {
+		return nil
+	}
+
+	loc, ok := data.Attributes["location"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	countryValue, ok := loc["country"].(string)
+	if !ok {
+		return nil
+	}
+
+	country, err := location.NormalizeCountry(countryValue, format)
+	if err != nil {
+		return err
+	}
+	loc["country"] = country
+
+	if regionValue, ok := loc["region"].(string); ok {
+		region, err := location.NormalizeRegion(country, regionValue, format)
+		if err != nil {
+			return err
+		}
+		loc["region"] = region
+	}
+
+	return nil
+}
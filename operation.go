@@ -0,0 +1,86 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Operation identifies an API operation performed by the client.
+type Operation string
+
+// Operations performed by the client.
+const (
+	OperationGetAccounts            Operation = "GetAccounts"
+	OperationGetBulkImportJobs      Operation = "GetBulkImportJobs"
+	OperationGetBulkImportJob       Operation = "GetBulkImportJob"
+	OperationCreateBulkImportJob    Operation = "CreateBulkImportJob"
+	OperationGetCampaign            Operation = "GetCampaign"
+	OperationCreateCampaign         Operation = "CreateCampaign"
+	OperationGetCampaignSendJob     Operation = "GetCampaignSendJob"
+	OperationUpdateCampaignSendJob  Operation = "UpdateCampaignSendJob"
+	OperationGetEvents              Operation = "GetEvents"
+	OperationGetEvent               Operation = "GetEvent"
+	OperationCreateEvent            Operation = "CreateEvent"
+	OperationGetFlows               Operation = "GetFlows"
+	OperationGetFlow                Operation = "GetFlow"
+	OperationGetFlowActions         Operation = "GetFlowActions"
+	OperationGetMetrics             Operation = "GetMetrics"
+	OperationGetMetric              Operation = "GetMetric"
+	OperationGetLists               Operation = "GetLists"
+	OperationGetList                Operation = "GetList"
+	OperationGetListProfiles        Operation = "GetListProfiles"
+	OperationRemoveProfilesFromList Operation = "RemoveProfilesFromList"
+	OperationGetProfiles            Operation = "GetProfiles"
+	OperationGetProfile             Operation = "GetProfile"
+	OperationGetProfileLists        Operation = "GetProfileLists"
+	OperationGetProfileSegments     Operation = "GetProfileSegments"
+	OperationCreateProfile          Operation = "CreateProfile"
+	OperationUpdateProfile          Operation = "UpdateProfile"
+	OperationCreateOrUpdateProfile  Operation = "CreateOrUpdateProfile"
+	OperationGetSegments            Operation = "GetSegments"
+	OperationGetSegment             Operation = "GetSegment"
+	OperationGetSegmentProfiles     Operation = "GetSegmentProfiles"
+	OperationSuppressProfiles       Operation = "SuppressProfiles"
+	OperationUnsuppressProfiles     Operation = "UnsuppressProfiles"
+	OperationSubscribeProfiles      Operation = "SubscribeProfiles"
+	OperationUnsubscribeProfiles    Operation = "UnsubscribeProfiles"
+)
+
+// Operations returns all the operations performed by the client, sorted by name, e.g. to register
+// the telemetry labels of every operation up front.
+func Operations() []Operation {
+	ops := make([]Operation, 0, len(requiredScopes))
+	for op := range requiredScopes {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i] < ops[j] })
+	return ops
+}
+
+// OperationFromContext returns the operation of the request with the context, if any. The context of every request
+// sent by the client carries its operation, so that e.g. a custom http.RoundTripper of a client created with
+// NewWithClient can label its telemetry with the operation instead of parsing the URL path.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationContextKey{}).(Operation)
+	return op, ok
+}
+
+// OperationOf returns the operation of the request that failed with the error, if the error carries it,
+// e.g. an *APIError, a *BadHTTPResponseError, an *ErrMissingScope or an *ErrOperationNotAllowed.
+func OperationOf(err error) (Operation, bool) {
+	var opErr interface{ operation() Operation }
+	if !errors.As(err, &opErr) {
+		return "", false
+	}
+	op := opErr.operation()
+	return op, op != ""
+}
+
+func (e *APIError) operation() Operation                 { return e.Operation }
+func (e *BadHTTPResponseError) operation() Operation     { return e.op }
+func (e *ErrMissingScope) operation() Operation          { return e.Operation }
+func (e *ErrOperationNotAllowed) operation() Operation   { return e.Operation }
+func (e *ErrUnknownField) operation() Operation          { return e.Operation }
+func (e *ErrUnsupportedAttributes) operation() Operation { return e.Operation }
+func (e *ErrPartialPage) operation() Operation           { return e.Operation }
@@ -0,0 +1,103 @@
+package klaviyo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+func TestOperations(t *testing.T) {
+	ops := klaviyo.Operations()
+
+	require.Contains(t, ops, klaviyo.OperationGetProfiles)
+	require.Contains(t, ops, klaviyo.OperationCreateEvent)
+	require.True(t, sort.SliceIsSorted(ops, func(i, j int) bool { return ops[i] < ops[j] }))
+	for _, op := range ops {
+		require.NotEmpty(t, klaviyo.RequiredScope(op), op)
+	}
+}
+
+func TestOperationFromContext(t *testing.T) {
+	var ops []klaviyo.Operation
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		op, ok := klaviyo.OperationFromContext(req.Context())
+		require.True(t, ok)
+		ops = append(ops, op)
+		return jsonResponse(http.StatusOK, `{"data":[],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	_, err := kc.GetProfiles(context.TODO())
+	require.NoError(t, err)
+	_, err = kc.GetEvents(context.TODO())
+	require.NoError(t, err)
+
+	require.Equal(t, []klaviyo.Operation{klaviyo.OperationGetProfiles, klaviyo.OperationGetEvents}, ops)
+
+	_, ok := klaviyo.OperationFromContext(context.TODO())
+	require.False(t, ok)
+}
+
+func TestOperationOf(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/events" {
+			return jsonResponse(http.StatusBadRequest, `<html>Bad Request</html>`), nil
+		}
+		return jsonResponse(http.StatusBadRequest, `{"errors":[{"status":400,"code":"invalid","title":"Invalid input."}]}`), nil
+	})}
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithDeniedOperations(klaviyo.OperationCreateEvent))
+
+	_, err := kc.GetProfiles(context.TODO())
+	var apiErr *klaviyo.APIError
+	require.ErrorAs(t, err, &apiErr)
+	op, ok := klaviyo.OperationOf(err)
+	require.True(t, ok)
+	require.Equal(t, klaviyo.OperationGetProfiles, op)
+
+	_, err = kc.GetEvents(context.TODO())
+	var badResponseErr *klaviyo.BadHTTPResponseError
+	require.ErrorAs(t, err, &badResponseErr)
+	require.Equal(t, klaviyo.OperationGetEvents, badResponseErr.Operation())
+	op, ok = klaviyo.OperationOf(err)
+	require.True(t, ok)
+	require.Equal(t, klaviyo.OperationGetEvents, op)
+
+	_, err = kc.CreateEvent(context.TODO(), &event.NewEvent{}, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", "Placed Order")
+	op, ok = klaviyo.OperationOf(err)
+	require.True(t, ok, err)
+	require.Equal(t, klaviyo.OperationCreateEvent, op)
+
+	_, ok = klaviyo.OperationOf(errors.New("unrelated"))
+	require.False(t, ok)
+
+	entries := logs.FilterMessage("request completed").All()
+	require.Len(t, entries, 2)
+	require.Equal(t, klaviyo.OperationGetProfiles, entries[0].ContextMap()[klaviyo.LogFieldOperation])
+}
+
+func TestAPICompatibility(t *testing.T) {
+	compat := klaviyo.APICompatibility()
+
+	require.Equal(t, klaviyo.Version, compat.Version)
+	require.NotEmpty(t, compat.Revision)
+	require.Len(t, compat.Endpoints, len(klaviyo.Operations()))
+	for i, e := range compat.Endpoints {
+		require.Equal(t, klaviyo.Operations()[i], e.Operation)
+		require.NotEmpty(t, e.Method, e.Operation)
+		require.NotEmpty(t, e.Path, e.Operation)
+		require.Equal(t, klaviyo.RequiredScope(e.Operation), e.Scope)
+	}
+}
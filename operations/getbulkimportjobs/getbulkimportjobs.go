@@ -0,0 +1,27 @@
+// Package provides utilities to define parameters for the GetBulkImportJobs method.
+
+package getbulkimportjobs
+
+import (
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/models/bulkimport"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+// Param is an interface that any parameter type should implement.
+// It provides a method to apply the parameter as a query parameter.
+type Param = query.Param
+
+// FieldsUpdaterFunc is a type that wraps a function that updates URL query parameters.
+type FieldsUpdaterFunc = query.FieldsUpdaterFunc
+
+// WithPageSize returns a parameter that sets the page size for the request.
+// It ensures that the page size is within the range allowed for bulk import jobs.
+func WithPageSize(pageSize int) Param {
+	return query.ResourcePageSize("profile-bulk-import-job", pageSize)
+}
+
+// WithStatus returns a parameter that retrieves only the jobs with the given status.
+func WithStatus(status bulkimport.Status) Param {
+	return query.Filter(filter.Equals("status", string(status)))
+}
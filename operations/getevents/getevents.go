@@ -0,0 +1,51 @@
+// Package provides utilities to define parameters for the GetEvents method.
+
+package getevents
+
+import (
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+// Param is an interface that any parameter type should implement.
+// It provides a method to apply the parameter as a query parameter.
+type Param = query.Param
+
+// FieldsUpdaterFunc is a type that wraps a function that updates URL query parameters.
+type FieldsUpdaterFunc = query.FieldsUpdaterFunc
+
+// WithPageSize returns a parameter that sets the page size for the request.
+// It ensures that the page size is within the range allowed for events.
+func WithPageSize(pageSize int) Param {
+	return query.ResourcePageSize("event", pageSize)
+}
+
+// WithCursor returns a parameter that requests the page starting at the cursor.
+// An empty cursor requests the first page.
+func WithCursor(cursor string) Param {
+	return query.Cursor(cursor)
+}
+
+// WithFields returns a parameter that sets the specific fields to be retrieved for the event,
+// e.g. "timestamp" or "event_properties".
+func WithFields(fieldName ...string) Param {
+	return query.Fields("event", fieldName...)
+}
+
+// WithFilter returns a parameter that retrieves only the events matching the filter expression,
+// e.g. filter.GreaterThan("datetime", t). An empty expression has no effect.
+func WithFilter(f filter.Expr) Param {
+	return query.Filter(f)
+}
+
+// WithSort returns a parameter that sorts the events by the field, e.g. "datetime";
+// a "-" prefix sorts in descending order, e.g. "-datetime".
+func WithSort(field string) Param {
+	return query.Sort(field)
+}
+
+// WithInclude returns a parameter that includes the related resources of the events in the response,
+// e.g. "profile" and "metric".
+func WithInclude(resource ...string) Param {
+	return query.Include(resource...)
+}
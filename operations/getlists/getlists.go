@@ -0,0 +1,38 @@
+// Package provides utilities to define parameters for the GetLists method.
+
+package getlists
+
+import (
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+// Param is an interface that any parameter type should implement.
+// It provides a method to apply the parameter as a query parameter.
+type Param = query.Param
+
+// FieldsUpdaterFunc is a type that wraps a function that updates URL query parameters.
+type FieldsUpdaterFunc = query.FieldsUpdaterFunc
+
+// WithFields returns a parameter that sets the specific fields to be retrieved for the list, e.g. "name".
+func WithFields(fieldName ...string) Param {
+	return query.Fields("list", fieldName...)
+}
+
+// WithFilter returns a parameter that retrieves only the lists matching the filter expression,
+// e.g. filter.Equals("name", "Newsletter"). An empty expression has no effect.
+func WithFilter(f filter.Expr) Param {
+	return query.Filter(f)
+}
+
+// WithSort returns a parameter that sorts the lists by the field, e.g. "name";
+// a "-" prefix sorts in descending order, e.g. "-created".
+func WithSort(field string) Param {
+	return query.Sort(field)
+}
+
+// WithCursor returns a parameter that requests the page starting at the cursor.
+// An empty cursor requests the first page.
+func WithCursor(cursor string) Param {
+	return query.Cursor(cursor)
+}
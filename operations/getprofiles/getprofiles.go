@@ -3,30 +3,26 @@
 package getprofiles
 
 import (
-	"net/url"
-	"strconv"
-	"strings"
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/operations/query"
 )
 
 const (
-	minPageSize     = 1
-	maxPageSize     = 100
 	defaultPageSize = 20
 )
 
+// Additional fields of the profile that are not returned by default.
+const (
+	AdditionalFieldSubscriptions       = "subscriptions"
+	AdditionalFieldPredictiveAnalytics = "predictive_analytics"
+)
+
 // Param is an interface that any parameter type should implement.
 // It provides a method to apply the parameter as a query parameter.
-type Param interface {
-	Apply(fields url.Values)
-}
+type Param = query.Param
 
 // FieldsUpdaterFunc is a type that wraps a function that updates URL query parameters.
-type FieldsUpdaterFunc func(url.Values)
-
-// Apply calls the underlying function to update the URL query parameters.
-func (f FieldsUpdaterFunc) Apply(fields url.Values) {
-	f(fields)
-}
+type FieldsUpdaterFunc = query.FieldsUpdaterFunc
 
 // WithDefaultPageSize returns a parameter that sets the page size to its default value.
 func WithDefaultPageSize() Param {
@@ -34,24 +30,31 @@ func WithDefaultPageSize() Param {
 }
 
 // WithPageSize returns a parameter that sets the page size for the request.
-// It ensures that the page size is within the allowed range.
+// It ensures that the page size is within the range allowed for profiles.
 func WithPageSize(pageSize int) Param {
-	if pageSize < minPageSize {
-		pageSize = minPageSize
-	} else if pageSize > maxPageSize {
-		pageSize = maxPageSize
-	}
-	return FieldsUpdaterFunc(func(fields url.Values) {
-		fields.Set("page[size]", strconv.Itoa(pageSize))
-	})
+	return query.ResourcePageSize("profile", pageSize)
 }
 
 // WithFields returns a parameter that sets the specific fields to be retrieved for the profile.
 // It accepts a variable number of field names and constructs the appropriate query parameter.
 func WithFields(fieldName ...string) Param {
-	return FieldsUpdaterFunc(func(fields url.Values) {
-		if names := strings.Join(fieldName, ","); names != "" {
-			fields.Set("fields[profile]", names)
-		}
-	})
+	return query.Fields("profile", fieldName...)
+}
+
+// WithAdditionalFields returns a parameter that requests additional fields of the profile
+// that are not returned by default, e.g. AdditionalFieldSubscriptions or AdditionalFieldPredictiveAnalytics.
+func WithAdditionalFields(fieldName ...string) Param {
+	return query.AdditionalFields("profile", fieldName...)
+}
+
+// WithFilter returns a parameter that retrieves only the profiles matching the filter expression,
+// e.g. filter.Equals("email", "sarah.mason@klaviyo-demo.com"). An empty expression has no effect.
+func WithFilter(f filter.Expr) Param {
+	return query.Filter(f)
+}
+
+// WithCursor returns a parameter that requests the page starting at the cursor, as returned
+// with the previous page by GetProfilesPage. An empty cursor requests the first page.
+func WithCursor(cursor string) Param {
+	return query.Cursor(cursor)
 }
@@ -0,0 +1,55 @@
+package query
+
+import (
+	"net/url"
+)
+
+// Limits are the limits of the auto-paginating helpers, e.g. GetAllProfiles, set with WithMaxPages and WithMaxItems.
+// Zero means no limit.
+type Limits struct {
+	// MaxPages is the maximum number of pages to fetch.
+	MaxPages int
+	// MaxItems is the maximum number of items to fetch.
+	MaxItems int
+}
+
+// limitParam is a parameter that sets a limit of the auto-paginating helpers rather than a query parameter.
+type limitParam func(*Limits)
+
+// Apply does nothing, the limits aren't sent to the API.
+func (limitParam) Apply(url.Values) {}
+
+// WithMaxPages returns a parameter that limits the auto-paginating helpers to n pages, so that e.g. a misconfigured
+// filter can't pull an entire account. The helpers fail when more pages remain after n pages.
+// A non-positive n means no limit.
+func WithMaxPages(n int) Param {
+	return limitParam(func(l *Limits) {
+		l.MaxPages = n
+	})
+}
+
+// WithMaxItems returns a parameter that limits the auto-paginating helpers to n items, so that e.g. a misconfigured
+// filter can't pull an entire account. The helpers return at most n items and fail when more items remain.
+// A non-positive n means no limit.
+func WithMaxItems(n int) Param {
+	return limitParam(func(l *Limits) {
+		l.MaxItems = n
+	})
+}
+
+// LimitsOf returns the limits set by the parameters.
+func LimitsOf(params ...Param) Limits {
+	var l Limits
+	for _, p := range params {
+		if set, ok := p.(limitParam); ok {
+			set(&l)
+		}
+	}
+	if l.MaxPages < 0 {
+		l.MaxPages = 0
+	}
+	if l.MaxItems < 0 {
+		l.MaxItems = 0
+	}
+	return l
+}
@@ -0,0 +1,127 @@
+// Package provides the query parameters shared by the operations retrieving collections of resources,
+// e.g. the page size, the sparse fieldsets, the filter, the sort order and the page cursor.
+
+package query
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/monetha/go-klaviyo/filter"
+)
+
+// Param is an interface that any parameter type should implement.
+// It provides a method to apply the parameter as a query parameter.
+type Param interface {
+	Apply(fields url.Values)
+}
+
+// FieldsUpdaterFunc is a type that wraps a function that updates URL query parameters.
+type FieldsUpdaterFunc func(url.Values)
+
+// Apply calls the underlying function to update the URL query parameters.
+func (f FieldsUpdaterFunc) Apply(fields url.Values) {
+	f(fields)
+}
+
+// PageSize returns a parameter that sets the page size for the request.
+// It ensures that the page size is within the given range.
+func PageSize(pageSize, min, max int) Param {
+	if pageSize < min {
+		pageSize = min
+	} else if pageSize > max {
+		pageSize = max
+	}
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		fields.Set("page[size]", strconv.Itoa(pageSize))
+	})
+}
+
+// PageSizeRange is the range of page sizes accepted by the endpoint listing a resource type.
+type PageSizeRange struct {
+	Min, Max int
+}
+
+// pageSizeRanges lists the page size ranges of the resource types that differ from defaultPageSizeRange.
+var pageSizeRanges = map[string]PageSizeRange{
+	"event": {Min: 1, Max: 200},
+}
+
+// defaultPageSizeRange is the page size range of most of the Klaviyo endpoints, e.g. of profiles.
+var defaultPageSizeRange = PageSizeRange{Min: 1, Max: 100}
+
+// PageSizeRangeOf returns the page size range of the endpoint listing the resource type, e.g. "event".
+func PageSizeRangeOf(resource string) PageSizeRange {
+	if r, ok := pageSizeRanges[resource]; ok {
+		return r
+	}
+	return defaultPageSizeRange
+}
+
+// ResourcePageSize returns a parameter that sets the page size for the request listing the resource type,
+// e.g. "event". It ensures that the page size is within the range accepted for the resource type.
+func ResourcePageSize(resource string, pageSize int) Param {
+	r := PageSizeRangeOf(resource)
+	return PageSize(pageSize, r.Min, r.Max)
+}
+
+// Cursor returns a parameter that requests the page starting at the cursor.
+// An empty cursor requests the first page.
+func Cursor(cursor string) Param {
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		if cursor != "" {
+			fields.Set("page[cursor]", cursor)
+		}
+	})
+}
+
+// Fields returns a parameter that sets the specific fields to be retrieved for the resource type,
+// e.g. "profile". Without field names it has no effect.
+func Fields(resource string, fieldName ...string) Param {
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		if names := strings.Join(fieldName, ","); names != "" {
+			fields.Set("fields["+resource+"]", names)
+		}
+	})
+}
+
+// AdditionalFields returns a parameter that requests additional fields of the resource type
+// that are not returned by default. Without field names it has no effect.
+func AdditionalFields(resource string, fieldName ...string) Param {
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		if names := strings.Join(fieldName, ","); names != "" {
+			fields.Set("additional-fields["+resource+"]", names)
+		}
+	})
+}
+
+// Filter returns a parameter that retrieves only the resources matching the filter expression.
+// An empty expression has no effect.
+func Filter(f filter.Expr) Param {
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		if !f.IsEmpty() {
+			fields.Set("filter", f.String())
+		}
+	})
+}
+
+// Sort returns a parameter that sorts the resources by the field; a "-" prefix sorts in descending order.
+// An empty field has no effect.
+func Sort(field string) Param {
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		if field != "" {
+			fields.Set("sort", field)
+		}
+	})
+}
+
+// Include returns a parameter that includes the related resources in the response.
+// Without resources it has no effect.
+func Include(resource ...string) Param {
+	return FieldsUpdaterFunc(func(fields url.Values) {
+		if names := strings.Join(resource, ","); names != "" {
+			fields.Set("include", names)
+		}
+	})
+}
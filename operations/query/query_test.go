@@ -0,0 +1,85 @@
+package query_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+func TestParams(t *testing.T) {
+	fields := url.Values{}
+	for _, p := range []query.Param{
+		query.PageSize(500, 1, 100),
+		query.Cursor("bmV4dA"),
+		query.Fields("profile", "email", "phone_number"),
+		query.AdditionalFields("profile", "subscriptions"),
+		query.Filter(filter.Equals("email", "sarah.mason@klaviyo-demo.com")),
+		query.Sort("-created"),
+		query.Include("lists"),
+	} {
+		p.Apply(fields)
+	}
+
+	require.Equal(t, url.Values{
+		"page[size]":                 {"100"},
+		"page[cursor]":               {"bmV4dA"},
+		"fields[profile]":            {"email,phone_number"},
+		"additional-fields[profile]": {"subscriptions"},
+		"filter":                     {`equals(email,"sarah.mason@klaviyo-demo.com")`},
+		"sort":                       {"-created"},
+		"include":                    {"lists"},
+	}, fields)
+}
+
+func TestParams_Empty(t *testing.T) {
+	fields := url.Values{}
+	for _, p := range []query.Param{
+		query.PageSize(0, 1, 100),
+		query.Cursor(""),
+		query.Fields("profile"),
+		query.AdditionalFields("profile"),
+		query.Filter(filter.Expr{}),
+		query.Sort(""),
+		query.Include(),
+	} {
+		p.Apply(fields)
+	}
+
+	require.Equal(t, url.Values{"page[size]": {"1"}}, fields)
+}
+
+func TestLimitsOf(t *testing.T) {
+	params := []query.Param{query.WithMaxPages(10), query.Sort("-created"), query.WithMaxItems(-1), query.WithMaxItems(500)}
+
+	require.Equal(t, query.Limits{MaxPages: 10, MaxItems: 500}, query.LimitsOf(params...))
+
+	// the limits aren't sent to the API
+	fields := url.Values{}
+	for _, p := range params {
+		p.Apply(fields)
+	}
+	require.Equal(t, url.Values{"sort": {"-created"}}, fields)
+}
+
+func TestResourcePageSize(t *testing.T) {
+	for _, tc := range []struct {
+		resource string
+		pageSize int
+		want     string
+	}{
+		{resource: "event", pageSize: 150, want: "150"},
+		{resource: "event", pageSize: 500, want: "200"},
+		{resource: "profile", pageSize: 150, want: "100"},
+		{resource: "profile-bulk-import-job", pageSize: 0, want: "1"},
+	} {
+		fields := url.Values{}
+		query.ResourcePageSize(tc.resource, tc.pageSize).Apply(fields)
+		require.Equal(t, tc.want, fields.Get("page[size]"), "%s page size %d", tc.resource, tc.pageSize)
+	}
+
+	require.Equal(t, query.PageSizeRange{Min: 1, Max: 200}, query.PageSizeRangeOf("event"))
+}
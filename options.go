@@ -0,0 +1,418 @@
+package klaviyo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/models/profile/location"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+// Options holds the configuration of the client.
+type Options struct {
+	proxyURL              *url.URL
+	clientCertificates    []tls.Certificate
+	rootCAs               *x509.CertPool
+	eventTimeLocation     *time.Location
+	strictEventTime       bool
+	maxResponseSize       int64
+	nameResolutionTTL     time.Duration
+	clock                 clock.Clock
+	pageRetries           int
+	paginationLimits      query.Limits
+	locationFormat        location.Format
+	eventProperties       map[string]string
+	profileProperties     map[string]interface{}
+	propertyPrefix        string
+	propertyValidation    PropertyValidation
+	latencySLOs           map[Operation]LatencySLO
+	metricsHook           MetricsHook
+	hosts                 map[Family]*url.URL
+	knownMetrics          map[string]struct{}
+	errorBodyLogSize      int
+	payloadSamplers       map[Operation]PayloadSampler
+	strictDecoding        StrictDecoding
+	coalescedOps          map[Operation]struct{}
+	serializers           map[reflect.Type]propertySerializer
+	attributeCombinations AttributeCombinations
+	conflictRetries       int
+	floatPrecision        int
+	allowedOps            map[Operation]struct{}
+	deniedOps             map[Operation]struct{}
+	duplicateIdentifiers  DuplicateIdentifiers
+	oversizedBulkProfiles OversizedBulkProfiles
+	// err is the error of the first invalid option, if any.
+	err error
+}
+
+// Option is an interface that any client configuration option should implement.
+type Option interface {
+	Apply(*Options)
+}
+
+// OptionFunc is a function type that implements the Option interface.
+type OptionFunc func(*Options)
+
+// Apply calls the underlying function to update the client options.
+func (f OptionFunc) Apply(o *Options) {
+	f(o)
+}
+
+// WithProxyURL sets the proxy used by the default transport. Credentials of authenticated proxies
+// can be provided as user info of the URL. The option has no effect on clients created with NewWithClient.
+func WithProxyURL(proxyURL *url.URL) Option {
+	return OptionFunc(func(o *Options) {
+		o.proxyURL = proxyURL
+	})
+}
+
+// WithTLSClientCertificates sets the TLS client certificates (mTLS) presented by the default transport.
+// The option has no effect on clients created with NewWithClient.
+func WithTLSClientCertificates(certificates ...tls.Certificate) Option {
+	return OptionFunc(func(o *Options) {
+		o.clientCertificates = append(o.clientCertificates, certificates...)
+	})
+}
+
+// WithRootCAs sets the root certificate authorities used by the default transport to verify server certificates,
+// e.g. the certificate authority of a TLS-intercepting egress proxy. The option has no effect on clients created with NewWithClient.
+func WithRootCAs(rootCAs *x509.CertPool) Option {
+	return OptionFunc(func(o *Options) {
+		o.rootCAs = rootCAs
+	})
+}
+
+// WithEventTimeLocation sets the location used to interpret event times given as local wall-clock
+// strings without a UTC offset (e.g. "2024-01-30T05:10:00"). Such times are sent with an explicit offset.
+func WithEventTimeLocation(loc *time.Location) Option {
+	return OptionFunc(func(o *Options) {
+		o.eventTimeLocation = loc
+	})
+}
+
+// WithStrictEventTime makes the client reject events whose time has no explicit UTC offset
+// and can't be interpreted in the location set by WithEventTimeLocation.
+func WithStrictEventTime() Option {
+	return OptionFunc(func(o *Options) {
+		o.strictEventTime = true
+	})
+}
+
+// WithMaxResponseSize sets the maximum size of a response body in bytes. Larger responses are discarded
+// without buffering them into memory and the request fails with ErrResponseTooLarge.
+// By default, the size of the response body is not limited.
+func WithMaxResponseSize(maxBytes int64) Option {
+	return OptionFunc(func(o *Options) {
+		o.maxResponseSize = maxBytes
+	})
+}
+
+// WithNameResolutionTTL sets how long the IDs resolved from names (e.g. by ResolveListID) are cached.
+// A zero duration disables caching. The default is 5 minutes.
+func WithNameResolutionTTL(ttl time.Duration) Option {
+	return OptionFunc(func(o *Options) {
+		o.nameResolutionTTL = ttl
+	})
+}
+
+// WithPageRetries sets how many times paginators fetch a page again after it failed because
+// the endpoint was rate limited. The default is 3.
+func WithPageRetries(retries int) Option {
+	return OptionFunc(func(o *Options) {
+		o.pageRetries = retries
+	})
+}
+
+// WithPaginationLimits sets the default limits of every paginated call of the client, set with query.WithMaxPages
+// and query.WithMaxItems, including the helpers that take no parameters, e.g. GetProfileLists, ResolveListID or
+// MembershipPoller.Poll. Limits passed to a call override the defaults. By default, the pagination isn't limited.
+func WithPaginationLimits(params ...query.Param) Option {
+	return OptionFunc(func(o *Options) {
+		o.paginationLimits = query.LimitsOf(params...)
+	})
+}
+
+// WithClock sets the clock used by paginators, caches, pollers and the retries of the HTTP layer to get
+// the current time and to wait, so that tests can advance time artificially. The retries after transport errors,
+// e.g. a reset connection, and context deadlines always use the system time. The default is clock.System.
+func WithClock(c clock.Clock) Option {
+	return OptionFunc(func(o *Options) {
+		o.clock = c
+	})
+}
+
+// WithLocationNormalization makes the client normalize the country and region of profile locations
+// to ISO 3166 codes or English names on write, so segments filtering on country don't miss profiles
+// written with inconsistent values. Writes with unmappable values fail with location.ErrUnknownCountry
+// or location.ErrUnknownRegion.
+func WithLocationNormalization(format location.Format) Option {
+	return OptionFunc(func(o *Options) {
+		o.locationFormat = format
+	})
+}
+
+// WithDefaultEventProperties sets properties merged into every event created through the client,
+// e.g. the service name, environment or app version. Properties of the event override the defaults.
+// The option can be given several times; later properties override earlier ones.
+func WithDefaultEventProperties(properties map[string]string) Option {
+	return OptionFunc(func(o *Options) {
+		if o.eventProperties == nil {
+			o.eventProperties = make(map[string]string, len(properties))
+		}
+		for k, v := range properties {
+			o.eventProperties[k] = v
+		}
+	})
+}
+
+// WithDefaultProfileProperties sets properties merged into every profile created or updated through the client,
+// so each service of a multi-team account can stamp its writes. Properties of the profile override the defaults.
+// The option can be given several times; later properties override earlier ones.
+func WithDefaultProfileProperties(properties map[string]interface{}) Option {
+	return OptionFunc(func(o *Options) {
+		if o.profileProperties == nil {
+			o.profileProperties = make(map[string]interface{}, len(properties))
+		}
+		for k, v := range properties {
+			o.profileProperties[k] = v
+		}
+	})
+}
+
+// WithProfilePropertyPrefix sets the namespace prefix added to the names of all the profile properties
+// written or unset through the client, including the default ones, e.g. "billing_".
+// Names of the properties of the profiles read from Klaviyo are not changed.
+func WithProfilePropertyPrefix(prefix string) Option {
+	return OptionFunc(func(o *Options) {
+		o.propertyPrefix = prefix
+	})
+}
+
+// WithPropertyValidation sets how the client treats profile property values that Klaviyo coerces badly,
+// e.g. time.Time, []byte, NaN or infinite floats and maps nested too deeply, and properties exceeding the documented
+// limits of the property count, key length and string value length. By default, a warning is logged.
+func WithPropertyValidation(mode PropertyValidation) Option {
+	return OptionFunc(func(o *Options) {
+		o.propertyValidation = mode
+	})
+}
+
+// WithLatencySLO declares latency objectives of operations. The client tracks how many requests of the operation
+// exceed the threshold and reports the burn of the SLO to the metrics hook set by WithMetricsHook.
+// The option can be given several times; a later SLO of an operation overrides an earlier one.
+func WithLatencySLO(slos ...LatencySLO) Option {
+	return OptionFunc(func(o *Options) {
+		if o.latencySLOs == nil {
+			o.latencySLOs = make(map[Operation]LatencySLO, len(slos))
+		}
+		for _, slo := range slos {
+			o.latencySLOs[slo.Operation] = slo
+		}
+	})
+}
+
+// WithMetricsHook sets the hook called after every API request with its latency and the state of the SLO of the operation.
+func WithMetricsHook(hook MetricsHook) Option {
+	return OptionFunc(func(o *Options) {
+		o.metricsHook = hook
+	})
+}
+
+// WithHost overrides the base URL of a family of endpoints, e.g. to route the REST API through a regional
+// gateway while keeping the client-side API on its default host. The base URL must be absolute;
+// an invalid host or an unknown family makes New return an *ErrInvalidHost.
+func WithHost(family Family, baseURL *url.URL) Option {
+	return OptionFunc(func(o *Options) {
+		if err := validateHost(family, baseURL); err != nil {
+			if o.err == nil {
+				o.err = err
+			}
+			return
+		}
+		if o.hosts == nil {
+			o.hosts = make(map[Family]*url.URL)
+		}
+		o.hosts[family] = baseURL
+	})
+}
+
+// WithKnownMetrics registers the metric names used by the application. CreateEvent then logs a warning
+// for events of unknown or misspelled metrics, which would otherwise silently create a new metric and split analytics.
+// The option can be given several times; the names are accumulated.
+func WithKnownMetrics(names ...string) Option {
+	return OptionFunc(func(o *Options) {
+		if o.knownMetrics == nil {
+			o.knownMetrics = make(map[string]struct{}, len(names))
+		}
+		for _, name := range names {
+			o.knownMetrics[name] = struct{}{}
+		}
+	})
+}
+
+// WithErrorBodyLogging makes the client log a warning with the endpoint and a snippet of the response body
+// of every failed request, so failures are not opaque. Email addresses and phone numbers in the body are redacted
+// and the snippet is truncated to maxBytes. By default, response bodies are not logged.
+func WithErrorBodyLogging(maxBytes int) Option {
+	return OptionFunc(func(o *Options) {
+		o.errorBodyLogSize = maxBytes
+	})
+}
+
+// WithPayloadLogging enables debug logging of the request and response bodies of the given operations,
+// or of all the operations if none are given, for the requests chosen by the sampler, e.g. SampleEvery(100)
+// or SampleRate(5). Email addresses and phone numbers in the bodies are redacted. The option can be given
+// several times to sample operations differently; the sampler of an operation takes precedence over the one of all operations.
+func WithPayloadLogging(sampler PayloadSampler, ops ...Operation) Option {
+	return OptionFunc(func(o *Options) {
+		if o.payloadSamplers == nil {
+			o.payloadSamplers = make(map[Operation]PayloadSampler)
+		}
+		if len(ops) == 0 {
+			o.payloadSamplers[""] = sampler
+		}
+		for _, op := range ops {
+			o.payloadSamplers[op] = sampler
+		}
+	})
+}
+
+// WithStrictDecoding makes the client detect fields of responses that the models don't capture, so that fields
+// added or renamed by Klaviyo are noticed instead of being silently lost. In the StrictDecodingWarn mode,
+// a warning is logged; in the StrictDecodingError mode, the request fails with ErrUnknownField.
+// Detection decodes every response twice. By default, unknown fields are ignored.
+func WithStrictDecoding(mode StrictDecoding) Option {
+	return OptionFunc(func(o *Options) {
+		o.strictDecoding = mode
+	})
+}
+
+// WithRequestCoalescing makes identical concurrent GET requests of the given operations, or of all the operations
+// if none are given, share a single API request, e.g. concurrent GetProfile calls with the same ID in fan-out services.
+// Requests are identical if they have the same URL and headers. The shared request is bound to the context of
+// the caller that started it; other callers stop waiting when their own context is done.
+func WithRequestCoalescing(ops ...Operation) Option {
+	return OptionFunc(func(o *Options) {
+		if o.coalescedOps == nil {
+			o.coalescedOps = make(map[Operation]struct{})
+		}
+		if len(ops) == 0 {
+			o.coalescedOps[""] = struct{}{}
+		}
+		for _, op := range ops {
+			o.coalescedOps[op] = struct{}{}
+		}
+	})
+}
+
+// WithAttributeCombinations sets how the client treats profile attribute combinations that an endpoint rejects,
+// e.g. an anonymous ID together with other identifiers in a bulk import job. By default, the request fails
+// with ErrUnsupportedAttributes before it is sent.
+func WithAttributeCombinations(mode AttributeCombinations) Option {
+	return OptionFunc(func(o *Options) {
+		o.attributeCombinations = mode
+	})
+}
+
+// WithDuplicateIdentifiers sets how bulk imports treat profile updates sharing an email, phone number,
+// external ID or profile ID, whose result would otherwise depend on the order Klaviyo processes them in.
+// By default, the import fails with ErrDuplicateIdentifier before any job is created.
+func WithDuplicateIdentifiers(mode DuplicateIdentifiers) Option {
+	return OptionFunc(func(o *Options) {
+		o.duplicateIdentifiers = mode
+	})
+}
+
+// WithOversizedBulkProfiles sets how bulk imports treat profiles exceeding the maximum size of a bulk import
+// profile, or, in the split mode, the maximum number of properties of a request. By default, the import fails
+// with ErrBulkProfileTooLarge before any job is created.
+func WithOversizedBulkProfiles(mode OversizedBulkProfiles) Option {
+	return OptionFunc(func(o *Options) {
+		o.oversizedBulkProfiles = mode
+	})
+}
+
+// WithConflictRetries makes upserts, e.g. CreateOrUpdateProfile, retry up to retries times with a growing wait
+// when the API reports a conflict caused by a concurrent write of the same identifiers. By default, conflicts
+// are returned to the caller.
+func WithConflictRetries(retries int) Option {
+	return OptionFunc(func(o *Options) {
+		o.conflictRetries = retries
+	})
+}
+
+// WithFloatPrecision rounds event values and float profile property values to the given number of decimal places.
+// Floats are always sent as plain decimal numbers, never in the exponent notation (e.g. 1000000 instead of 1e+06);
+// by default, they keep the shortest representation that round-trips to the same float. Integers that don't fit
+// a float exactly, e.g. large order IDs, must be passed as integer types or json.Number to be sent without precision loss.
+func WithFloatPrecision(digits int) Option {
+	return OptionFunc(func(o *Options) {
+		o.floatPrecision = digits
+	})
+}
+
+// WithAllowedOperations restricts the client to the given operations, e.g. a service that only creates events
+// and reads profiles, as a defense in depth beyond the scopes of the API key. Other operations fail with
+// ErrOperationNotAllowed before a request is sent. The option can be given several times to allow more operations.
+func WithAllowedOperations(ops ...Operation) Option {
+	return OptionFunc(func(o *Options) {
+		if o.allowedOps == nil {
+			o.allowedOps = make(map[Operation]struct{})
+		}
+		for _, op := range ops {
+			o.allowedOps[op] = struct{}{}
+		}
+	})
+}
+
+// WithDeniedOperations prevents the client from performing the given operations, which fail with
+// ErrOperationNotAllowed before a request is sent. Denied operations take precedence over allowed ones.
+func WithDeniedOperations(ops ...Operation) Option {
+	return OptionFunc(func(o *Options) {
+		if o.deniedOps == nil {
+			o.deniedOps = make(map[Operation]struct{})
+		}
+		for _, op := range ops {
+			o.deniedOps[op] = struct{}{}
+		}
+	})
+}
+
+// newOptions creates the client options by applying the given options.
+func newOptions(opts []Option) *Options {
+	o := &Options{
+		nameResolutionTTL: defaultNameResolutionTTL,
+		clock:             clock.System,
+		pageRetries:       defaultPageRetries,
+		floatPrecision:    -1,
+	}
+	for _, opt := range opts {
+		opt.Apply(o)
+	}
+	return o
+}
+
+// transport returns the transport configured by the options, or nil if the default transport should be used.
+func (o *Options) transport() http.RoundTripper {
+	if o.proxyURL == nil && len(o.clientCertificates) == 0 && o.rootCAs == nil {
+		return nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if o.proxyURL != nil {
+		t.Proxy = http.ProxyURL(o.proxyURL)
+	}
+	if len(o.clientCertificates) > 0 || o.rootCAs != nil {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		t.TLSClientConfig.Certificates = o.clientCertificates
+		t.TLSClientConfig.RootCAs = o.rootCAs
+	}
+	return t
+}
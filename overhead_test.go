@@ -0,0 +1,89 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+const overheadProfileBody = `{"data":{"type":"profile","id":"01GDDKASAP8TKDDA2GRZDSVP4H","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}}`
+
+// newOverheadClient returns a client answering every request with a profile, without network round trips,
+// so that the benchmarks measure the client itself.
+func newOverheadClient(logger *zap.Logger, opts ...klaviyo.Option) *klaviyo.Client {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, overheadProfileBody), nil
+	})}
+	return klaviyo.NewWithClient(validAPIKey, logger, c, opts...)
+}
+
+// BenchmarkClient_OptionalSubsystems compares the cost of a request with the optional subsystems unset,
+// which is the default, to the cost with each of them enabled.
+func BenchmarkClient_OptionalSubsystems(b *testing.B) {
+	debugLogger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(discard{}), zapcore.DebugLevel))
+
+	benchmarks := []struct {
+		name   string
+		logger *zap.Logger
+		opts   []klaviyo.Option
+	}{
+		{name: "default", logger: zap.NewNop()},
+		{name: "metrics hook", logger: zap.NewNop(), opts: []klaviyo.Option{
+			klaviyo.WithMetricsHook(func(klaviyo.RequestMetrics) {}),
+		}},
+		{name: "latency SLO", logger: zap.NewNop(), opts: []klaviyo.Option{
+			klaviyo.WithLatencySLO(klaviyo.LatencySLO{Operation: klaviyo.OperationGetProfile, Objective: 0.99, Threshold: 800 * time.Millisecond}),
+		}},
+		{name: "request coalescing", logger: zap.NewNop(), opts: []klaviyo.Option{
+			klaviyo.WithRequestCoalescing(klaviyo.OperationGetProfile),
+		}},
+		{name: "debug logging", logger: debugLogger},
+		{name: "payload logging", logger: debugLogger, opts: []klaviyo.Option{
+			klaviyo.WithPayloadLogging(klaviyo.SampleEvery(1)),
+		}},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			kc := newOverheadClient(bm.logger, bm.opts...)
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := kc.GetProfile(ctx, "01GDDKASAP8TKDDA2GRZDSVP4H"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_DisabledDebugLogging(t *testing.T) {
+	ctx := context.Background()
+	allocs := func(logger *zap.Logger, opts ...klaviyo.Option) float64 {
+		kc := newOverheadClient(logger, opts...)
+		return testing.AllocsPerRun(100, func() {
+			_, err := kc.GetProfile(ctx, "01GDDKASAP8TKDDA2GRZDSVP4H")
+			require.NoError(t, err)
+		})
+	}
+
+	infoLogger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(discard{}), zapcore.InfoLevel))
+
+	// the request and payload debug logs must not cost anything unless debug messages are logged
+	require.Equal(t, allocs(zap.NewNop()), allocs(infoLogger, klaviyo.WithPayloadLogging(klaviyo.SampleEvery(1))))
+}
+
+// discard is a zapcore.WriteSyncer dropping everything written to it.
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+func (discard) Sync() error                 { return nil }
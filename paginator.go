@@ -0,0 +1,246 @@
+package klaviyo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/operations/getprofiles"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+// PaginationProgress reports the progress of a paginator, e.g. for long-running exports.
+type PaginationProgress struct {
+	// Pages is the number of pages fetched so far.
+	Pages int
+	// Records is the number of records fetched so far.
+	Records int
+	// LastCursor is the cursor of the last page fetched, or an empty string for the first page.
+	LastCursor string
+}
+
+// ErrPaginationInterrupted is returned by the paginators and the auto-paginating helpers when a page can't be fetched
+// or processed. It carries the cursor to resume the pagination from, so that an interrupted export can continue with
+// the failed page, e.g. by passing the cursor with getprofiles.WithCursor, instead of restarting from the first page.
+type ErrPaginationInterrupted struct {
+	// Cursor is the cursor of the failed page, or an empty string if the first page failed.
+	Cursor string
+	// Progress is the progress of the pagination when it was interrupted.
+	Progress PaginationProgress
+	// Err is the error of the failed page.
+	Err error
+}
+
+// Error returns a string representation of the ErrPaginationInterrupted error.
+// It conforms to the error interface.
+func (e *ErrPaginationInterrupted) Error() string {
+	return fmt.Sprintf("klaviyo: pagination interrupted after %d pages: %v", e.Progress.Pages, e.Err)
+}
+
+// Unwrap returns the error of the failed page for Go's errors.Is() and errors.As() functions.
+func (e *ErrPaginationInterrupted) Unwrap() error {
+	return e.Err
+}
+
+// ErrPaginationLimitReached indicates that a paginator stopped at the limits set with query.WithMaxPages or
+// query.WithMaxItems while more records remained. It is returned wrapped in *ErrPaginationInterrupted.
+type ErrPaginationLimitReached struct {
+	Limits query.Limits
+}
+
+// Error returns a string representation of the ErrPaginationLimitReached error.
+// It conforms to the error interface.
+func (e *ErrPaginationLimitReached) Error() string {
+	return fmt.Sprintf("klaviyo: pagination limit reached (max pages: %d, max items: %d)", e.Limits.MaxPages, e.Limits.MaxItems)
+}
+
+// ErrCursorExpired indicates that the API rejected the cursor of a page, e.g. because a stored cursor expired.
+// It is returned wrapped in *ErrPaginationInterrupted. The pagination can't be resumed from the cursor; it has to
+// restart from the first page, e.g. with a filter from a checkpoint like the last seen datetime.
+type ErrCursorExpired struct {
+	Cursor string
+	Cause  *APIError
+}
+
+// Error returns a string representation of the ErrCursorExpired error.
+// It conforms to the error interface.
+func (e *ErrCursorExpired) Error() string {
+	return fmt.Sprintf("klaviyo: page cursor %q was rejected: %v", e.Cursor, e.Cause)
+}
+
+// Unwrap returns the error of the API for Go's errors.Is() and errors.As() functions.
+func (e *ErrCursorExpired) Unwrap() error {
+	return e.Cause
+}
+
+// cursorRejected returns the API error if it reports an invalid or expired page cursor.
+func cursorRejected(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest {
+		return nil, false
+	}
+	if apiErr.Source.Parameter == pageCursorField || strings.Contains(apiErr.Source.Pointer, "cursor") {
+		return apiErr, true
+	}
+	return apiErr, strings.Contains(strings.ToLower(apiErr.Detail), "cursor")
+}
+
+// pageFunc retrieves a single page of records and returns the cursor of the next page, if any.
+type pageFunc[T any] func(ctx context.Context, fields url.Values) ([]T, string, error)
+
+// Paginator iterates over the pages of a list endpoint by following the page cursors.
+//
+// If fetching a page fails because the endpoint is rate limited, the paginator waits for the time requested
+// by the API (Retry-After) and fetches the same page again, up to the number of times set by WithPageRetries.
+// A failed page never advances the cursor, so calling Next again after an error retries the same page.
+// The error of a failed page is an *ErrPaginationInterrupted carrying the cursor of the page. A cursor set with
+// the parameters of the paginator, e.g. getprofiles.WithCursor, is the starting cursor of the pagination.
+// If the API rejects the cursor, e.g. because it expired, the error wraps an *ErrCursorExpired.
+// If some records of a page can't be decoded, the others are returned together with *ErrPartialPage
+// and the cursor is advanced, so the pagination can continue.
+//
+// The paginator fetches at most the number of pages and records set with query.WithMaxPages and query.WithMaxItems.
+// The page reaching the record limit is trimmed to it, and fetching further pages fails with
+// *ErrPaginationLimitReached; resuming from its cursor fetches the trimmed page again.
+type Paginator[T any] struct {
+	getPage  pageFunc[T]
+	clock    clock.Clock
+	fields   url.Values
+	retries  int
+	cursor   string
+	done     bool
+	progress PaginationProgress
+	limits   query.Limits
+}
+
+// newPaginator creates a paginator limited by the default limits of the client, see WithPaginationLimits.
+func newPaginator[T any](c *Client, getPage pageFunc[T], fields url.Values) *Paginator[T] {
+	return &Paginator[T]{
+		getPage: getPage,
+		clock:   c.options.clock,
+		fields:  fields,
+		retries: c.options.pageRetries,
+		cursor:  fields.Get(pageCursorField),
+		limits:  c.options.paginationLimits,
+	}
+}
+
+// limit overrides the limits of the paginator with the limits set by the parameters of the call.
+func (p *Paginator[T]) limit(params []query.Param) {
+	l := query.LimitsOf(params...)
+	if l.MaxPages > 0 {
+		p.limits.MaxPages = l.MaxPages
+	}
+	if l.MaxItems > 0 {
+		p.limits.MaxItems = l.MaxItems
+	}
+}
+
+// allPages fetches all the remaining pages of the paginator and returns their records.
+func allPages[T any](ctx context.Context, p *Paginator[T]) ([]T, error) {
+	var all []T
+	for p.HasNext() {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+	}
+	return all, nil
+}
+
+// NewProfilesPaginator creates a paginator over all the profiles matching the given parameters.
+func (c *Client) NewProfilesPaginator(params ...getprofiles.Param) *Paginator[*profile.ExistingProfile] {
+	fields := url.Values{}
+	for _, p := range params {
+		p.Apply(fields)
+	}
+	p := newPaginator(c, c.getProfilesPage, fields)
+	p.limit(params)
+	return p
+}
+
+// HasNext reports whether there are more pages to fetch.
+func (p *Paginator[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page of records.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+	if p.limitReached() {
+		return nil, p.interrupted(&ErrPaginationLimitReached{Limits: p.limits})
+	}
+
+	fields := cloneValues(p.fields)
+	if p.cursor != "" {
+		fields.Set(pageCursorField, p.cursor)
+	}
+
+	for attempt := 0; ; attempt++ {
+		records, next, err := p.getPage(ctx, fields)
+		if apiErr, ok := cursorRejected(err); ok && p.cursor != "" {
+			return nil, p.interrupted(&ErrCursorExpired{Cursor: p.cursor, Cause: apiErr})
+		}
+		var partialErr *ErrPartialPage
+		if err == nil || errors.As(err, &partialErr) {
+			p.progress.Pages++
+			p.progress.LastCursor = p.cursor
+			if max := p.limits.MaxItems; max > 0 && p.progress.Records+len(records) > max {
+				// the cursor isn't advanced, so that resuming doesn't skip the rest of the trimmed page
+				records = records[:max-p.progress.Records]
+				p.progress.Records = max
+				return records, err
+			}
+			p.progress.Records += len(records)
+			p.cursor = next
+			p.done = next == ""
+			return records, err
+		}
+
+		var rateLimited *ErrRateLimited
+		if attempt >= p.retries || !errors.As(err, &rateLimited) {
+			return nil, p.interrupted(err)
+		}
+		if err := p.clock.Sleep(ctx, rateLimited.RetryAfter); err != nil {
+			return nil, p.interrupted(err)
+		}
+	}
+}
+
+// limitReached reports whether the paginator fetched as many pages or records as its limits allow.
+func (p *Paginator[T]) limitReached() bool {
+	return (p.limits.MaxPages > 0 && p.progress.Pages >= p.limits.MaxPages) ||
+		(p.limits.MaxItems > 0 && p.progress.Records >= p.limits.MaxItems)
+}
+
+// Cursor returns the cursor of the next page to fetch, or an empty string for the first page.
+// Together with the parameters of the paginator, it can be used to resume the pagination later.
+func (p *Paginator[T]) Cursor() string {
+	return p.cursor
+}
+
+// interrupted returns the error of the page that couldn't be fetched or processed.
+func (p *Paginator[T]) interrupted(err error) error {
+	return &ErrPaginationInterrupted{Cursor: p.cursor, Progress: p.progress, Err: err}
+}
+
+// Progress returns the progress of the paginator.
+func (p *Paginator[T]) Progress() PaginationProgress {
+	return p.progress
+}
+
+func cloneValues(v url.Values) url.Values {
+	c := make(url.Values, len(v))
+	for k, vs := range v {
+		c[k] = append([]string(nil), vs...)
+	}
+	return c
+}
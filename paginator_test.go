@@ -0,0 +1,243 @@
+package klaviyo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/operations/getprofiles"
+	"github.com/monetha/go-klaviyo/operations/query"
+)
+
+func TestPaginator_Next(t *testing.T) {
+	t.Run("page fetch is retried after rate limiting without losing the cursor", func(t *testing.T) {
+		var cursors []string
+		rateLimited := 0
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			cursor := req.URL.Query().Get("page[cursor]")
+			if cursor == "page2" && rateLimited < 6 {
+				rateLimited++
+				resp := jsonResponse(http.StatusTooManyRequests, `{"errors":[]}`)
+				resp.Header.Set("Retry-After", "0")
+				return resp, nil
+			}
+			cursors = append(cursors, cursor)
+			if cursor == "" {
+				return jsonResponse(http.StatusOK, `{"data":[{"id":"1"},{"id":"2"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page2"}}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"3"}],"links":{"next":null}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+		paginator := kc.NewProfilesPaginator()
+
+		ctx := context.TODO()
+		var ids []string
+		for paginator.HasNext() {
+			ps, err := paginator.Next(ctx)
+			require.NoError(t, err)
+			for _, p := range ps {
+				ids = append(ids, p.Id)
+			}
+		}
+
+		require.Equal(t, []string{"1", "2", "3"}, ids)
+		require.Equal(t, []string{"", "page2"}, cursors)
+		require.Equal(t, klaviyo.PaginationProgress{Pages: 2, Records: 3, LastCursor: "page2"}, paginator.Progress())
+	})
+}
+
+func TestPaginator_Resume(t *testing.T) {
+	var cursors []string
+	failing := true
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		cursor := req.URL.Query().Get("page[cursor]")
+		cursors = append(cursors, cursor)
+		switch cursor {
+		case "":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"1"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page2"}}`), nil
+		case "page2":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"2"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page3"}}`), nil
+		}
+		if failing {
+			return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"e1","status":400,"code":"invalid","title":"Invalid input.","detail":"error"}]}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"id":"3"}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	var ids []string
+	collect := func(ps []*profile.ExistingProfile) error {
+		for _, p := range ps {
+			ids = append(ids, p.Id)
+		}
+		return nil
+	}
+
+	err := kc.ForEachProfilePage(ctx, collect)
+	var interrupted *klaviyo.ErrPaginationInterrupted
+	require.True(t, errors.As(err, &interrupted))
+	require.Equal(t, "page3", interrupted.Cursor)
+	require.Equal(t, 2, interrupted.Progress.Pages)
+	var apiErr *klaviyo.APIError
+	require.True(t, errors.As(err, &apiErr))
+
+	// the export resumes with the failed page
+	failing = false
+	cursors = nil
+	paginator := kc.NewProfilesPaginator(getprofiles.WithCursor(interrupted.Cursor))
+	require.Equal(t, "page3", paginator.Cursor())
+	ps, err := paginator.Next(ctx)
+	require.NoError(t, err)
+	require.NoError(t, collect(ps))
+	require.False(t, paginator.HasNext())
+
+	require.Equal(t, []string{"1", "2", "3"}, ids)
+	require.Equal(t, []string{"page3"}, cursors)
+	require.Equal(t, klaviyo.PaginationProgress{Pages: 1, Records: 1, LastCursor: "page3"}, paginator.Progress())
+}
+
+func TestClient_ForEachProfilePage_CallbackCursor(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("page[cursor]") == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"1"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page2"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"id":"2"}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	errWarehouse := errors.New("warehouse unavailable")
+	err := kc.ForEachProfilePage(context.TODO(), func(ps []*profile.ExistingProfile) error {
+		if ps[0].Id == "2" {
+			return errWarehouse
+		}
+		return nil
+	})
+
+	// the page the callback failed on is processed again when resuming
+	var interrupted *klaviyo.ErrPaginationInterrupted
+	require.True(t, errors.As(err, &interrupted))
+	require.ErrorIs(t, err, errWarehouse)
+	require.Equal(t, "page2", interrupted.Cursor)
+}
+
+func TestPaginator_Limits(t *testing.T) {
+	var queries []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		queries = append(queries, req.URL.RawQuery)
+		switch req.URL.Query().Get("page[cursor]") {
+		case "":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"1"},{"id":"2"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page2"}}`), nil
+		case "page2":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"3"},{"id":"4"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page3"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"id":"5"}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	t.Run("max items", func(t *testing.T) {
+		queries = nil
+		var (
+			ids  []string
+			errs []error
+		)
+		kc.GetAllProfiles(ctx, query.WithMaxItems(3))(func(p *profile.ExistingProfile, err error) bool {
+			if err != nil {
+				errs = append(errs, err)
+				return true
+			}
+			ids = append(ids, p.Id)
+			return true
+		})
+
+		require.Equal(t, []string{"1", "2", "3"}, ids)
+		require.Len(t, errs, 1)
+		var limitErr *klaviyo.ErrPaginationLimitReached
+		require.True(t, errors.As(errs[0], &limitErr))
+		require.Equal(t, 3, limitErr.Limits.MaxItems)
+
+		// resuming fetches the trimmed page again
+		var interrupted *klaviyo.ErrPaginationInterrupted
+		require.True(t, errors.As(errs[0], &interrupted))
+		require.Equal(t, "page2", interrupted.Cursor)
+		require.Equal(t, []string{"", "page%5Bcursor%5D=page2"}, queries)
+	})
+
+	t.Run("max pages", func(t *testing.T) {
+		var pages int
+		err := kc.ForEachProfilePage(ctx, func(ps []*profile.ExistingProfile) error {
+			pages++
+			return nil
+		}, query.WithMaxPages(2))
+
+		var limitErr *klaviyo.ErrPaginationLimitReached
+		require.True(t, errors.As(err, &limitErr))
+		require.Equal(t, 2, pages)
+		require.False(t, klaviyo.IsRetryable(err))
+	})
+
+	t.Run("limits that aren't reached", func(t *testing.T) {
+		var ids []string
+		err := kc.ForEachProfilePage(ctx, func(ps []*profile.ExistingProfile) error {
+			for _, p := range ps {
+				ids = append(ids, p.Id)
+			}
+			return nil
+		}, query.WithMaxPages(3), query.WithMaxItems(5))
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+	})
+
+	t.Run("default limits of the client", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithPaginationLimits(query.WithMaxPages(1)))
+
+		// the profile lists are paginated without parameters
+		_, err := kc.GetProfileLists(ctx, "01GDDKASAP8TKDDA2GRZDSVP4H")
+		var limitErr *klaviyo.ErrPaginationLimitReached
+		require.True(t, errors.As(err, &limitErr))
+		require.Equal(t, 1, limitErr.Limits.MaxPages)
+
+		// limits passed to a call override the defaults
+		var pages int
+		err = kc.ForEachProfilePage(ctx, func(ps []*profile.ExistingProfile) error {
+			pages++
+			return nil
+		}, query.WithMaxPages(3))
+		require.NoError(t, err)
+		require.Equal(t, 3, pages)
+	})
+}
+
+func TestPaginator_CursorExpired(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("page[cursor]") != "" {
+			return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"4e8d2a1b","status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid page cursor.","source":{"parameter":"page[cursor]"}}]}`), nil
+		}
+		return jsonResponse(http.StatusBadRequest, `{"errors":[{"status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid filter.","source":{"parameter":"filter"}}]}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	_, err := kc.NewProfilesPaginator(getprofiles.WithCursor("c3RhbGU")).Next(context.TODO())
+	var expired *klaviyo.ErrCursorExpired
+	require.ErrorAs(t, err, &expired)
+	require.Equal(t, "c3RhbGU", expired.Cursor)
+	require.Equal(t, "page[cursor]", expired.Cause.Source.Parameter)
+	var interrupted *klaviyo.ErrPaginationInterrupted
+	require.ErrorAs(t, err, &interrupted)
+
+	_, err = kc.NewProfilesPaginator().Next(context.TODO())
+	require.False(t, errors.As(err, &expired), "the first page has no cursor to expire")
+}
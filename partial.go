@@ -0,0 +1,79 @@
+package klaviyo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ensure that PartialError implements the error interface.
+var _ error = (*PartialError)(nil)
+
+// StepResult holds the result of a single step (API call) of a composite operation.
+type StepResult struct {
+	// Name is the name of the step, e.g. "get campaign".
+	Name string
+	// Done reports whether the step completed successfully.
+	Done bool
+	// Err is the error of the step, if it failed.
+	Err error
+	// Retryable reports whether the failed step can be safely retried without repeating its side effects.
+	Retryable bool
+	// Start and End delimit the inputs handled by a step submitting a chunk of the inputs of the operation,
+	// e.g. the emails of SuppressProfiles, as inputs[Start:End]. They are zero for other steps.
+	Start, End int
+}
+
+// PartialError indicates that a composite operation consisting of several API calls failed after
+// some of its steps were already performed. It carries the result of every attempted step.
+type PartialError struct {
+	// Operation is the name of the composite operation, e.g. "clone campaign".
+	Operation string
+	// Steps holds the results of the attempted steps in the order they were performed.
+	Steps []StepResult
+}
+
+// Error returns a human-readable representation of the PartialError.
+func (e *PartialError) Error() string {
+	var failed []string
+	for _, s := range e.Failed() {
+		failed = append(failed, fmt.Sprintf("%s: %v", s.Name, s.Err))
+	}
+	return fmt.Sprintf("klaviyo: %s partially failed: %s", e.Operation, strings.Join(failed, "; "))
+}
+
+// Unwrap returns the error of the first failed step for Go's errors.Is() and errors.As() functions.
+func (e *PartialError) Unwrap() error {
+	if failed := e.Failed(); len(failed) > 0 {
+		return failed[0].Err
+	}
+	return nil
+}
+
+// Failed returns the results of the failed steps.
+func (e *PartialError) Failed() []StepResult {
+	var failed []StepResult
+	for _, s := range e.Steps {
+		if s.Err != nil {
+			failed = append(failed, s)
+		}
+	}
+	return failed
+}
+
+// Retryable returns the results of the failed steps that can be safely retried.
+func (e *PartialError) Retryable() []StepResult {
+	var retryable []StepResult
+	for _, s := range e.Failed() {
+		if s.Retryable {
+			retryable = append(retryable, s)
+		}
+	}
+	return retryable
+}
+
+// isWriteRetryable reports whether a failed write request can be safely retried, i.e. the error
+// proves that the request was rejected before it took effect.
+func isWriteRetryable(err error) bool {
+	return errors.Is(err, ErrTooManyRequests)
+}
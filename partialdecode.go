@@ -0,0 +1,106 @@
+package klaviyo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DecodeFailure describes a record of a list response that couldn't be decoded, e.g. because of bad data in one profile.
+type DecodeFailure struct {
+	// Index is the index of the record in its page.
+	Index int
+	// ID is the ID of the record, if it could be read.
+	ID  string
+	Err error
+}
+
+// ErrPartialPage indicates that some records of a page couldn't be decoded. It is returned together with
+// the records that could, so a single bad record doesn't fail the whole page. Methods walking several pages
+// return it after the walk, with the failures of all the pages.
+type ErrPartialPage struct {
+	Operation Operation
+	Failures  []DecodeFailure
+}
+
+// Error returns a string representation of the ErrPartialPage error.
+// It conforms to the error interface.
+func (e *ErrPartialPage) Error() string {
+	f := e.Failures[0]
+	return fmt.Sprintf("klaviyo: %d records of the response of %s couldn't be decoded, first record %d (ID %q): %v",
+		len(e.Failures), e.Operation, f.Index, f.ID, f.Err)
+}
+
+// partialPage is the result of a list request whose records are decoded one by one.
+type partialPage[T any] struct {
+	Data  records[T] `json:"data"`
+	Links links      `json:"links"`
+}
+
+// strictResult returns the value the response is checked against by the strict decoding,
+// which the records decoded one by one would escape.
+func (p *partialPage[T]) strictResult() interface{} {
+	return &struct {
+		Data []T `json:"data"`
+	}{}
+}
+
+// records decodes the records of a list response one by one, keeping the records that can be decoded
+// and the failures of those that can't.
+type records[T any] struct {
+	items    []T
+	failures []DecodeFailure
+}
+
+// UnmarshalJSON decodes the array of records. It only fails if the data isn't an array.
+func (r *records[T]) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.items, r.failures = make([]T, 0, len(raw)), nil
+	for i, m := range raw {
+		var item T
+		if err := json.Unmarshal(m, &item); err != nil {
+			var id struct {
+				ID json.RawMessage `json:"id"`
+			}
+			_ = json.Unmarshal(m, &id)
+			r.failures = append(r.failures, DecodeFailure{Index: i, ID: jsonText(id.ID), Err: err})
+			continue
+		}
+		r.items = append(r.items, item)
+	}
+	return nil
+}
+
+// err returns *ErrPartialPage if some records couldn't be decoded.
+func (r *records[T]) err(op Operation) error {
+	if len(r.failures) == 0 {
+		return nil
+	}
+	return &ErrPartialPage{Operation: op, Failures: r.failures}
+}
+
+// decodeFailures collects the decode failures of the pages walked by a method.
+type decodeFailures []DecodeFailure
+
+// tolerate adds the failures of err if it's an *ErrPartialPage, so the walk can continue, and returns nil.
+// Any other error is returned as it is.
+func (f *decodeFailures) tolerate(err error) error {
+	var partialErr *ErrPartialPage
+	if !errors.As(err, &partialErr) {
+		return err
+	}
+	*f = append(*f, partialErr.Failures...)
+	return nil
+}
+
+// err returns *ErrPartialPage with the collected failures, if any.
+func (f decodeFailures) err(op Operation) error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &ErrPartialPage{Operation: op, Failures: f}
+}
@@ -0,0 +1,81 @@
+package klaviyo
+
+import (
+	"sync"
+	"time"
+)
+
+// maxPayloadLogSize limits the size of the request and response bodies logged by payload logging.
+const maxPayloadLogSize = 16 << 10
+
+// PayloadSampler decides whether the payloads of a request are logged.
+// Implementations must be safe for concurrent use.
+type PayloadSampler interface {
+	Sample(now time.Time) bool
+}
+
+// SampleEvery returns a sampler that samples every n-th request. A sampler with n <= 1 samples all requests.
+func SampleEvery(n int) PayloadSampler {
+	return &everySampler{n: uint64(n)}
+}
+
+type everySampler struct {
+	mu    sync.Mutex
+	n     uint64
+	count uint64
+}
+
+func (s *everySampler) Sample(time.Time) bool {
+	if s.n <= 1 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	return s.count%s.n == 1
+}
+
+// SampleRate returns a sampler that samples at most perSecond requests in every second.
+func SampleRate(perSecond int) PayloadSampler {
+	return &rateSampler{limit: perSecond}
+}
+
+type rateSampler struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Time
+	count  int
+}
+
+func (s *rateSampler) Sample(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if window := now.Truncate(time.Second); !window.Equal(s.window) {
+		s.window, s.count = window, 0
+	}
+	if s.count >= s.limit {
+		return false
+	}
+	s.count++
+	return true
+}
+
+// logPayload logs the request and response bodies at debug level if payload logging is enabled for the operation,
+// debug messages are logged and the request is sampled. Email addresses and phone numbers in the bodies are redacted.
+func (c *Client) logPayload(op Operation, endpoint string, statusCode int, requestBody, responseBody []byte) {
+	sampler, ok := c.options.payloadSamplers[op]
+	if !ok {
+		sampler, ok = c.options.payloadSamplers[""]
+	}
+	if !ok || !c.logger.DebugEnabled() || !sampler.Sample(c.options.clock.Now()) {
+		return
+	}
+
+	c.logger.Debug("request payload",
+		LogFieldOperation, op,
+		LogFieldEndpoint, endpoint,
+		LogFieldStatus, statusCode,
+		"request_body", redactBody(requestBody, maxPayloadLogSize),
+		"response_body", redactBody(responseBody, maxPayloadLogSize),
+	)
+}
@@ -0,0 +1,64 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+func TestWithPayloadLogging(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}]}`), nil
+	})}
+
+	t.Run("every n-th request is logged", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithPayloadLogging(klaviyo.SampleEvery(2)))
+
+		for i := 0; i < 4; i++ {
+			_, err := kc.GetProfiles(context.TODO())
+			require.NoError(t, err)
+		}
+
+		entries := logs.FilterMessage("request payload").All()
+		require.Len(t, entries, 2)
+		fields := entries[0].ContextMap()
+		require.Equal(t, klaviyo.OperationGetProfiles, fields["operation"])
+		require.Contains(t, fields["response_body"], `"email":"[redacted-email]"`)
+	})
+
+	t.Run("only the given operations are logged", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithPayloadLogging(klaviyo.SampleEvery(1), klaviyo.OperationGetProfile))
+
+		_, err := kc.GetProfiles(context.TODO())
+		require.NoError(t, err)
+
+		require.Zero(t, logs.FilterMessage("request payload").Len())
+	})
+
+	t.Run("at most the given number of requests per second is logged", func(t *testing.T) {
+		clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+		core, logs := observer.New(zapcore.DebugLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithClock(clk), klaviyo.WithPayloadLogging(klaviyo.SampleRate(1)))
+
+		for i := 0; i < 3; i++ {
+			_, err := kc.GetProfiles(context.TODO())
+			require.NoError(t, err)
+		}
+		clk.Advance(time.Second)
+		_, err := kc.GetProfiles(context.TODO())
+		require.NoError(t, err)
+
+		require.Equal(t, 2, logs.FilterMessage("request payload").Len())
+	})
+}
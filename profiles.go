@@ -0,0 +1,589 @@
+package klaviyo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/models/list"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/updater"
+	"github.com/monetha/go-klaviyo/models/segment"
+	"github.com/monetha/go-klaviyo/operations/getprofiles"
+)
+
+const (
+	pageCursorField = "page[cursor]"
+
+	// maxProfileFilterIDs is the maximum number of values of an any() filter of the profiles endpoint.
+	maxProfileFilterIDs = 100
+)
+
+// links holds the pagination links of a list response.
+type links struct {
+	Self string  `json:"self"`
+	Next *string `json:"next"`
+	Prev *string `json:"prev"`
+}
+
+// nextCursor returns the cursor of the next page, or an empty string if there is no next page.
+func (l links) nextCursor() string {
+	if l.Next == nil || *l.Next == "" {
+		return ""
+	}
+	u, err := url.Parse(*l.Next)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get(pageCursorField)
+}
+
+// prepareNewProfile applies the client-level profile options (location normalization, default properties,
+// property prefix and serializers) to the profile to be created. The given profile is not modified.
+func (c *Client) prepareNewProfile(p *profile.NewProfile) (*profile.NewProfile, error) {
+	p, err := c.normalizeNewProfile(p)
+	if err != nil || p == nil {
+		return p, err
+	}
+
+	if properties, ok := c.stampProperties(p.Attributes.Properties); ok {
+		np := *p
+		np.Attributes.Properties = properties
+		p = &np
+	}
+
+	if p.Attributes.Properties != nil {
+		properties, err := c.serializeProperties(p.Attributes.Properties)
+		if err != nil {
+			return nil, err
+		}
+		np := *p
+		np.Attributes.Properties = properties
+		p = &np
+	}
+
+	if err := c.validateProperties(OperationCreateProfile, p.Attributes.Properties); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ErrPropertyConflict indicates that a profile update performs several operations (set, unset, append, unappend)
+// on the same property, which Klaviyo would resolve in an unspecified order.
+type ErrPropertyConflict struct {
+	Property   string
+	Operations []string
+}
+
+// Error returns a string representation of the ErrPropertyConflict error.
+// It conforms to the error interface.
+func (e *ErrPropertyConflict) Error() string {
+	return fmt.Sprintf("klaviyo: conflicting operations on property %q: %s", e.Property, strings.Join(e.Operations, ", "))
+}
+
+// checkPropertyConflicts returns ErrPropertyConflict for the first property, in alphabetical order,
+// that is the target of more than one operation of the profile update.
+func checkPropertyConflicts(data *updater.ProfileData) error {
+	set, _ := data.Attributes["properties"].(map[string]interface{})
+	kinds := 0
+	for _, n := range []int{len(set), len(data.PropertiesToRemove), len(data.PropertiesToAppend), len(data.PropertiesToUnappend)} {
+		if n > 0 {
+			kinds++
+		}
+	}
+	if kinds < 2 && len(data.PropertiesToRemove) < 2 {
+		// a conflict needs two operations on a property, and only unset lists can repeat a property
+		return nil
+	}
+
+	ops := make(map[string][]string)
+	for name := range set {
+		ops[name] = append(ops[name], "set")
+	}
+	for _, name := range data.PropertiesToRemove {
+		ops[name] = append(ops[name], "unset")
+	}
+	for name := range data.PropertiesToAppend {
+		ops[name] = append(ops[name], "append")
+	}
+	for name := range data.PropertiesToUnappend {
+		ops[name] = append(ops[name], "unappend")
+	}
+
+	names := make([]string, 0, len(ops))
+	for name, o := range ops {
+		if len(o) > 1 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return &ErrPropertyConflict{Property: names[0], Operations: ops[names[0]]}
+}
+
+// profileIdentifiers lists the attributes identifying a profile without its ID, e.g. in a bulk import job
+// or a create-or-update of the profile.
+var profileIdentifiers = []string{"email", "phone_number", "external_id"}
+
+// hasProfileIdentifier reports whether the attributes identify the profile without its ID.
+func hasProfileIdentifier(attributes map[string]interface{}) bool {
+	for _, name := range profileIdentifiers {
+		if v, ok := attributes[name]; ok && v != nil && v != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// patchPropertiesMeta returns the meta of the profile update holding the unset, append and unappend
+// operations on properties, or nil if there are none.
+func patchPropertiesMeta(data *updater.ProfileData) map[string]interface{} {
+	if len(data.PropertiesToRemove) == 0 && len(data.PropertiesToAppend) == 0 && len(data.PropertiesToUnappend) == 0 {
+		return nil
+	}
+
+	patch := make(map[string]interface{}, 3)
+	if len(data.PropertiesToRemove) > 0 {
+		patch["unset"] = data.PropertiesToRemove
+	}
+	if len(data.PropertiesToAppend) > 0 {
+		patch["append"] = data.PropertiesToAppend
+	}
+	if len(data.PropertiesToUnappend) > 0 {
+		patch["unappend"] = data.PropertiesToUnappend
+	}
+	return map[string]interface{}{"patch_properties": patch}
+}
+
+// prepareProfileData checks the profile update for conflicting property operations and applies
+// the client-level profile options (location normalization, default properties, property prefix and serializers) to it.
+func (c *Client) prepareProfileData(op Operation, data *updater.ProfileData) error {
+	if err := c.prepareProfileAttributes(op, data); err != nil {
+		return err
+	}
+
+	properties, _ := data.Attributes["properties"].(map[string]interface{})
+	return c.validateProperties(op, properties)
+}
+
+// prepareProfileAttributes checks, normalizes and serializes the profile data like prepareProfileData,
+// without validating the property values.
+func (c *Client) prepareProfileAttributes(op Operation, data *updater.ProfileData) error {
+	if err := checkPropertyConflicts(data); err != nil {
+		return err
+	}
+
+	if err := c.checkAttributeCombinations(op, data); err != nil {
+		return err
+	}
+
+	if err := c.normalizeProfileData(data); err != nil {
+		return err
+	}
+
+	current, _ := data.Attributes["properties"].(map[string]interface{})
+	if properties, ok := c.stampProperties(current); ok {
+		data.Attributes["properties"] = properties
+	}
+
+	if prefix := c.options.propertyPrefix; prefix != "" {
+		for i, name := range data.PropertiesToRemove {
+			data.PropertiesToRemove[i] = prefix + name
+		}
+		data.PropertiesToAppend = prefixKeys(prefix, data.PropertiesToAppend)
+		data.PropertiesToUnappend = prefixKeys(prefix, data.PropertiesToUnappend)
+	}
+
+	if properties, ok := data.Attributes["properties"].(map[string]interface{}); ok {
+		serialized, err := c.serializeProperties(properties)
+		if err != nil {
+			return err
+		}
+		data.Attributes["properties"] = serialized
+	}
+	for _, m := range []*map[string]interface{}{&data.PropertiesToAppend, &data.PropertiesToUnappend} {
+		serialized, err := c.serializeProperties(*m)
+		if err != nil {
+			return err
+		}
+		*m = serialized
+	}
+	return nil
+}
+
+// stampProperties returns the properties merged with the default profile properties and with the property
+// prefix added to their names. It returns false if there is nothing to change.
+func (c *Client) stampProperties(properties map[string]interface{}) (map[string]interface{}, bool) {
+	defaults, prefix := c.options.profileProperties, c.options.propertyPrefix
+	if len(defaults) == 0 && (prefix == "" || len(properties) == 0) {
+		return nil, false
+	}
+
+	stamped := make(map[string]interface{}, len(defaults)+len(properties))
+	for k, v := range defaults {
+		stamped[prefix+k] = v
+	}
+	for k, v := range properties {
+		stamped[prefix+k] = v
+	}
+	return stamped, true
+}
+
+func prefixKeys(prefix string, m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	prefixed := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		prefixed[prefix+k] = v
+	}
+	return prefixed
+}
+
+// GetProfilesPage retrieves a single page of profiles and returns the cursor of the next page, or an empty string
+// if it's the last page. The next page is retrieved by passing the cursor with getprofiles.WithCursor.
+// If some profiles of the page can't be decoded, the others are returned together with *ErrPartialPage.
+func (c *Client) GetProfilesPage(ctx context.Context, params ...getprofiles.Param) (_ []*profile.ExistingProfile, nextCursor string, _ error) {
+	fields := url.Values{}
+	for _, p := range params {
+		p.Apply(fields)
+	}
+	return c.getProfilesPage(ctx, fields)
+}
+
+// GetAllProfiles returns a sequence of all the profiles matching the given parameters, following the page cursors
+// as the sequence is consumed, so that any number of profiles can be streamed without buffering them in memory.
+// If fetching a page fails, the error is yielded with a nil profile and the sequence ends. The error is
+// an *ErrPaginationInterrupted carrying the cursor to resume from, passed with getprofiles.WithCursor.
+// If some profiles of a page can't be decoded, the others are yielded, followed by an *ErrPartialPage
+// with a nil profile, and the sequence continues with the next page.
+//
+// The sequence has the signature of iter.Seq2[*profile.ExistingProfile, error], so with Go 1.23 or later
+// it can be ranged over:
+//
+//	for p, err := range c.GetAllProfiles(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// With older Go versions, it's called with the yield function, which returns false to stop the iteration.
+func (c *Client) GetAllProfiles(ctx context.Context, params ...getprofiles.Param) func(yield func(*profile.ExistingProfile, error) bool) {
+	return func(yield func(*profile.ExistingProfile, error) bool) {
+		paginator := c.NewProfilesPaginator(params...)
+		for paginator.HasNext() {
+			ps, err := paginator.Next(ctx)
+			var partialErr *ErrPartialPage
+			if err != nil && !errors.As(err, &partialErr) {
+				yield(nil, err)
+				return
+			}
+			for _, p := range ps {
+				if !yield(p, nil) {
+					return
+				}
+			}
+			if partialErr != nil && !yield(nil, partialErr) {
+				return
+			}
+		}
+	}
+}
+
+// ForEachProfilePage walks the pages of the profiles matching the given parameters and calls fn with every page,
+// e.g. to load the profiles into a data warehouse page by page. It stops and returns the error if fetching a page
+// or fn fails, or if the context is canceled between the pages.
+//
+// The walk starts at the cursor passed with getprofiles.WithCursor, if any. The returned error is
+// an *ErrPaginationInterrupted carrying the cursor of the page that failed, to resume the walk from.
+// Profiles that can't be decoded are left out of the pages passed to fn; if there are any,
+// an *ErrPartialPage is returned once all the pages are walked.
+func (c *Client) ForEachProfilePage(ctx context.Context, fn func([]*profile.ExistingProfile) error, params ...getprofiles.Param) error {
+	var failures decodeFailures
+	paginator := c.NewProfilesPaginator(params...)
+	for paginator.HasNext() {
+		if err := ctx.Err(); err != nil {
+			return paginator.interrupted(err)
+		}
+		ps, err := paginator.Next(ctx)
+		if err := failures.tolerate(err); err != nil {
+			return err
+		}
+		if err := fn(ps); err != nil {
+			progress := paginator.Progress()
+			return &ErrPaginationInterrupted{Cursor: progress.LastCursor, Progress: progress, Err: err}
+		}
+	}
+	return failures.err(OperationGetProfiles)
+}
+
+// getProfilesPage retrieves a single page of profiles and returns the cursor of the next page, if any.
+// If some profiles of the page can't be decoded, the others are returned together with *ErrPartialPage.
+func (c *Client) getProfilesPage(ctx context.Context, fields url.Values) ([]*profile.ExistingProfile, string, error) {
+	var result partialPage[*profile.ExistingProfile]
+	if err := c.doReq(ctx, OperationGetProfiles, http.MethodGet, profilesPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data.items, result.Links.nextCursor(), result.Data.err(OperationGetProfiles)
+}
+
+// FindProfilesByProperty returns the profiles having the custom property with the given name set to the given value.
+// The name can be given with the "properties." prefix used by the API, e.g. "properties.plan", and can address
+// a nested property by joining the keys with dots, e.g. "address.city"; a property whose name contains the dots
+// takes precedence over the nested one.
+//
+// The Klaviyo API doesn't allow filtering profiles by custom properties, so this method scans ALL profiles of the
+// account page by page and matches them client-side. Every page costs an API call, which makes the method slow and
+// expensive for large accounts; use it in support tools rather than in request paths. The params can be used to
+// restrict the scanned profiles, but if the returned fields are restricted, they must include "properties".
+// If some profiles can't be decoded, the matching profiles are returned together with *ErrPartialPage.
+func (c *Client) FindProfilesByProperty(ctx context.Context, name string, value interface{}, params ...getprofiles.Param) ([]*profile.ExistingProfile, error) {
+	want, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	params = append([]getprofiles.Param{getprofiles.WithPageSize(maxProfilesPageSize)}, params...)
+	paginator := c.NewProfilesPaginator(params...)
+
+	var (
+		found    []*profile.ExistingProfile
+		failures decodeFailures
+	)
+	for paginator.HasNext() {
+		ps, err := paginator.Next(ctx)
+		if err := failures.tolerate(err); err != nil {
+			return nil, err
+		}
+
+		for _, p := range ps {
+			v, ok := lookupProperty(p.Attributes.Properties, name)
+			if !ok {
+				continue
+			}
+			if got, err := json.Marshal(v); err == nil && bytes.Equal(got, want) {
+				found = append(found, p)
+			}
+		}
+	}
+
+	return found, failures.err(OperationGetProfiles)
+}
+
+// lookupProperty returns the value of the property with the given name, with or without the "properties." prefix,
+// following the dots of the name into the nested properties unless a property has the name as is.
+func lookupProperty(properties map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := properties[name]; ok {
+		return v, true
+	}
+	return lookupNestedProperty(properties, strings.TrimPrefix(name, "properties."))
+}
+
+// lookupNestedProperty returns the value of the property with the given name, trying every key of the nested
+// properties the dots of the name can split it into.
+func lookupNestedProperty(properties map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := properties[name]; ok {
+		return v, true
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] != '.' {
+			continue
+		}
+		if nested, ok := properties[name[:i]].(map[string]interface{}); ok {
+			if v, ok := lookupNestedProperty(nested, name[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// GetProfilesByIDs retrieves the profiles with the given IDs with an any(id,…) filter, instead of a GetProfile call
+// per ID. Up to 100 IDs are requested at once; more IDs are requested in chunks of 100. Profiles are returned
+// in the order of the IDs; IDs of profiles that don't exist are skipped and duplicate IDs are returned once.
+// If some profiles can't be decoded, the others are returned together with *ErrPartialPage.
+func (c *Client) GetProfilesByIDs(ctx context.Context, ids []string) ([]*profile.ExistingProfile, error) {
+	ids = uniqueStrings(ids)
+
+	found := make(map[string]*profile.ExistingProfile, len(ids))
+	var failures decodeFailures
+	for _, chunk := range chunkStrings(ids, maxProfileFilterIDs) {
+		if len(chunk) == 0 {
+			continue
+		}
+
+		fields := url.Values{}
+		fields.Set("filter", filter.AnyString("id", chunk...).String())
+		fields.Set("page[size]", strconv.Itoa(maxProfilesPageSize))
+
+		paginator := newPaginator(c, c.getProfilesPage, fields)
+		for paginator.HasNext() {
+			ps, err := paginator.Next(ctx)
+			if err := failures.tolerate(err); err != nil {
+				return nil, err
+			}
+			for _, p := range ps {
+				found[p.Id] = p
+			}
+		}
+	}
+
+	profiles := make([]*profile.ExistingProfile, 0, len(found))
+	for _, id := range ids {
+		if p, ok := found[id]; ok {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles, failures.err(OperationGetProfiles)
+}
+
+// ErrAmbiguousProfile indicates that several profiles have the identifier that was expected to identify a single profile.
+// It holds the IDs of the first two matching profiles.
+type ErrAmbiguousProfile struct {
+	// Identifier is the identifier the profiles were looked up by, e.g. "email".
+	Identifier string
+	Value      string
+	IDs        []string
+}
+
+// Error returns a string representation of the ErrAmbiguousProfile error.
+// It conforms to the error interface.
+func (e *ErrAmbiguousProfile) Error() string {
+	return fmt.Sprintf("klaviyo: several profiles with %s %q: %s", e.Identifier, e.Value, strings.Join(e.IDs, ", "))
+}
+
+// GetProfileByEmail retrieves the profile with the given email. If there is no such profile, it returns
+// ErrProfileDoesNotExist; if there are several, it returns an *ErrAmbiguousProfile.
+func (c *Client) GetProfileByEmail(ctx context.Context, email string) (*profile.ExistingProfile, error) {
+	return c.getProfileBy(ctx, "email", email)
+}
+
+// GetProfileByExternalID retrieves the profile with the given external ID. If there is no such profile, it returns
+// ErrProfileDoesNotExist; if there are several, it returns an *ErrAmbiguousProfile.
+func (c *Client) GetProfileByExternalID(ctx context.Context, externalID string) (*profile.ExistingProfile, error) {
+	return c.getProfileBy(ctx, "external_id", externalID)
+}
+
+// getProfileBy retrieves the single profile whose identifier equals the value.
+// Two profiles are requested, which is enough to tell whether the identifier is ambiguous.
+func (c *Client) getProfileBy(ctx context.Context, identifier, value string) (*profile.ExistingProfile, error) {
+	ps, err := c.GetProfiles(ctx,
+		getprofiles.WithFilter(filter.Equals(identifier, value)),
+		getprofiles.WithPageSize(2),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(ps) {
+	case 0:
+		return nil, ErrProfileDoesNotExist
+	case 1:
+		return ps[0], nil
+	}
+	ids := make([]string, 0, len(ps))
+	for _, p := range ps {
+		ids = append(ids, p.Id)
+	}
+	return nil, &ErrAmbiguousProfile{Identifier: identifier, Value: value, IDs: ids}
+}
+
+// CreateProfileOrGet creates a new profile in Klaviyo. If a profile with the same identifiers already exists,
+// the existing profile is fetched and returned instead, and existed is true. The existing profile is not updated.
+// If fetching the existing profile fails, the error is returned and existed is still true.
+func (c *Client) CreateProfileOrGet(ctx context.Context, p *profile.NewProfile) (_ *profile.ExistingProfile, existed bool, _ error) {
+	created, err := c.CreateProfile(ctx, p)
+	if err == nil {
+		return created, false, nil
+	}
+
+	var dup *ErrProfileAlreadyExists
+	if !errors.As(err, &dup) || dup.DuplicateProfileID == "" {
+		return nil, false, err
+	}
+
+	existing, err := c.GetProfile(ctx, dup.DuplicateProfileID)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return existing, true, nil
+}
+
+// CountProfiles counts the profiles matching the filter expression, e.g. filter.GreaterThan("created", t),
+// or all the profiles if the filter is empty. The API has no count endpoint, so the profiles are paged through
+// with the largest page size, fetching only their emails. If limit is positive, counting stops as soon as
+// limit profiles are counted, which makes pre-flight checks like "at least 1000 recipients" cheap. As whole pages
+// are counted, the count can exceed the limit. complete reports whether all the matching profiles were counted.
+func (c *Client) CountProfiles(ctx context.Context, f filter.Expr, limit int) (count int, complete bool, _ error) {
+	paginator := c.NewProfilesPaginator(
+		getprofiles.WithPageSize(maxProfilesPageSize),
+		getprofiles.WithFields("email"),
+		getprofiles.WithFilter(f),
+	)
+	for paginator.HasNext() {
+		if limit > 0 && count >= limit {
+			return count, false, nil
+		}
+		ps, err := paginator.Next(ctx)
+		var partialErr *ErrPartialPage
+		if errors.As(err, &partialErr) {
+			// the profiles exist even if they can't be decoded
+			count += len(partialErr.Failures)
+		} else if err != nil {
+			return 0, false, err
+		}
+		count += len(ps)
+	}
+
+	return count, true, nil
+}
+
+// GetProfileLists retrieves all the lists the profile with the given ID belongs to,
+// e.g. to show which audiences a customer is subscribed to.
+func (c *Client) GetProfileLists(ctx context.Context, profileID string) ([]*list.ExistingList, error) {
+	endpoint := path.Join(profilesPath, profileID, listsPath)
+	p := newPaginator(c, func(ctx context.Context, fields url.Values) ([]*list.ExistingList, string, error) {
+		var result struct {
+			Data  []*list.ExistingList `json:"data"`
+			Links links                `json:"links"`
+		}
+		if err := c.doReq(ctx, OperationGetProfileLists, http.MethodGet, endpoint, fields, nil, &result); err != nil {
+			return nil, "", err
+		}
+		return result.Data, result.Links.nextCursor(), nil
+	}, url.Values{})
+
+	return allPages(ctx, p)
+}
+
+// GetProfileSegments retrieves all the segments the profile with the given ID belongs to.
+func (c *Client) GetProfileSegments(ctx context.Context, profileID string) ([]*segment.ExistingSegment, error) {
+	endpoint := path.Join(profilesPath, profileID, segmentsPath)
+	p := newPaginator(c, func(ctx context.Context, fields url.Values) ([]*segment.ExistingSegment, string, error) {
+		var result struct {
+			Data  []*segment.ExistingSegment `json:"data"`
+			Links links                      `json:"links"`
+		}
+		if err := c.doReq(ctx, OperationGetProfileSegments, http.MethodGet, endpoint, fields, nil, &result); err != nil {
+			return nil, "", err
+		}
+		return result.Data, result.Links.nextCursor(), nil
+	}, url.Values{})
+
+	return allPages(ctx, p)
+}
@@ -0,0 +1,867 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/property"
+	"github.com/monetha/go-klaviyo/operations/getprofiles"
+)
+
+func TestClient_FindProfilesByProperty(t *testing.T) {
+	t.Run("find profiles by property value across pages with valid API key", func(t *testing.T) {
+		withHTTPRecorder("tests/find_profiles_by_property_valid_api_key", func(c *http.Client) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+			ctx := context.TODO()
+			ps, err := kc.FindProfilesByProperty(ctx, "order_number", 1001)
+
+			require.NoError(t, err)
+			require.Len(t, ps, 2, "profiles len")
+			require.Equal(t, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", ps[0].Id)
+			require.Equal(t, "01H8J21A3S4D5F6G7H8J9K0L1M", ps[1].Id)
+		})
+	})
+
+	t.Run("prefixed and nested property names", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"data":[`+
+				`{"type":"profile","id":"1","attributes":{"properties":{"plan":"pro","address":{"city":"Vilnius"}}}},`+
+				`{"type":"profile","id":"2","attributes":{"properties":{"plan":"free","address.city":"Vilnius"}}},`+
+				`{"type":"profile","id":"3","attributes":{"properties":{"address":{"city":"Riga"}}}}`+
+				`],"links":{"next":null}}`), nil
+		})}
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+		ctx := context.TODO()
+
+		ids := func(ps []*profile.ExistingProfile) []string {
+			var ids []string
+			for _, p := range ps {
+				ids = append(ids, p.Id)
+			}
+			return ids
+		}
+
+		ps, err := kc.FindProfilesByProperty(ctx, "properties.plan", "pro")
+		require.NoError(t, err)
+		require.Equal(t, []string{"1"}, ids(ps))
+
+		ps, err = kc.FindProfilesByProperty(ctx, "properties.address.city", "Vilnius")
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, ids(ps))
+
+		ps, err = kc.FindProfilesByProperty(ctx, "address.city", "Riga")
+		require.NoError(t, err)
+		require.Equal(t, []string{"3"}, ids(ps))
+	})
+}
+
+func TestClient_UpdateProfile_DefaultProperties(t *testing.T) {
+	t.Run("update profile with default properties and property prefix", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c,
+			klaviyo.WithDefaultProfileProperties(map[string]interface{}{"source": "billing-service"}),
+			klaviyo.WithProfilePropertyPrefix("billing_"),
+		)
+
+		ctx := context.TODO()
+		_, err := kc.UpdateProfile(ctx, "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			profile.WithProperties(property.WithValue("plan", "pro")),
+			profile.UnsetProperties("trial"),
+		)
+
+		require.NoError(t, err)
+		require.Contains(t, body, `"properties":{"billing_plan":"pro","billing_source":"billing-service"}`)
+		require.Contains(t, body, `"unset":["billing_trial"]`)
+	})
+}
+
+func TestClient_CreateProfileOrGet(t *testing.T) {
+	t.Run("new profile is created", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodPost, req.Method)
+			return jsonResponse(http.StatusCreated, `{"data":{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		p, existed, err := kc.CreateProfileOrGet(context.TODO(), initialProfile)
+
+		require.NoError(t, err)
+		require.False(t, existed)
+		require.Equal(t, "01HN6AFEHGF6F77WJRKT1C9JHG", p.Id)
+	})
+
+	t.Run("existing profile is fetched", func(t *testing.T) {
+		var methods []string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			methods = append(methods, req.Method)
+			if req.Method == http.MethodPost {
+				return jsonResponse(http.StatusConflict, `{"errors":[{"id":"e0907b3f-c6d4-4c64-aa4c-51247aec7a4e","status":409,"code":"duplicate_profile","title":"Conflict.","detail":"A profile already exists with one of these identifiers.","source":{"pointer":"/data/attributes"},"meta":{"duplicate_profile_id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ"}}]}`), nil
+			}
+			require.Equal(t, "/api/profiles/01H8HKMDG8F4MN7PSRZ4YQYNVQ", req.URL.Path)
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com","first_name":"Sarah"}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		p, existed, err := kc.CreateProfileOrGet(context.TODO(), initialProfile)
+
+		require.NoError(t, err)
+		require.True(t, existed)
+		require.Equal(t, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", p.Id)
+		require.Equal(t, "Sarah", *p.Attributes.FirstName)
+		require.Equal(t, []string{http.MethodPost, http.MethodGet}, methods)
+	})
+}
+
+func TestClient_CreateOrUpdateProfile(t *testing.T) {
+	t.Run("attributes and property operations are sent in one call", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, "/api/profile-import", req.URL.Path)
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithProfilePropertyPrefix("loyalty_"))
+
+		p, err := kc.CreateOrUpdateProfile(context.TODO(),
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithProperties(property.WithValue("tier", "gold")),
+			profile.UnsetProperties("trial"),
+			profile.AppendProperty("badges", "early-bird"),
+			profile.UnappendProperty("coupons", "WELCOME10"),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", p.Id)
+		require.JSONEq(t, `{"data":{"type":"profile","attributes":{"email":"sarah.mason@klaviyo-demo.com","properties":{"loyalty_tier":"gold"}},"meta":{"patch_properties":{"unset":["loyalty_trial"],"append":{"loyalty_badges":"early-bird"},"unappend":{"loyalty_coupons":"WELCOME10"}}}}}`, body)
+	})
+
+	t.Run("conflicting operations on the same property are rejected", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		p, err := kc.CreateOrUpdateProfile(context.TODO(),
+			profile.WithEmail("sarah.mason@klaviyo-demo.com"),
+			profile.WithProperties(property.WithValue("tier", "gold")),
+			profile.UnsetProperties("tier"),
+		)
+
+		var e *klaviyo.ErrPropertyConflict
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "tier", e.Property)
+		require.Equal(t, []string{"set", "unset"}, e.Operations)
+		require.Nil(t, p)
+	})
+
+	t.Run("typed identifiers are sent as attributes", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		_, err := kc.CreateOrUpdateProfile(context.TODO(),
+			profile.IdentifyByExternalID("63f64a2b"),
+			profile.IdentifyByPhoneNumber("+15005550006"),
+			profile.WithFirstName("Sarah"),
+		)
+
+		require.NoError(t, err)
+		require.JSONEq(t, `{"data":{"type":"profile","attributes":{"external_id":"63f64a2b","phone_number":"+15005550006","first_name":"Sarah"}}}`, body)
+	})
+
+	t.Run("profile without identifier is rejected", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		p, err := kc.CreateOrUpdateProfile(context.TODO(),
+			profile.IdentifyByEmail(""),
+			profile.WithAnonymousId("anon-1"),
+			profile.WithFirstName("Sarah"),
+		)
+
+		require.ErrorIs(t, err, klaviyo.ErrMissingIdentifier)
+		require.Nil(t, p)
+	})
+}
+
+func TestClient_CountProfiles(t *testing.T) {
+	pages := map[string]string{
+		"":    `{"data":[{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHA","attributes":{}},{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHB","attributes":{}}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=cDI"}}`,
+		"cDI": `{"data":[{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHC","attributes":{}},{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHD","attributes":{}}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=cDM"}}`,
+		"cDM": `{"data":[{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHE","attributes":{}}],"links":{"next":null}}`,
+	}
+
+	var requests int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		q := req.URL.Query()
+		require.Equal(t, `greater-than(created,2024-01-01T00:00:00Z)`, q.Get("filter"))
+		require.Equal(t, "100", q.Get("page[size]"))
+		require.Equal(t, "email", q.Get("fields[profile]"))
+		return jsonResponse(http.StatusOK, pages[q.Get("page[cursor]")]), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+	createdAfter := filter.GreaterThan("created", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	t.Run("count all matching profiles", func(t *testing.T) {
+		requests = 0
+
+		count, complete, err := kc.CountProfiles(ctx, createdAfter, 0)
+
+		require.NoError(t, err)
+		require.Equal(t, 5, count)
+		require.True(t, complete)
+		require.Equal(t, 3, requests)
+	})
+
+	t.Run("stop counting at the limit", func(t *testing.T) {
+		requests = 0
+
+		count, complete, err := kc.CountProfiles(ctx, createdAfter, 3)
+
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+		require.False(t, complete)
+		require.Equal(t, 2, requests)
+	})
+}
+
+func TestClient_UpdateProfileUnsetChunks(t *testing.T) {
+	names := make([]string, 250)
+	for i := range names {
+		names[i] = fmt.Sprintf("legacy_%03d", i)
+	}
+
+	type patch struct {
+		Data struct {
+			Attributes map[string]interface{} `json:"attributes"`
+			Meta       struct {
+				PatchProperties struct {
+					Unset []string `json:"unset"`
+				} `json:"patch_properties"`
+			} `json:"meta"`
+		} `json:"data"`
+	}
+
+	newClient := func(patches *[]patch, failAt int) *klaviyo.Client {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var p patch
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&p))
+			*patches = append(*patches, p)
+			if len(*patches) == failAt {
+				return jsonResponse(http.StatusBadRequest, `{"errors":[{"status":400,"code":"invalid","title":"Invalid input."}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"title":"patch %d"}}}`, len(*patches))), nil
+		})}
+		return klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	}
+
+	t.Run("unset properties are split across requests", func(t *testing.T) {
+		var patches []patch
+		kc := newClient(&patches, 0)
+
+		p, err := kc.UpdateProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			profile.WithFirstName("Sarah"),
+			profile.UnsetProperties(names...),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, "patch 3", *p.Attributes.Title)
+		require.Len(t, patches, 3)
+		require.Equal(t, map[string]interface{}{"first_name": "Sarah"}, patches[0].Data.Attributes)
+		require.Empty(t, patches[1].Data.Attributes)
+		require.Empty(t, patches[2].Data.Attributes)
+		require.Equal(t, names[:100], patches[0].Data.Meta.PatchProperties.Unset)
+		require.Equal(t, names[100:200], patches[1].Data.Meta.PatchProperties.Unset)
+		require.Equal(t, names[200:], patches[2].Data.Meta.PatchProperties.Unset)
+	})
+
+	t.Run("failed subsequent request returns partial error", func(t *testing.T) {
+		var patches []patch
+		kc := newClient(&patches, 3)
+
+		p, err := kc.UpdateProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ", profile.UnsetProperties(names...))
+
+		var e *klaviyo.PartialError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Steps, 3)
+		require.Len(t, e.Failed(), 1)
+		require.Equal(t, "unset properties 200-249", e.Failed()[0].Name)
+		require.Equal(t, "patch 2", *p.Attributes.Title)
+	})
+}
+
+func TestClient_CreateOrUpdateProfile_ConflictRetries(t *testing.T) {
+	const conflict = `{"errors":[{"id":"e1","status":409,"code":"conflict","title":"Conflict.","detail":"A concurrent request is updating this profile."}]}`
+
+	t.Run("conflict is retried", func(t *testing.T) {
+		var attempts int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts < 3 {
+				return jsonResponse(http.StatusConflict, conflict), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01GVCF3FZ5W7YCE0MW4ZCBVWXM","attributes":{"email":"user@example.com"}}}`), nil
+		})}
+
+		clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk), klaviyo.WithConflictRetries(3))
+
+		type result struct {
+			p   *profile.ExistingProfile
+			err error
+		}
+		done := make(chan result, 1)
+		go func() {
+			p, err := kc.CreateOrUpdateProfile(context.TODO(), profile.WithEmail("user@example.com"))
+			done <- result{p, err}
+		}()
+
+		waitForSleeper(t, clk)
+		clk.Advance(250 * time.Millisecond)
+		waitForSleeper(t, clk)
+		clk.Advance(500 * time.Millisecond)
+
+		r := <-done
+		require.NoError(t, r.err)
+		require.Equal(t, "01GVCF3FZ5W7YCE0MW4ZCBVWXM", r.p.Id)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("conflict is returned by default", func(t *testing.T) {
+		var attempts int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return jsonResponse(http.StatusConflict, conflict), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		_, err := kc.CreateOrUpdateProfile(context.TODO(), profile.WithEmail("user@example.com"))
+
+		var apiErr *klaviyo.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusConflict, apiErr.Status)
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestClient_GetProfilesPage(t *testing.T) {
+	var cursors []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profiles", req.URL.Path)
+		cursor := req.URL.Query().Get("page[cursor]")
+		cursors = append(cursors, cursor)
+		if cursor == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{}}],`+
+				`"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=bmV4dDo6aWQ6OjE"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	ps, next, err := kc.GetProfilesPage(ctx, getprofiles.WithPageSize(1))
+	require.NoError(t, err)
+	require.Len(t, ps, 1)
+	require.Equal(t, "bmV4dDo6aWQ6OjE", next)
+
+	ps, next, err = kc.GetProfilesPage(ctx, getprofiles.WithPageSize(1), getprofiles.WithCursor(next))
+	require.NoError(t, err)
+	require.Len(t, ps, 1)
+	require.Equal(t, "01HN6AFEHGF6F77WJRKT1C9JHG", ps[0].Id)
+	require.Empty(t, next)
+
+	require.Equal(t, []string{"", "bmV4dDo6aWQ6OjE"}, cursors)
+}
+
+func TestClient_GetAllProfiles(t *testing.T) {
+	pages := map[string]string{
+		"": `{"data":[{"type":"profile","id":"p1","attributes":{}},{"type":"profile","id":"p2","attributes":{}}],` +
+			`"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=c2"}}`,
+		"c2": `{"data":[{"type":"profile","id":"p3","attributes":{}}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=c3"}}`,
+	}
+
+	var requests int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		body, ok := pages[req.URL.Query().Get("page[cursor]")]
+		if !ok {
+			return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"e1","status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid page cursor."}]}`), nil
+		}
+		return jsonResponse(http.StatusOK, body), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	t.Run("profiles are yielded until the error", func(t *testing.T) {
+		requests = 0
+		var (
+			ids  []string
+			errs []error
+		)
+		kc.GetAllProfiles(context.TODO())(func(p *profile.ExistingProfile, err error) bool {
+			if err != nil {
+				errs = append(errs, err)
+				return true
+			}
+			ids = append(ids, p.Id)
+			return true
+		})
+
+		require.Equal(t, []string{"p1", "p2", "p3"}, ids)
+		require.Len(t, errs, 1)
+		require.Equal(t, 3, requests)
+	})
+
+	t.Run("stopping the iteration stops fetching pages", func(t *testing.T) {
+		requests = 0
+		var ids []string
+		kc.GetAllProfiles(context.TODO())(func(p *profile.ExistingProfile, err error) bool {
+			require.NoError(t, err)
+			ids = append(ids, p.Id)
+			return len(ids) < 2
+		})
+
+		require.Equal(t, []string{"p1", "p2"}, ids)
+		require.Equal(t, 1, requests)
+	})
+}
+
+func TestClient_PlanMerge(t *testing.T) {
+	profiles := map[string]string{
+		"/api/profiles/01H8HKMDG8F4MN7PSRZ4YQYNVQ": `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com","first_name":"Sarah","phone_number":"+15005550006"}}}`,
+		"/api/profiles/01HN6AFEHGF6F77WJRKT1C9JHG": `{"data":{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{"email":"sarah.mason@klaviyo-demo.com","first_name":"Sara","phone_number":"+15005550009","title":"CTO"}}}`,
+	}
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, req.Method)
+		return jsonResponse(http.StatusOK, profiles[req.URL.Path]), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	plan, err := kc.PlanMerge(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ", "01HN6AFEHGF6F77WJRKT1C9JHG")
+	require.NoError(t, err)
+	require.Equal(t, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", plan.Destination.Id)
+	require.Equal(t, "01HN6AFEHGF6F77WJRKT1C9JHG", plan.Source.Id)
+	require.Equal(t, []profile.Conflict{
+		{Field: "first_name", A: "Sarah", B: "Sara"},
+		{Field: "phone_number", A: "+15005550006", B: "+15005550009"},
+	}, plan.Conflicts)
+	require.Equal(t, []string{"title"}, plan.Added)
+}
+
+func TestClient_GetProfiles_PredictiveAnalytics(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "subscriptions,predictive_analytics", req.URL.Query().Get("additional-fields[profile]"))
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{
+			"subscriptions":{"email":{"marketing":{"consent":"SUBSCRIBED","can_receive_email_marketing":true}}},
+			"predictive_analytics":{"historic_clv":93.87,"predicted_clv":27.24,"total_clv":121.11,
+				"historic_number_of_orders":2,"predicted_number_of_orders":0.54,"average_days_between_orders":189,
+				"average_order_value":46.94,"churn_probability":0.36,"expected_date_of_next_order":"2024-05-10T00:00:00+00:00"}
+		}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	ps, err := kc.GetProfiles(context.TODO(), getprofiles.WithAdditionalFields(
+		getprofiles.AdditionalFieldSubscriptions,
+		getprofiles.AdditionalFieldPredictiveAnalytics,
+	))
+	require.NoError(t, err)
+	require.Len(t, ps, 1)
+
+	attrs := ps[0].Attributes
+	require.Equal(t, "SUBSCRIBED", attrs.Subscriptions.Email.Marketing.Consent)
+
+	pa := attrs.PredictiveAnalytics
+	require.NotNil(t, pa)
+	require.Equal(t, 93.87, *pa.HistoricCLV)
+	require.Equal(t, 27.24, *pa.PredictedCLV)
+	require.Equal(t, 0.36, *pa.ChurnProbability)
+	require.Equal(t, 46.94, *pa.AverageOrderValue)
+	require.Equal(t, time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), pa.ExpectedDateOfNextOrder.UTC())
+
+	clone := ps[0].Clone()
+	*clone.Attributes.PredictiveAnalytics.HistoricCLV = 0
+	require.Equal(t, 93.87, *pa.HistoricCLV)
+}
+
+func TestClient_ForEachProfilePage(t *testing.T) {
+	var requests int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if req.URL.Query().Get("page[cursor]") == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"profile","id":"p1","attributes":{}},{"type":"profile","id":"p2","attributes":{}}],`+
+				`"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=c2"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"profile","id":"p3","attributes":{}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	t.Run("all pages are passed to the callback", func(t *testing.T) {
+		requests = 0
+		var pages [][]string
+		err := kc.ForEachProfilePage(context.TODO(), func(ps []*profile.ExistingProfile) error {
+			var ids []string
+			for _, p := range ps {
+				ids = append(ids, p.Id)
+			}
+			pages = append(pages, ids)
+			return nil
+		}, getprofiles.WithPageSize(2))
+
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"p1", "p2"}, {"p3"}}, pages)
+		require.Equal(t, 2, requests)
+	})
+
+	t.Run("callback error stops the walk", func(t *testing.T) {
+		requests = 0
+		errWarehouse := errors.New("warehouse unavailable")
+		err := kc.ForEachProfilePage(context.TODO(), func(ps []*profile.ExistingProfile) error {
+			return errWarehouse
+		})
+
+		require.ErrorIs(t, err, errWarehouse)
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("context cancellation stops the walk", func(t *testing.T) {
+		requests = 0
+		ctx, cancel := context.WithCancel(context.Background())
+		err := kc.ForEachProfilePage(ctx, func(ps []*profile.ExistingProfile) error {
+			cancel()
+			return nil
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, requests)
+	})
+}
+
+func TestClient_GetProfileByEmail(t *testing.T) {
+	const profileJSON = `{"type":"profile","id":"%s","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}`
+
+	var (
+		filters []string
+		ids     []string
+	)
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profiles", req.URL.Path)
+		require.Equal(t, "2", req.URL.Query().Get("page[size]"))
+		filters = append(filters, req.URL.Query().Get("filter"))
+
+		data := make([]string, 0, len(ids))
+		for _, id := range ids {
+			data = append(data, fmt.Sprintf(profileJSON, id))
+		}
+		return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	t.Run("single profile", func(t *testing.T) {
+		filters, ids = nil, []string{"01GDDKASAP8TKDDA2GRZDSVP4H"}
+
+		p, err := kc.GetProfileByEmail(ctx, "sarah.mason@klaviyo-demo.com")
+
+		require.NoError(t, err)
+		require.Equal(t, "01GDDKASAP8TKDDA2GRZDSVP4H", p.Id)
+		require.Equal(t, []string{`equals(email,"sarah.mason@klaviyo-demo.com")`}, filters)
+	})
+
+	t.Run("no profile", func(t *testing.T) {
+		filters, ids = nil, nil
+
+		p, err := kc.GetProfileByExternalID(ctx, "63f64a2b")
+
+		require.ErrorIs(t, err, klaviyo.ErrProfileDoesNotExist)
+		require.Nil(t, p)
+		require.Equal(t, []string{`equals(external_id,"63f64a2b")`}, filters)
+	})
+
+	t.Run("several profiles", func(t *testing.T) {
+		filters, ids = nil, []string{"01GDDKASAP8TKDDA2GRZDSVP4H", "01HN6AFEHGF6F77WJRKT1C9JHG"}
+
+		p, err := kc.GetProfileByExternalID(ctx, "63f64a2b")
+
+		var e *klaviyo.ErrAmbiguousProfile
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "external_id", e.Identifier)
+		require.Equal(t, ids, e.IDs)
+		require.Nil(t, p)
+	})
+}
+
+func TestClient_GetProfilesByIDs(t *testing.T) {
+	ids := make([]string, 0, 102)
+	for i := 0; i < 101; i++ {
+		ids = append(ids, fmt.Sprintf("prof%03d", i))
+	}
+	ids = append(ids, "prof000", "missing")
+
+	var filters []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profiles", req.URL.Path)
+		filter := req.URL.Query().Get("filter")
+		filters = append(filters, filter)
+
+		// respond with the requested profiles in reverse order, except the missing one
+		requested := strings.Split(strings.TrimSuffix(strings.TrimPrefix(filter, "any(id,["), "])"), ",")
+		var data []string
+		for i := len(requested) - 1; i >= 0; i-- {
+			if id := strings.Trim(requested[i], `"`); id != "missing" {
+				data = append(data, fmt.Sprintf(`{"type":"profile","id":%q,"attributes":{}}`, id))
+			}
+		}
+		return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	profiles, err := kc.GetProfilesByIDs(context.TODO(), ids)
+	require.NoError(t, err)
+	require.Len(t, filters, 2)
+	require.True(t, strings.HasPrefix(filters[0], `any(id,["prof000","prof001",`))
+	require.Equal(t, `any(id,["prof100","missing"])`, filters[1])
+
+	require.Len(t, profiles, 101)
+	for i, p := range profiles {
+		require.Equal(t, ids[i], p.Id)
+	}
+}
+
+func TestClient_GetProfileLists(t *testing.T) {
+	const profileID = "01GDDKASAP8TKDDA2GRZDSVP4H"
+
+	var cursors []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profiles/"+profileID+"/lists", req.URL.Path)
+		cursor := req.URL.Query().Get("page[cursor]")
+		cursors = append(cursors, cursor)
+		if cursor == "" {
+			return jsonResponse(http.StatusOK, `{"data":[{"type":"list","id":"Y6nRLr","attributes":{"name":"Newsletter"}}],"links":{"next":"https://a.klaviyo.com/api/profiles/`+profileID+`/lists/?page%5Bcursor%5D=bmV4dA"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"list","id":"XnLM8q","attributes":{"name":"VIP"}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	ls, err := kc.GetProfileLists(context.TODO(), profileID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "bmV4dA"}, cursors)
+	require.Len(t, ls, 2)
+	require.Equal(t, "Y6nRLr", ls[0].ID)
+	require.Equal(t, "Newsletter", ls[0].Attributes.Name)
+	require.Equal(t, "VIP", ls[1].Attributes.Name)
+}
+
+func TestClient_GetProfileSegments(t *testing.T) {
+	const profileID = "01GDDKASAP8TKDDA2GRZDSVP4H"
+
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/api/profiles/"+profileID+"/segments", req.URL.Path)
+		return jsonResponse(http.StatusOK, `{"data":[{"type":"segment","id":"UTd5ui","attributes":{"name":"Engaged"}}],"links":{"next":null}}`), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	ss, err := kc.GetProfileSegments(context.TODO(), profileID)
+	require.NoError(t, err)
+	require.Len(t, ss, 1)
+	require.Equal(t, "UTd5ui", ss[0].ID)
+	require.Equal(t, "Engaged", ss[0].Attributes.Name)
+}
+
+func BenchmarkClient_UpdateProfile(b *testing.B) {
+	kc := newOverheadClient(zap.NewNop())
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := kc.UpdateProfile(ctx, "01GDDKASAP8TKDDA2GRZDSVP4H",
+			profile.WithFirstName("Sarah"),
+			profile.WithLastName("Mason"),
+			profile.WithProperties(
+				property.WithValue("plan", "pro"),
+				property.WithValue("lifetime_value", 1024.5),
+				property.WithValue("tags", []interface{}{"vip", "beta"}),
+			),
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestClient_GetProfiles_PartialPage(t *testing.T) {
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"data":[`+
+			`{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com"}},`+
+			`{"type":"profile","id":"01GDDKASAP8TKDDA2GRZDSVP4H","attributes":{"email":42}},`+
+			`{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{"email":"john.doe@klaviyo-demo.com","locale":"en-US"}}`+
+			`],"links":{"next":null}}`), nil
+	})}
+
+	t.Run("records that can't be decoded are reported", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ps, err := kc.GetProfiles(context.TODO())
+
+		var e *klaviyo.ErrPartialPage
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, klaviyo.OperationGetProfiles, e.Operation)
+		require.Len(t, e.Failures, 1)
+		require.Equal(t, 1, e.Failures[0].Index)
+		require.Equal(t, "01GDDKASAP8TKDDA2GRZDSVP4H", e.Failures[0].ID)
+		require.Error(t, e.Failures[0].Err)
+
+		require.Len(t, ps, 2)
+		require.Equal(t, "01H8HKMDG8F4MN7PSRZ4YQYNVQ", ps[0].Id)
+		require.Equal(t, "01HN6AFEHGF6F77WJRKT1C9JHG", ps[1].Id)
+	})
+
+	t.Run("strict decoding checks the records", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"data":[`+
+				`{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{"email":"john.doe@klaviyo-demo.com","locale":"en-US"}}`+
+				`],"links":{"next":null}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithStrictDecoding(klaviyo.StrictDecodingError))
+
+		ps, err := kc.GetProfiles(context.TODO())
+
+		var e *klaviyo.ErrUnknownField
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "locale", e.Field)
+		require.Nil(t, ps)
+	})
+}
+
+func TestClient_ProfilePages_PartialPage(t *testing.T) {
+	pages := map[string]string{
+		"": `{"data":[` +
+			`{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"properties":{"tier":"gold"}}},` +
+			`{"type":"profile","id":"01GDDKASAP8TKDDA2GRZDSVP4H","attributes":{"email":42}}` +
+			`],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=cDI"}}`,
+		"cDI": `{"data":[{"type":"profile","id":"01HN6AFEHGF6F77WJRKT1C9JHG","attributes":{"properties":{"tier":"gold"}}}],"links":{"next":null}}`,
+	}
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, pages[req.URL.Query().Get("page[cursor]")]), nil
+	})}
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+	ctx := context.TODO()
+
+	requirePartial := func(t *testing.T, err error) {
+		var e *klaviyo.ErrPartialPage
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Failures, 1)
+		require.Equal(t, "01GDDKASAP8TKDDA2GRZDSVP4H", e.Failures[0].ID)
+	}
+
+	t.Run("paginator continues after a partial page", func(t *testing.T) {
+		paginator := kc.NewProfilesPaginator()
+
+		ps, err := paginator.Next(ctx)
+		requirePartial(t, err)
+		require.Len(t, ps, 1)
+		require.True(t, paginator.HasNext())
+
+		ps, err = paginator.Next(ctx)
+		require.NoError(t, err)
+		require.Len(t, ps, 1)
+		require.False(t, paginator.HasNext())
+	})
+
+	t.Run("page walk reports the failures at the end", func(t *testing.T) {
+		var ids []string
+		err := kc.ForEachProfilePage(ctx, func(ps []*profile.ExistingProfile) error {
+			for _, p := range ps {
+				ids = append(ids, p.Id)
+			}
+			return nil
+		})
+		requirePartial(t, err)
+		require.Equal(t, []string{"01H8HKMDG8F4MN7PSRZ4YQYNVQ", "01HN6AFEHGF6F77WJRKT1C9JHG"}, ids)
+	})
+
+	t.Run("sequence yields the failures and continues", func(t *testing.T) {
+		var ids []string
+		var errs []error
+		kc.GetAllProfiles(ctx)(func(p *profile.ExistingProfile, err error) bool {
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				ids = append(ids, p.Id)
+			}
+			return true
+		})
+		require.Len(t, errs, 1)
+		requirePartial(t, errs[0])
+		require.Len(t, ids, 2)
+	})
+
+	t.Run("profiles that can't be decoded are counted", func(t *testing.T) {
+		count, complete, err := kc.CountProfiles(ctx, filter.Expr{}, 0)
+		require.NoError(t, err)
+		require.True(t, complete)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("search returns the matches and the failures", func(t *testing.T) {
+		ps, err := kc.FindProfilesByProperty(ctx, "tier", "gold")
+		requirePartial(t, err)
+		require.Len(t, ps, 2)
+	})
+
+	t.Run("lookup by IDs returns the decoded profiles and the failures", func(t *testing.T) {
+		ps, err := kc.GetProfilesByIDs(ctx, []string{"01H8HKMDG8F4MN7PSRZ4YQYNVQ", "01GDDKASAP8TKDDA2GRZDSVP4H", "01HN6AFEHGF6F77WJRKT1C9JHG"})
+		requirePartial(t, err)
+		require.Len(t, ps, 2)
+	})
+}
@@ -0,0 +1,117 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/monetha/go-klaviyo/filter"
+	"github.com/monetha/go-klaviyo/internal/cache"
+)
+
+// ErrNameNotFound indicates that no resource of the given kind has the given name.
+type ErrNameNotFound struct {
+	Kind string
+	Name string
+}
+
+// Error returns a string representation of the ErrNameNotFound error.
+// It conforms to the error interface.
+func (e *ErrNameNotFound) Error() string {
+	return fmt.Sprintf("klaviyo: no %s named %q", e.Kind, e.Name)
+}
+
+// ErrAmbiguousName indicates that several resources of the given kind have the given name.
+// It holds the IDs of all the matching resources.
+type ErrAmbiguousName struct {
+	Kind string
+	Name string
+	IDs  []string
+}
+
+// Error returns a string representation of the ErrAmbiguousName error.
+// It conforms to the error interface.
+func (e *ErrAmbiguousName) Error() string {
+	return fmt.Sprintf("klaviyo: %d %ss named %q: %s", len(e.IDs), e.Kind, e.Name, strings.Join(e.IDs, ", "))
+}
+
+// resolvers holds the caches of the resolved resource IDs.
+type resolvers struct {
+	listIDs    *cache.TTL
+	segmentIDs *cache.TTL
+}
+
+func newResolvers(o *Options) *resolvers {
+	return &resolvers{
+		listIDs:    cache.NewTTL(o.nameResolutionTTL, o.clock.Now),
+		segmentIDs: cache.NewTTL(o.nameResolutionTTL, o.clock.Now),
+	}
+}
+
+// ResolveListID returns the ID of the list with the given name. Resolved IDs are cached
+// for the time set by WithNameResolutionTTL. If there is no such list, ErrNameNotFound is returned;
+// if several lists have the name, ErrAmbiguousName is returned.
+func (c *Client) ResolveListID(ctx context.Context, name string) (string, error) {
+	return c.resolveID(ctx, c.resolvers.listIDs, "list", name, func(ctx context.Context, fields url.Values) ([]string, string, error) {
+		ls, cursor, err := c.getListsPage(ctx, fields)
+		if err != nil {
+			return nil, "", err
+		}
+		var ids []string
+		for _, l := range ls {
+			if l.Attributes.Name == name {
+				ids = append(ids, l.ID)
+			}
+		}
+		return ids, cursor, nil
+	})
+}
+
+// ResolveSegmentID returns the ID of the segment with the given name. Resolved IDs are cached
+// for the time set by WithNameResolutionTTL. If there is no such segment, ErrNameNotFound is returned;
+// if several segments have the name, ErrAmbiguousName is returned.
+func (c *Client) ResolveSegmentID(ctx context.Context, name string) (string, error) {
+	return c.resolveID(ctx, c.resolvers.segmentIDs, "segment", name, func(ctx context.Context, fields url.Values) ([]string, string, error) {
+		ss, cursor, err := c.getSegmentsPage(ctx, fields)
+		if err != nil {
+			return nil, "", err
+		}
+		var ids []string
+		for _, s := range ss {
+			if s.Attributes.Name == name {
+				ids = append(ids, s.ID)
+			}
+		}
+		return ids, cursor, nil
+	})
+}
+
+// idsPageFunc retrieves a single page of the IDs of resources matching the filter and returns the cursor of the next page, if any.
+type idsPageFunc func(ctx context.Context, fields url.Values) ([]string, string, error)
+
+// resolveID returns the ID of the resource of the kind with the given name, fetching all the pages of the IDs
+// of the resources with the name unless the ID is cached.
+func (c *Client) resolveID(ctx context.Context, ids *cache.TTL, kind, name string, getPage idsPageFunc) (string, error) {
+	if id, ok := ids.Get(name); ok {
+		return id, nil
+	}
+
+	fields := url.Values{}
+	fields.Set("filter", filter.Equals("name", name).String())
+
+	found, err := allPages(ctx, newPaginator(c, pageFunc[string](getPage), fields))
+	if err != nil {
+		return "", err
+	}
+
+	switch len(found) {
+	case 0:
+		return "", &ErrNameNotFound{Kind: kind, Name: name}
+	case 1:
+		ids.Set(name, found[0])
+		return found[0], nil
+	default:
+		return "", &ErrAmbiguousName{Kind: kind, Name: name, IDs: found}
+	}
+}
@@ -0,0 +1,152 @@
+package klaviyo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+// ErrRateLimited is returned by the client method when the endpoint is retried the maximum number
+// of times and still responds with 429 Too Many Requests. It matches ErrTooManyRequests with errors.Is().
+type ErrRateLimited struct {
+	// RetryAfter is the time to wait before retrying, as requested by the API, or zero if it is unknown.
+	RetryAfter time.Duration
+}
+
+// Error returns a string representation of the ErrRateLimited error.
+// It conforms to the error interface.
+func (e *ErrRateLimited) Error() string {
+	if e.RetryAfter <= 0 {
+		return ErrTooManyRequests.Error()
+	}
+	return fmt.Sprintf("%s (retry after %s)", ErrTooManyRequests.Error(), e.RetryAfter)
+}
+
+// Is makes ErrRateLimited match ErrTooManyRequests with Go's errors.Is() function.
+func (e *ErrRateLimited) Is(target error) bool {
+	return target == ErrTooManyRequests
+}
+
+// ErrServiceUnavailable is returned by the client method when the endpoint is retried the maximum number
+// of times and Klaviyo still responds with 503 Service Unavailable, e.g. during maintenance. Batch jobs
+// can pause for RetryAfter and resume instead of aborting.
+type ErrServiceUnavailable struct {
+	// RetryAfter is the time to wait before retrying, as requested by the API, or zero if it is unknown.
+	RetryAfter time.Duration
+	// Maintenance reports whether the response indicates that Klaviyo is in maintenance mode.
+	Maintenance bool
+}
+
+// Error returns a string representation of the ErrServiceUnavailable error.
+// It conforms to the error interface.
+func (e *ErrServiceUnavailable) Error() string {
+	msg := "klaviyo: service unavailable"
+	if e.Maintenance {
+		msg += " (maintenance)"
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	return msg
+}
+
+// ErrRetryAfterExceedsDeadline is returned by the client method when the endpoint responds with 429 Too Many Requests
+// or 503 Service Unavailable and the time to wait requested by the API exceeds the remaining time of the context deadline.
+// The client returns immediately instead of waiting and then failing, so callers with a latency budget can degrade gracefully.
+type ErrRetryAfterExceedsDeadline struct {
+	// RetryAfter is the time to wait before retrying, as requested by the API.
+	RetryAfter time.Duration
+	// StatusCode is the status code of the response.
+	StatusCode int
+}
+
+// Error returns a string representation of the ErrRetryAfterExceedsDeadline error.
+// It conforms to the error interface.
+func (e *ErrRetryAfterExceedsDeadline) Error() string {
+	return fmt.Sprintf("klaviyo: retry after %s exceeds the context deadline (status %d)", e.RetryAfter, e.StatusCode)
+}
+
+// checkRetry is the retry policy of the client. It extends the default policy by giving up immediately
+// if the wait requested by the Retry-After header doesn't fit in the remaining time of the context deadline.
+// Context deadlines are always based on the system time, so the clock of the client is not used.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !retry || checkErr != nil || resp == nil {
+		return retry, checkErr
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return retry, nil
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return retry, nil
+	}
+	now := time.Now()
+	if retryAfter := parseRetryAfter(resp.Header, now); retryAfter > deadline.Sub(now) {
+		return false, &ErrRetryAfterExceedsDeadline{RetryAfter: retryAfter, StatusCode: resp.StatusCode}
+	}
+	return retry, nil
+}
+
+// newBackoff returns the backoff of the retrying HTTP client. With a clock other than the system one, the wait
+// before retrying a response, e.g. 429 Too Many Requests, elapses on the clock: the backoff sleeps on the clock
+// until the context of the request is done and returns no wait. The wait after a transport error isn't tied
+// to a request, so it elapses on the system time to stay cancelable by the context.
+func newBackoff(clk clock.Clock) retryablehttp.Backoff {
+	if clk == clock.System {
+		return retryablehttp.DefaultBackoff
+	}
+	return func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		wait := retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+		if resp == nil || resp.Request == nil {
+			return wait
+		}
+		_ = clk.Sleep(resp.Request.Context(), wait)
+		return 0
+	}
+}
+
+// maxMaintenanceBodySize limits how much of a 503 response body is inspected for maintenance markers.
+const maxMaintenanceBodySize = 4 << 10
+
+// newServiceUnavailableError creates ErrServiceUnavailable from the 503 response.
+func newServiceUnavailableError(resp *http.Response, now time.Time) *ErrServiceUnavailable {
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, maxMaintenanceBodySize))
+	}
+	return &ErrServiceUnavailable{
+		RetryAfter:  parseRetryAfter(resp.Header, now),
+		Maintenance: bytes.Contains(bytes.ToLower(body), []byte("maintenance")),
+	}
+}
+
+// parseRetryAfter returns the duration requested by the Retry-After header, which can be given
+// either in seconds or as an HTTP date. It returns zero if the header is missing or invalid.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
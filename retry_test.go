@@ -0,0 +1,147 @@
+package klaviyo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+	"github.com/monetha/go-klaviyo/models/event"
+)
+
+func TestClient_ServiceUnavailable(t *testing.T) {
+	t.Run("get profiles during maintenance", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp := jsonResponse(http.StatusServiceUnavailable, `<html><body>Klaviyo is down for scheduled maintenance.</body></html>`)
+			resp.Header.Set("Retry-After", "0")
+			return resp, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ctx := context.TODO()
+		ps, err := kc.GetProfiles(ctx)
+
+		var e *klaviyo.ErrServiceUnavailable
+		require.ErrorAs(t, err, &e)
+		require.True(t, e.Maintenance)
+		require.Nil(t, ps)
+	})
+}
+
+func TestClient_RetryAfterExceedsDeadline(t *testing.T) {
+	t.Run("rate limited request returns immediately", func(t *testing.T) {
+		var calls int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			resp := jsonResponse(http.StatusTooManyRequests, `{"errors":[{"status":429,"code":"throttled","title":"Request was throttled."}]}`)
+			resp.Header.Set("Retry-After", "30")
+			return resp, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		start := time.Now()
+		ps, err := kc.GetProfiles(ctx)
+
+		var e *klaviyo.ErrRetryAfterExceedsDeadline
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, 30*time.Second, e.RetryAfter)
+		require.Equal(t, http.StatusTooManyRequests, e.StatusCode)
+		require.Nil(t, ps)
+		require.Equal(t, 1, calls)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("request is retried when the wait fits in the deadline", func(t *testing.T) {
+		var calls int
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				resp := jsonResponse(http.StatusServiceUnavailable, ``)
+				resp.Header.Set("Retry-After", "0")
+				return resp, nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := kc.GetProfiles(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, calls)
+	})
+}
+
+func TestClient_RetriedRequestBody(t *testing.T) {
+	var bodies []string
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies)%2 == 1 {
+			resp := jsonResponse(http.StatusTooManyRequests, `{"errors":[{"status":429,"code":"throttled","title":"Request was throttled."}]}`)
+			resp.Header.Set("Retry-After", "0")
+			return resp, nil
+		}
+		return jsonResponse(http.StatusAccepted, ""), nil
+	})}
+
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+	ctx := context.TODO()
+	first, err := kc.CreateEvent(ctx, &event.NewEvent{NewAttributes: event.NewAttributes{UniqueID: "order-1"}}, "01GDDKASAP8TKDDA2GRZDSVP4H", event.MetricPlacedOrder)
+	require.NoError(t, err)
+	second, err := kc.CreateEvent(ctx, &event.NewEvent{NewAttributes: event.NewAttributes{UniqueID: "order-2"}}, "01GDDKASAP8TKDDA2GRZDSVP4H", event.MetricPlacedOrder)
+	require.NoError(t, err)
+
+	// the retries send the same body, and the payloads of the results outlive the pooled request buffers
+	require.Len(t, bodies, 4)
+	require.Equal(t, bodies[0], bodies[1])
+	require.Equal(t, bodies[2], bodies[3])
+	require.Equal(t, bodies[1], string(first.Payload))
+	require.Equal(t, bodies[3], string(second.Payload))
+	require.Contains(t, string(first.Payload), `"unique_id":"order-1"`)
+}
+
+func TestClient_RetryClock(t *testing.T) {
+	var calls int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := jsonResponse(http.StatusTooManyRequests, `{"errors":[{"status":429,"code":"throttled","title":"Request was throttled."}]}`)
+			resp.Header.Set("Retry-After", "30")
+			return resp, nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[]}`), nil
+	})}
+
+	clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clk))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := kc.GetProfiles(context.TODO())
+		done <- err
+	}()
+
+	// the retry waits for the Retry-After on the clock instead of the system time
+	waitForSleeper(t, clk)
+	require.Equal(t, 1, calls)
+	clk.Advance(30 * time.Second)
+
+	require.NoError(t, <-done)
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,93 @@
+package klaviyo
+
+import (
+	"fmt"
+)
+
+// Scope is a Klaviyo API key scope.
+type Scope string
+
+// Klaviyo API key scopes required by the client operations.
+const (
+	ScopeAccountsRead       Scope = "accounts:read"
+	ScopeCampaignsRead      Scope = "campaigns:read"
+	ScopeCampaignsWrite     Scope = "campaigns:write"
+	ScopeEventsRead         Scope = "events:read"
+	ScopeEventsWrite        Scope = "events:write"
+	ScopeFlowsRead          Scope = "flows:read"
+	ScopeListsRead          Scope = "lists:read"
+	ScopeListsWrite         Scope = "lists:write"
+	ScopeMetricsRead        Scope = "metrics:read"
+	ScopeProfilesRead       Scope = "profiles:read"
+	ScopeProfilesWrite      Scope = "profiles:write"
+	ScopeSegmentsRead       Scope = "segments:read"
+	ScopeSubscriptionsWrite Scope = "subscriptions:write"
+)
+
+// requiredScopes maps the operations to the scopes required by them.
+var requiredScopes = map[Operation]Scope{
+	OperationGetAccounts:            ScopeAccountsRead,
+	OperationGetBulkImportJobs:      ScopeProfilesRead,
+	OperationGetBulkImportJob:       ScopeProfilesRead,
+	OperationCreateBulkImportJob:    ScopeProfilesWrite,
+	OperationGetCampaign:            ScopeCampaignsRead,
+	OperationCreateCampaign:         ScopeCampaignsWrite,
+	OperationGetCampaignSendJob:     ScopeCampaignsRead,
+	OperationUpdateCampaignSendJob:  ScopeCampaignsWrite,
+	OperationGetEvents:              ScopeEventsRead,
+	OperationGetEvent:               ScopeEventsRead,
+	OperationCreateEvent:            ScopeEventsWrite,
+	OperationGetFlows:               ScopeFlowsRead,
+	OperationGetFlow:                ScopeFlowsRead,
+	OperationGetFlowActions:         ScopeFlowsRead,
+	OperationGetMetrics:             ScopeMetricsRead,
+	OperationGetMetric:              ScopeMetricsRead,
+	OperationGetLists:               ScopeListsRead,
+	OperationGetList:                ScopeListsRead,
+	OperationGetListProfiles:        ScopeListsRead,
+	OperationRemoveProfilesFromList: ScopeListsWrite,
+	OperationGetProfiles:            ScopeProfilesRead,
+	OperationGetProfile:             ScopeProfilesRead,
+	OperationGetProfileLists:        ScopeListsRead,
+	OperationGetProfileSegments:     ScopeSegmentsRead,
+	OperationCreateProfile:          ScopeProfilesWrite,
+	OperationUpdateProfile:          ScopeProfilesWrite,
+	OperationCreateOrUpdateProfile:  ScopeProfilesWrite,
+	OperationGetSegments:            ScopeSegmentsRead,
+	OperationGetSegment:             ScopeSegmentsRead,
+	OperationGetSegmentProfiles:     ScopeSegmentsRead,
+	OperationSuppressProfiles:       ScopeSubscriptionsWrite,
+	OperationUnsuppressProfiles:     ScopeSubscriptionsWrite,
+	OperationSubscribeProfiles:      ScopeSubscriptionsWrite,
+	OperationUnsubscribeProfiles:    ScopeSubscriptionsWrite,
+}
+
+// RequiredScope returns the API key scope required by the operation, or an empty scope if the operation is unknown.
+func RequiredScope(op Operation) Scope {
+	return requiredScopes[op]
+}
+
+// ErrMissingScope indicates that the API key is not allowed to perform the operation,
+// because it lacks the required scope.
+type ErrMissingScope struct {
+	Operation Operation
+	Scope     Scope
+	Cause     *APIError
+}
+
+// Error returns a string representation of the ErrMissingScope error.
+// It conforms to the error interface.
+func (e *ErrMissingScope) Error() string {
+	if e.Scope == "" {
+		return fmt.Sprintf("klaviyo: API key is not allowed to perform %s", e.Operation)
+	}
+	return fmt.Sprintf("klaviyo: API key is missing the %q scope required to perform %s", e.Scope, e.Operation)
+}
+
+// Unwrap provides compatibility for Go's errors.Is() and errors.As() functions.
+func (e *ErrMissingScope) Unwrap() error {
+	if e.Cause == nil {
+		return nil
+	}
+	return e.Cause
+}
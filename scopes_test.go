@@ -0,0 +1,38 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestRequiredScope(t *testing.T) {
+	require.Equal(t, klaviyo.ScopeProfilesRead, klaviyo.RequiredScope(klaviyo.OperationGetProfile))
+	require.Equal(t, klaviyo.ScopeEventsWrite, klaviyo.RequiredScope(klaviyo.OperationCreateEvent))
+	require.Empty(t, klaviyo.RequiredScope("UnknownOperation"))
+}
+
+func TestClient_MissingScope(t *testing.T) {
+	t.Run("create event with API key missing the scope", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusForbidden, `{"errors":[{"id":"a8c5d3b2-0c48-4b8c-9d1e-51d7e2f1c6a0","status":403,"code":"permission_denied","title":"You do not have permission to perform this action.","detail":"This API key is missing required scopes.","source":{"pointer":"/data/"}}]}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		ctx := context.TODO()
+		res, err := kc.CreateEvent(ctx, &inititalEvent, "01HN6AFEHGF6F77WJRKT1C9JHG", "Reward")
+
+		var e *klaviyo.ErrMissingScope
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, klaviyo.OperationCreateEvent, e.Operation)
+		require.Equal(t, klaviyo.ScopeEventsWrite, e.Scope)
+		require.Contains(t, err.Error(), `"events:write"`)
+		require.Nil(t, res)
+	})
+}
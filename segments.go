@@ -0,0 +1,49 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/monetha/go-klaviyo/models/segment"
+)
+
+const segmentsPath = "segments"
+
+// GetSegments retrieves a list of segments from Klaviyo.
+func (c *Client) GetSegments(ctx context.Context) ([]*segment.ExistingSegment, error) {
+	ss, _, err := c.getSegmentsPage(ctx, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	return ss, nil
+}
+
+// GetSegment retrieves a specific segment by its ID from Klaviyo.
+func (c *Client) GetSegment(ctx context.Context, segmentID string) (*segment.ExistingSegment, error) {
+	endpoint := path.Join(segmentsPath, segmentID)
+
+	var result struct {
+		Data segment.ExistingSegment `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetSegment, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// getSegmentsPage retrieves a single page of segments and returns the cursor of the next page, if any.
+func (c *Client) getSegmentsPage(ctx context.Context, fields url.Values) ([]*segment.ExistingSegment, string, error) {
+	var result struct {
+		Data  []*segment.ExistingSegment `json:"data"`
+		Links links                      `json:"links"`
+	}
+	if err := c.doReq(ctx, OperationGetSegments, http.MethodGet, segmentsPath, fields, nil, &result); err != nil {
+		return nil, "", err
+	}
+
+	return result.Data, result.Links.nextCursor(), nil
+}
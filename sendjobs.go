@@ -0,0 +1,76 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/monetha/go-klaviyo/models/campaign"
+)
+
+const (
+	campaignSendJobType  = "campaign-send-job"
+	campaignSendJobsPath = "campaign-send-jobs"
+)
+
+// Actions that can be performed on a campaign send job.
+const (
+	sendJobActionCancel = "cancel"
+	sendJobActionRevert = "revert"
+)
+
+// GetCampaignSendJob retrieves the send job of the campaign with the given ID.
+func (c *Client) GetCampaignSendJob(ctx context.Context, jobID string) (*campaign.SendJob, error) {
+	endpoint := path.Join(campaignSendJobsPath, jobID)
+
+	var result struct {
+		Data campaign.SendJob `json:"data"`
+	}
+	if err := c.doReq(ctx, OperationGetCampaignSendJob, http.MethodGet, endpoint, nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// CancelCampaignSendJob cancels the campaign send job with the given ID, halting a send in progress.
+// The campaign is set to the cancelled status and can't be sent again.
+func (c *Client) CancelCampaignSendJob(ctx context.Context, jobID string) error {
+	return c.updateCampaignSendJob(ctx, jobID, sendJobActionCancel)
+}
+
+// RevertCampaignSendJob cancels the campaign send job with the given ID and reverts the campaign to a draft,
+// so it can be fixed and sent again.
+func (c *Client) RevertCampaignSendJob(ctx context.Context, jobID string) error {
+	return c.updateCampaignSendJob(ctx, jobID, sendJobActionRevert)
+}
+
+func (c *Client) updateCampaignSendJob(ctx context.Context, jobID, action string) error {
+	request := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": campaignSendJobType,
+			"id":   jobID,
+			"attributes": map[string]interface{}{
+				"action": action,
+			},
+		},
+	}
+
+	endpoint := path.Join(campaignSendJobsPath, jobID)
+	return c.doReq(ctx, OperationUpdateCampaignSendJob, http.MethodPatch, endpoint, nil, request, nil)
+}
+
+// WaitForCampaignSendJob polls the campaign send job with the given ID until its status is final
+// (complete or cancelled) and returns the job, e.g. to confirm that a cancellation took effect. The first wait
+// between the polls is interval, at least a second, doubling after every poll up to the cap set with WithMaxWaitInterval;
+// WithWaitProgress reports every polled job.
+// It returns early if the context is done or polling fails, together with the last polled job, if any;
+// if the context is done, the error is an *ErrWaitInterrupted wrapping the error of the context.
+func (c *Client) WaitForCampaignSendJob(ctx context.Context, jobID string, interval time.Duration, opts ...WaitOption) (*campaign.SendJob, error) {
+	return poll(ctx, c.options.clock, interval, opts, func(ctx context.Context) (*campaign.SendJob, error) {
+		return c.GetCampaignSendJob(ctx, jobID)
+	}, func(job *campaign.SendJob) bool {
+		return job.Attributes.Status.IsFinal()
+	})
+}
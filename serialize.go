@@ -0,0 +1,141 @@
+package klaviyo
+
+import (
+	"reflect"
+)
+
+// propertySerializer converts a property value of a user type to a value Klaviyo understands.
+type propertySerializer func(interface{}) (interface{}, error)
+
+// WithPropertySerializer registers a serializer for profile property values of the type T, e.g. decimal.Decimal
+// or a custom enum, so they don't need to be converted to primitive types before calling the client:
+//
+//	klaviyo.WithPropertySerializer(func(d decimal.Decimal) (interface{}, error) {
+//		return d.InexactFloat64(), nil
+//	})
+//
+// Serializers apply to values of exactly the type T, including values nested in maps and slices of any type,
+// e.g. []decimal.Decimal or map[string]MyEnum.
+// If a serializer fails, the request fails with ErrInvalidPropertyValue.
+func WithPropertySerializer[T any](serialize func(T) (interface{}, error)) Option {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return OptionFunc(func(o *Options) {
+		if o.serializers == nil {
+			o.serializers = make(map[reflect.Type]propertySerializer)
+		}
+		o.serializers[t] = func(v interface{}) (interface{}, error) {
+			return serialize(v.(T))
+		}
+	})
+}
+
+// serializeProperties returns a copy of the properties with the values of the types having registered serializers
+// serialized and float values formatted as plain decimal numbers.
+func (c *Client) serializeProperties(properties map[string]interface{}) (map[string]interface{}, error) {
+	if properties == nil {
+		return nil, nil
+	}
+
+	serialized := make(map[string]interface{}, len(properties))
+	for k, v := range properties {
+		sv, err := c.serializeValue(k, v)
+		if err != nil {
+			return nil, err
+		}
+		serialized[k] = sv
+	}
+	return serialized, nil
+}
+
+func (c *Client) serializeValue(key string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		serialized := make(map[string]interface{}, len(v))
+		for k, nested := range v {
+			sv, err := c.serializeValue(key+"."+k, nested)
+			if err != nil {
+				return nil, err
+			}
+			serialized[k] = sv
+		}
+		return serialized, nil
+	case []interface{}:
+		serialized := make([]interface{}, len(v))
+		for i, nested := range v {
+			sv, err := c.serializeValue(key, nested)
+			if err != nil {
+				return nil, err
+			}
+			serialized[i] = sv
+		}
+		return serialized, nil
+	}
+
+	serialize, ok := c.options.serializers[reflect.TypeOf(value)]
+	if !ok {
+		return c.serializeCollection(key, value)
+	}
+	sv, err := serialize(value)
+	if err != nil {
+		return nil, &ErrInvalidPropertyValue{Key: key, Reason: err.Error()}
+	}
+	return sv, nil
+}
+
+// serializeCollection serializes the elements of typed slices, arrays and maps with string keys,
+// e.g. []decimal.Decimal or map[string]MyEnum, and formats any other value as a plain decimal number if it's a float.
+// Collections whose elements need no serialization are returned as they are.
+func (c *Client) serializeCollection(key string, value interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() || !c.needsSerialization(rv.Type().Elem()) {
+			return value, nil
+		}
+		serialized := make([]interface{}, rv.Len())
+		for i := range serialized {
+			sv, err := c.serializeValue(key, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			serialized[i] = sv
+		}
+		return serialized, nil
+	case reflect.Map:
+		if rv.IsNil() || rv.Type().Key().Kind() != reflect.String || !c.needsSerialization(rv.Type().Elem()) {
+			// maps with non-string keys are reported by the property validation
+			return value, nil
+		}
+		serialized := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			sv, err := c.serializeValue(key+"."+k, iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			serialized[k] = sv
+		}
+		return serialized, nil
+	}
+
+	dv, _ := c.decimalValue(value)
+	return dv, nil
+}
+
+// needsSerialization reports whether values of the type may be changed by serializeValue, i.e. unless they are
+// strings, booleans, integers or byte slices without a registered serializer.
+func (c *Client) needsSerialization(t reflect.Type) bool {
+	if _, ok := c.options.serializers[t]; ok {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return false
+	}
+	return true
+}
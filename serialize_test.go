@@ -0,0 +1,105 @@
+package klaviyo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/property"
+)
+
+type cents int64
+
+type tier int
+
+const (
+	tierSilver tier = iota + 1
+	tierGold
+)
+
+func TestWithPropertySerializer(t *testing.T) {
+	serializers := []klaviyo.Option{
+		klaviyo.WithPropertySerializer(func(c cents) (interface{}, error) {
+			return fmt.Sprintf("%d.%02d", c/100, c%100), nil
+		}),
+		klaviyo.WithPropertySerializer(func(t tier) (interface{}, error) {
+			switch t {
+			case tierSilver:
+				return "silver", nil
+			case tierGold:
+				return "gold", nil
+			}
+			return nil, errors.New("unknown tier")
+		}),
+	}
+
+	t.Run("custom types are serialized", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, serializers...)
+
+		_, err := kc.UpdateProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			profile.WithProperties(
+				property.WithValue("balance", cents(150099)),
+				property.WithValue("loyalty", map[string]interface{}{"tier": tierGold, "history": []interface{}{tierSilver}}),
+			),
+		)
+
+		require.NoError(t, err)
+		require.Contains(t, body, `"properties":{"balance":"1500.99","loyalty":{"history":["silver"],"tier":"gold"}}`)
+	})
+
+	t.Run("typed slices and maps are walked", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, serializers...)
+
+		_, err := kc.UpdateProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ",
+			profile.WithProperties(
+				property.WithValue("payments", []cents{1050, 99}),
+				property.WithValue("tiers", map[string]tier{"2023": tierSilver, "2024": tierGold}),
+				property.WithValue("tags", []string{"vip"}),
+			),
+		)
+
+		require.NoError(t, err)
+		require.Contains(t, body, `"properties":{"payments":["10.50","0.99"],"tags":["vip"],"tiers":{"2023":"silver","2024":"gold"}}`)
+	})
+
+	t.Run("failed serialization fails the request", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request must not be sent")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, serializers...)
+
+		props := map[string]interface{}{"tier": tier(7)}
+		_, err := kc.CreateProfile(context.TODO(), &profile.NewProfile{
+			Attributes: profile.NewAttributes{Email: "sarah.mason@klaviyo-demo.com", Properties: props},
+		})
+
+		var e *klaviyo.ErrInvalidPropertyValue
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "tier", e.Key)
+		require.Equal(t, tier(7), props["tier"], "properties must not be modified")
+	})
+}
@@ -0,0 +1,77 @@
+package klaviyo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/monetha/go-klaviyo/models/event"
+	"github.com/monetha/go-klaviyo/models/list"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/segment"
+	"github.com/monetha/go-klaviyo/operations/getevents"
+)
+
+// snapshotConcurrency is the maximum number of requests performed concurrently by GetProfileSnapshot.
+const snapshotConcurrency = 2
+
+// ProfileSnapshot holds a profile together with its recent events and the lists and segments it belongs to.
+type ProfileSnapshot struct {
+	Profile  *profile.ExistingProfile
+	Events   []*event.ExistingEvent
+	Lists    []*list.ExistingList
+	Segments []*segment.ExistingSegment
+}
+
+// GetProfileSnapshot retrieves the profile with the given ID, its most recent events (the first page of
+// GetProfileEvents with the given parameters) and the lists and segments it belongs to, e.g. to render
+// a customer overview. At most snapshotConcurrency requests are performed concurrently.
+// If any of the requests fail, all the errors are returned and the snapshot is nil.
+func (c *Client) GetProfileSnapshot(ctx context.Context, profileID string, eventParams ...getevents.Param) (*ProfileSnapshot, error) {
+	var (
+		snapshot ProfileSnapshot
+		mu       sync.Mutex
+		errs     *multierror.Error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, snapshotConcurrency)
+
+	fetch := func(f func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := f(); err != nil {
+				mu.Lock()
+				errs = multierror.Append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch(func() (err error) {
+		snapshot.Profile, err = c.GetProfile(ctx, profileID)
+		return err
+	})
+	fetch(func() (err error) {
+		snapshot.Events, err = c.GetProfileEvents(ctx, profileID, eventParams...)
+		return err
+	})
+	fetch(func() (err error) {
+		snapshot.Lists, err = c.GetProfileLists(ctx, profileID)
+		return err
+	})
+	fetch(func() (err error) {
+		snapshot.Segments, err = c.GetProfileSegments(ctx, profileID)
+		return err
+	})
+	wg.Wait()
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
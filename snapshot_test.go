@@ -0,0 +1,72 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_GetProfileSnapshot(t *testing.T) {
+	const profileID = "01H8HKMDG8F4MN7PSRZ4YQYNVQ"
+
+	responses := map[string]string{
+		"/api/profiles/" + profileID:               `{"data":{"type":"profile","id":"` + profileID + `","attributes":{"email":"sarah.mason@klaviyo-demo.com"}}}`,
+		"/api/events":                              `{"data":[{"type":"event","id":"4vRpBT2BYkR","attributes":{}}],"links":{"next":null}}`,
+		"/api/profiles/" + profileID + "/lists":    `{"data":[{"type":"list","id":"Y6nRLr","attributes":{"name":"Newsletter"}}],"links":{"next":null}}`,
+		"/api/profiles/" + profileID + "/segments": `{"data":[{"type":"segment","id":"UTd5ui","attributes":{"name":"VIP"}}],"links":{"next":null}}`,
+	}
+
+	t.Run("all parts are fetched", func(t *testing.T) {
+		var (
+			mu     sync.Mutex
+			filter string
+		)
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, ok := responses[req.URL.Path]
+			require.True(t, ok, req.URL.Path)
+			if req.URL.Path == "/api/events" {
+				mu.Lock()
+				filter = req.URL.Query().Get("filter")
+				mu.Unlock()
+			}
+			return jsonResponse(http.StatusOK, body), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		s, err := kc.GetProfileSnapshot(context.TODO(), profileID)
+		require.NoError(t, err)
+		require.Equal(t, profileID, s.Profile.Id)
+		require.Len(t, s.Events, 1)
+		require.Equal(t, "4vRpBT2BYkR", s.Events[0].ID)
+		require.Len(t, s.Lists, 1)
+		require.Equal(t, "Newsletter", s.Lists[0].Attributes.Name)
+		require.Len(t, s.Segments, 1)
+		require.Equal(t, "UTd5ui", s.Segments[0].ID)
+		require.Equal(t, `equals(profile_id,"`+profileID+`")`, filter)
+	})
+
+	t.Run("failure of a part fails the snapshot", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/profiles/"+profileID+"/segments" {
+				return jsonResponse(http.StatusForbidden, `{"errors":[{"id":"e1","status":403,"code":"permission_denied","title":"You do not have permission to perform this action.","detail":"This API key is missing required scopes."}]}`), nil
+			}
+			return jsonResponse(http.StatusOK, responses[req.URL.Path]), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		s, err := kc.GetProfileSnapshot(context.TODO(), profileID)
+
+		var e *klaviyo.ErrMissingScope
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, klaviyo.OperationGetProfileSegments, e.Operation)
+		require.Nil(t, s)
+	})
+}
@@ -0,0 +1,121 @@
+package klaviyo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StrictDecoding defines how the client treats fields of responses that the models don't capture.
+type StrictDecoding int
+
+const (
+	// StrictDecodingOff ignores unknown fields, as encoding/json does.
+	StrictDecodingOff StrictDecoding = iota
+	// StrictDecodingWarn logs a warning for responses with unknown fields and decodes them as usual.
+	StrictDecodingWarn
+	// StrictDecodingError fails the request with ErrUnknownField if the response has unknown fields.
+	StrictDecodingError
+)
+
+// ignoredResponseFields are the JSON:API members of responses that the models deliberately don't capture.
+var ignoredResponseFields = []string{"type", "links", "relationships", "included", "meta"}
+
+// ErrUnknownField indicates that a response has a field the models don't capture, e.g. because Klaviyo
+// added or renamed a field. It is only returned in the StrictDecodingError mode.
+type ErrUnknownField struct {
+	Operation Operation
+	Field     string
+}
+
+// Error returns a string representation of the ErrUnknownField error.
+// It conforms to the error interface.
+func (e *ErrUnknownField) Error() string {
+	return fmt.Sprintf("klaviyo: response of %s has unknown field %q", e.Operation, e.Field)
+}
+
+// checkUnknownFields decodes the body again, disallowing unknown fields, and reports the first unknown field
+// according to the configured strict decoding. The JSON:API members listed in ignoredResponseFields are not reported.
+func (c *Client) checkUnknownFields(op Operation, body []byte, result interface{}) error {
+	mode := c.options.strictDecoding
+	if mode == StrictDecodingOff {
+		return nil
+	}
+
+	field := unknownField(body, result)
+	if field == "" {
+		return nil
+	}
+	if mode == StrictDecodingError {
+		return &ErrUnknownField{Operation: op, Field: field}
+	}
+
+	c.logger.Warn("unknown field in response",
+		LogFieldOperation, op,
+		"field", field,
+	)
+	return nil
+}
+
+// strictResulter is implemented by the results decoded leniently, e.g. record by record,
+// to provide the value the strict decoding checks the response against instead.
+type strictResulter interface {
+	strictResult() interface{}
+}
+
+// unknownField returns the name of the first field of the body that the type of result doesn't capture,
+// or an empty string if there is none.
+func unknownField(body []byte, result interface{}) string {
+	var tree interface{}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return ""
+	}
+	stripped, err := json.Marshal(stripFields(tree))
+	if err != nil {
+		return ""
+	}
+
+	target := reflect.New(reflect.TypeOf(result).Elem()).Interface()
+	if r, ok := result.(strictResulter); ok {
+		target = r.strictResult()
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(stripped))
+	dec.DisallowUnknownFields()
+	err = dec.Decode(target)
+	if err == nil {
+		return ""
+	}
+
+	const prefix = "json: unknown field "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return ""
+	}
+	field, uerr := strconv.Unquote(strings.TrimPrefix(msg, prefix))
+	if uerr != nil {
+		return strings.TrimPrefix(msg, prefix)
+	}
+	return field
+}
+
+// stripFields removes the ignored JSON:API members from the decoded JSON value recursively.
+func stripFields(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range ignoredResponseFields {
+			delete(v, name)
+		}
+		for k, nested := range v {
+			v[k] = stripFields(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = stripFields(nested)
+		}
+	}
+	return v
+}
@@ -0,0 +1,54 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	t.Run("recorded profile has no unknown fields", func(t *testing.T) {
+		withHTTPRecorder("tests/get_existing_profile_valid_api_key", func(c *http.Client) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithStrictDecoding(klaviyo.StrictDecodingError))
+
+			_, err := kc.GetProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ")
+			require.NoError(t, err)
+		})
+	})
+
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01H8HKMDG8F4MN7PSRZ4YQYNVQ","attributes":{"email":"sarah.mason@klaviyo-demo.com","locale":"en-US"},"links":{"self":"https://a.klaviyo.com/api/profiles/01H8HKMDG8F4MN7PSRZ4YQYNVQ/"}}}`), nil
+	})}
+
+	t.Run("unknown field fails the request in error mode", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithStrictDecoding(klaviyo.StrictDecodingError))
+
+		p, err := kc.GetProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ")
+
+		var e *klaviyo.ErrUnknownField
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, "locale", e.Field)
+		require.Equal(t, klaviyo.OperationGetProfile, e.Operation)
+		require.Nil(t, p)
+	})
+
+	t.Run("unknown field is logged in warning mode", func(t *testing.T) {
+		core, logs := observer.New(zapcore.WarnLevel)
+		kc := klaviyo.NewWithClient(validAPIKey, zap.New(core), c, klaviyo.WithStrictDecoding(klaviyo.StrictDecodingWarn))
+
+		p, err := kc.GetProfile(context.TODO(), "01H8HKMDG8F4MN7PSRZ4YQYNVQ")
+
+		require.NoError(t, err)
+		require.Equal(t, "sarah.mason@klaviyo-demo.com", p.Attributes.Email)
+		entries := logs.FilterMessage("unknown field in response").All()
+		require.Len(t, entries, 1)
+		require.Equal(t, "locale", entries[0].ContextMap()["field"])
+	})
+}
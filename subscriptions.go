@@ -0,0 +1,144 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	subscriptionCreateJobsPath = "profile-subscription-bulk-create-jobs"
+	subscriptionDeleteJobsPath = "profile-subscription-bulk-delete-jobs"
+
+	// maxSubscriptionProfiles is the maximum number of profiles subscribed or unsubscribed by a single job.
+	maxSubscriptionProfiles = 100
+)
+
+// Channel is a marketing channel a profile consents to.
+type Channel string
+
+// Marketing channels.
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+// Subscription is the marketing consent of a profile, identified by its email address, phone number or both.
+type Subscription struct {
+	Email string
+	// PhoneNumber is the phone number in E.164 format, e.g. "+15005550006".
+	PhoneNumber string
+	// ProfileID is the ID of the profile, if it's known.
+	ProfileID string
+	// Channels are the channels of the consent. If empty, the consent applies to the email marketing
+	// if Email is set and to the SMS marketing if PhoneNumber is set.
+	Channels []Channel
+}
+
+// channels returns the channels of the subscription.
+func (s Subscription) channels() []Channel {
+	if len(s.Channels) > 0 {
+		return s.Channels
+	}
+	var channels []Channel
+	if s.Email != "" {
+		channels = append(channels, ChannelEmail)
+	}
+	if s.PhoneNumber != "" {
+		channels = append(channels, ChannelSMS)
+	}
+	return channels
+}
+
+// ErrInvalidSubscription indicates that a subscription can't be submitted, e.g. because it consents
+// to the SMS marketing without a phone number.
+type ErrInvalidSubscription struct {
+	// Index is the index of the subscription in the submitted subscriptions.
+	Index  int
+	Reason string
+}
+
+// Error returns a string representation of the ErrInvalidSubscription error.
+// It conforms to the error interface.
+func (e *ErrInvalidSubscription) Error() string {
+	return fmt.Sprintf("klaviyo: invalid subscription %d: %s", e.Index, e.Reason)
+}
+
+// SubscribeProfiles records the marketing consent of the profiles, e.g. once a double opt-in is confirmed,
+// creating the profiles that don't exist. If listID isn't empty, the profiles are also added to the list,
+// and a list with double opt-in enabled sends the confirmation messages. Klaviyo processes the subscriptions
+// asynchronously. The subscriptions are checked before any job is created and *ErrInvalidSubscription
+// is returned for the first invalid one. They are submitted in jobs of at most 100 profiles; if some of
+// the jobs can't be created, a *PartialError is returned, whose failed steps delimit the subscriptions
+// of the failed jobs as subscriptions[Start:End].
+func (c *Client) SubscribeProfiles(ctx context.Context, listID string, subscriptions ...Subscription) error {
+	return c.subscriptionJobs(ctx, OperationSubscribeProfiles, listID, subscriptions)
+}
+
+// UnsubscribeProfiles revokes the marketing consent of the profiles on the channels of the subscriptions.
+// If listID isn't empty, the profiles are unsubscribed from the list only. The subscriptions are checked
+// and submitted like by SubscribeProfiles.
+func (c *Client) UnsubscribeProfiles(ctx context.Context, listID string, subscriptions ...Subscription) error {
+	return c.subscriptionJobs(ctx, OperationUnsubscribeProfiles, listID, subscriptions)
+}
+
+// subscriptionJobs creates the subscription create or delete jobs of the subscriptions, depending on the operation.
+func (c *Client) subscriptionJobs(ctx context.Context, op Operation, listID string, subscriptions []Subscription) error {
+	entries := make([]map[string]interface{}, 0, len(subscriptions))
+	for i, s := range subscriptions {
+		entry, reason := subscriptionEntry(s)
+		if reason != "" {
+			return &ErrInvalidSubscription{Index: i, Reason: reason}
+		}
+		entries = append(entries, entry)
+	}
+
+	endpoint, jobType, name := subscriptionCreateJobsPath, "profile-subscription-bulk-create-job", "subscribe profiles"
+	if op == OperationUnsubscribeProfiles {
+		endpoint, jobType, name = subscriptionDeleteJobsPath, "profile-subscription-bulk-delete-job", "unsubscribe profiles"
+	}
+
+	return c.profileJobs(ctx, op, endpoint, name, len(entries), maxSubscriptionProfiles, func(start, end int) interface{} {
+		attributes := map[string]interface{}{"subscriptions": entries[start:end]}
+		if listID != "" {
+			attributes["list_id"] = listID
+		}
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"type":       jobType,
+				"attributes": attributes,
+			},
+		}
+	})
+}
+
+// subscriptionEntry returns the subscription as an entry of a subscription job, or the reason it's invalid.
+func subscriptionEntry(s Subscription) (map[string]interface{}, string) {
+	if s.Email == "" && s.PhoneNumber == "" {
+		return nil, "an email address or a phone number is required"
+	}
+
+	channels := make(map[string][]string)
+	for _, ch := range s.channels() {
+		switch {
+		case ch == ChannelEmail && s.Email == "":
+			return nil, "the email channel requires an email address"
+		case ch == ChannelSMS && s.PhoneNumber == "":
+			return nil, "the SMS channel requires a phone number"
+		case ch != ChannelEmail && ch != ChannelSMS:
+			return nil, fmt.Sprintf("unknown channel %q", ch)
+		}
+		channels[string(ch)] = []string{"MARKETING"}
+	}
+
+	entry := map[string]interface{}{"channels": channels}
+	if s.Email != "" {
+		entry["email"] = s.Email
+	}
+	if s.PhoneNumber != "" {
+		entry["phone_number"] = s.PhoneNumber
+	}
+	if s.ProfileID != "" {
+		entry["profile_id"] = s.ProfileID
+	}
+	return entry, ""
+}
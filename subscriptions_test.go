@@ -0,0 +1,130 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_SubscribeProfiles(t *testing.T) {
+	type job struct {
+		Data struct {
+			Type       string `json:"type"`
+			Attributes struct {
+				ListID        string `json:"list_id"`
+				Subscriptions []struct {
+					Channels    map[string][]string `json:"channels"`
+					Email       string              `json:"email"`
+					PhoneNumber string              `json:"phone_number"`
+					ProfileID   string              `json:"profile_id"`
+				} `json:"subscriptions"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+
+	t.Run("subscribe profiles to a list", func(t *testing.T) {
+		var j job
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, "/api/profile-subscription-bulk-create-jobs", req.URL.Path)
+			body, _ := io.ReadAll(req.Body)
+			require.NoError(t, json.Unmarshal(body, &j))
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		err := kc.SubscribeProfiles(context.TODO(), "Y6nRLr",
+			klaviyo.Subscription{Email: "sarah.mason@klaviyo-demo.com", PhoneNumber: "+15005550006"},
+			klaviyo.Subscription{Email: "john.doe@klaviyo-demo.com", PhoneNumber: "+15005550007", ProfileID: "01GDDKASAP8TKDDA2GRZDSVP4H", Channels: []klaviyo.Channel{klaviyo.ChannelEmail}},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "profile-subscription-bulk-create-job", j.Data.Type)
+		require.Equal(t, "Y6nRLr", j.Data.Attributes.ListID)
+		require.Len(t, j.Data.Attributes.Subscriptions, 2)
+
+		s := j.Data.Attributes.Subscriptions[0]
+		require.Equal(t, map[string][]string{"email": {"MARKETING"}, "sms": {"MARKETING"}}, s.Channels)
+		require.Equal(t, "+15005550006", s.PhoneNumber)
+
+		s = j.Data.Attributes.Subscriptions[1]
+		require.Equal(t, map[string][]string{"email": {"MARKETING"}}, s.Channels)
+		require.Equal(t, "01GDDKASAP8TKDDA2GRZDSVP4H", s.ProfileID)
+	})
+
+	t.Run("unsubscribe profiles without a list", func(t *testing.T) {
+		var body string
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/api/profile-subscription-bulk-delete-jobs", req.URL.Path)
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		err := kc.UnsubscribeProfiles(context.TODO(), "", klaviyo.Subscription{PhoneNumber: "+15005550006"})
+		require.NoError(t, err)
+		require.Contains(t, body, `"type":"profile-subscription-bulk-delete-job"`)
+		require.Contains(t, body, `"channels":{"sms":["MARKETING"]}`)
+		require.NotContains(t, body, "list_id")
+	})
+
+	t.Run("invalid subscription fails before any job is created", func(t *testing.T) {
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request")
+			return nil, nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		err := kc.SubscribeProfiles(context.TODO(), "Y6nRLr",
+			klaviyo.Subscription{Email: "sarah.mason@klaviyo-demo.com"},
+			klaviyo.Subscription{Email: "john.doe@klaviyo-demo.com", Channels: []klaviyo.Channel{klaviyo.ChannelSMS}},
+		)
+
+		var e *klaviyo.ErrInvalidSubscription
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, 1, e.Index)
+		require.Equal(t, "the SMS channel requires a phone number", e.Reason)
+	})
+
+	t.Run("a failed job doesn't stop the next ones", func(t *testing.T) {
+		subscriptions := make([]klaviyo.Subscription, 0, 250)
+		for i := 0; i < 250; i++ {
+			subscriptions = append(subscriptions, klaviyo.Subscription{Email: fmt.Sprintf("user%03d@klaviyo-demo.com", i)})
+		}
+
+		calls := 0
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"e1","status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid email address."}]}`), nil
+			}
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		err := kc.SubscribeProfiles(context.TODO(), "Y6nRLr", subscriptions...)
+
+		require.Equal(t, 3, calls)
+		var e *klaviyo.PartialError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Steps, 3)
+		failed := e.Failed()
+		require.Len(t, failed, 1)
+		require.Equal(t, "subscribe profiles 0-99", failed[0].Name)
+		require.Equal(t, subscriptions[:100], subscriptions[failed[0].Start:failed[0].End])
+		require.Equal(t, 200, e.Steps[2].Start)
+		require.Equal(t, 250, e.Steps[2].End)
+	})
+}
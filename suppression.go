@@ -0,0 +1,91 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	suppressionCreateJobsPath = "profile-suppression-bulk-create-jobs"
+	suppressionDeleteJobsPath = "profile-suppression-bulk-delete-jobs"
+
+	// maxSuppressionEmails is the maximum number of email addresses suppressed or unsuppressed by a single job.
+	maxSuppressionEmails = 100
+)
+
+// SuppressProfiles suppresses the profiles with the given email addresses from receiving email marketing,
+// e.g. to honor an unsubscribe request received by a compliance tool. Profiles that don't exist are created
+// as suppressed. Klaviyo processes the suppressions asynchronously.
+// The emails are submitted in jobs of at most 100 addresses; if some of the jobs can't be created,
+// a *PartialError is returned, whose failed steps delimit the emails of the failed jobs.
+func (c *Client) SuppressProfiles(ctx context.Context, emails ...string) error {
+	return c.suppressionJobs(ctx, OperationSuppressProfiles, emails)
+}
+
+// UnsuppressProfiles removes the manual suppressions of the profiles with the given email addresses,
+// so they can receive email marketing again. Suppressions caused by bounces or spam complaints are not removed.
+// Klaviyo processes the removals asynchronously. The emails are submitted in jobs like by SuppressProfiles.
+func (c *Client) UnsuppressProfiles(ctx context.Context, emails ...string) error {
+	return c.suppressionJobs(ctx, OperationUnsuppressProfiles, emails)
+}
+
+// suppressionJobs creates the suppression create or delete jobs of the emails, depending on the operation.
+func (c *Client) suppressionJobs(ctx context.Context, op Operation, emails []string) error {
+	endpoint, jobType, name := suppressionCreateJobsPath, "profile-suppression-bulk-create-job", "suppress profiles"
+	if op == OperationUnsuppressProfiles {
+		endpoint, jobType, name = suppressionDeleteJobsPath, "profile-suppression-bulk-delete-job", "unsuppress profiles"
+	}
+
+	return c.profileJobs(ctx, op, endpoint, name, len(emails), maxSuppressionEmails, func(start, end int) interface{} {
+		suppressions := make([]map[string]string, 0, end-start)
+		for _, email := range emails[start:end] {
+			suppressions = append(suppressions, map[string]string{"email": email})
+		}
+		return map[string]interface{}{
+			"data": map[string]interface{}{
+				"type":       jobType,
+				"attributes": map[string]interface{}{"suppressions": suppressions},
+			},
+		}
+	})
+}
+
+// profileJobs creates the jobs of the operation for n profiles, in chunks of at most size profiles.
+// The request creating the job of the profiles [start, end) is built by request. The jobs are independent,
+// so a failed job doesn't stop the next ones. If some of the jobs can't be created, a *PartialError is returned,
+// with a step per job delimiting its profiles; the jobs are idempotent, so the failed ones can be retried.
+// If no job is created, the error of the first one is returned.
+func (c *Client) profileJobs(ctx context.Context, op Operation, endpoint, name string, n, size int, request func(start, end int) interface{}) error {
+	var (
+		steps    []StepResult
+		firstErr error
+		done     bool
+	)
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+
+		step := StepResult{Name: fmt.Sprintf("%s %d-%d", name, start, end-1), Start: start, End: end}
+		if err := c.doReq(ctx, op, http.MethodPost, endpoint, nil, request(start, end), nil); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			step.Err, step.Retryable = err, true
+		} else {
+			step.Done, done = true, true
+		}
+		steps = append(steps, step)
+	}
+
+	switch {
+	case firstErr == nil:
+		return nil
+	case !done:
+		return firstErr
+	default:
+		return &PartialError{Operation: name, Steps: steps}
+	}
+}
@@ -0,0 +1,92 @@
+package klaviyo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+)
+
+func TestClient_SuppressProfiles(t *testing.T) {
+	type job struct {
+		Data struct {
+			Type       string `json:"type"`
+			Attributes struct {
+				Suppressions []struct {
+					Email string `json:"email"`
+				} `json:"suppressions"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+
+	emails := make([]string, 0, 150)
+	for i := 0; i < 150; i++ {
+		emails = append(emails, fmt.Sprintf("user%03d@klaviyo-demo.com", i))
+	}
+
+	t.Run("emails are submitted in jobs of at most 100", func(t *testing.T) {
+		var jobs []job
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, http.MethodPost, req.Method)
+			require.Equal(t, "/api/profile-suppression-bulk-create-jobs", req.URL.Path)
+			var j job
+			body, _ := io.ReadAll(req.Body)
+			require.NoError(t, json.Unmarshal(body, &j))
+			jobs = append(jobs, j)
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		require.NoError(t, kc.SuppressProfiles(context.TODO(), emails...))
+		require.Len(t, jobs, 2)
+		require.Equal(t, "profile-suppression-bulk-create-job", jobs[0].Data.Type)
+		require.Len(t, jobs[0].Data.Attributes.Suppressions, 100)
+		require.Len(t, jobs[1].Data.Attributes.Suppressions, 50)
+		require.Equal(t, "user149@klaviyo-demo.com", jobs[1].Data.Attributes.Suppressions[49].Email)
+	})
+
+	t.Run("unsuppression uses the delete jobs", func(t *testing.T) {
+		var j job
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/api/profile-suppression-bulk-delete-jobs", req.URL.Path)
+			body, _ := io.ReadAll(req.Body)
+			require.NoError(t, json.Unmarshal(body, &j))
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		require.NoError(t, kc.UnsuppressProfiles(context.TODO(), "sarah.mason@klaviyo-demo.com"))
+		require.Equal(t, "profile-suppression-bulk-delete-job", j.Data.Type)
+		require.Equal(t, "sarah.mason@klaviyo-demo.com", j.Data.Attributes.Suppressions[0].Email)
+	})
+
+	t.Run("failure of a later job is a partial error", func(t *testing.T) {
+		calls := 0
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 2 {
+				return jsonResponse(http.StatusBadRequest, `{"errors":[{"id":"e1","status":400,"code":"invalid","title":"Invalid input.","detail":"Invalid email address."}]}`), nil
+			}
+			return jsonResponse(http.StatusAccepted, ""), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		err := kc.SuppressProfiles(context.TODO(), emails...)
+
+		var e *klaviyo.PartialError
+		require.ErrorAs(t, err, &e)
+		require.Len(t, e.Failed(), 1)
+		require.Equal(t, "suppress profiles 100-149", e.Failed()[0].Name)
+		require.Equal(t, emails[100:], emails[e.Failed()[0].Start:e.Failed()[0].End])
+	})
+}
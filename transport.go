@@ -0,0 +1,28 @@
+package klaviyo
+
+import (
+	"net/http"
+)
+
+// transport is the boundary between the client and the HTTP layer: it executes a request of the operation,
+// including the retries of the HTTP client, and returns the response with its body read into memory,
+// or a typed error, e.g. *ErrRateLimited. The higher-level subsystems (decoding, pagination, coalescing,
+// composite writes) are built on top of it, so it can be swapped to inject faults into all of them.
+type transport interface {
+	roundTrip(op Operation, req *http.Request) (*rawResponse, error)
+}
+
+// transportFunc is an adapter to allow the use of ordinary functions as transports.
+type transportFunc func(op Operation, req *http.Request) (*rawResponse, error)
+
+// roundTrip calls f(op, req).
+func (f transportFunc) roundTrip(op Operation, req *http.Request) (*rawResponse, error) {
+	return f(op, req)
+}
+
+// rawResponse holds a response with its body read into memory.
+type rawResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
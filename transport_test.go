@@ -0,0 +1,138 @@
+package klaviyo_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+// profilesServer serves two pages of profiles.
+var profilesServer = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+	if req.URL.Query().Get("page[cursor]") == "" {
+		return jsonResponse(http.StatusOK, `{"data":[{"id":"1"},{"id":"2"}],"links":{"next":"https://a.klaviyo.com/api/profiles/?page%5Bcursor%5D=page2"}}`), nil
+	}
+	return jsonResponse(http.StatusOK, `{"data":[{"id":"3"}],"links":{"next":null}}`), nil
+})
+
+func TestTransport_Faults(t *testing.T) {
+	t.Run("paginator survives a 429 storm", func(t *testing.T) {
+		clk := clock.NewManual(time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC))
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{Transport: profilesServer},
+			klaviyo.WithClock(clk), klaviyo.WithPageRetries(3))
+
+		storm := 3
+		klaviyo.SetTransport(kc, func(next klaviyo.TransportFunc) klaviyo.TransportFunc {
+			return func(op klaviyo.Operation, req *http.Request) (int, http.Header, []byte, error) {
+				if storm > 0 {
+					storm--
+					return 0, nil, nil, &klaviyo.ErrRateLimited{RetryAfter: time.Second}
+				}
+				return next(op, req)
+			}
+		})
+
+		paginator := kc.NewProfilesPaginator()
+		done := make(chan error)
+		var ids []string
+		go func() {
+			for paginator.HasNext() {
+				ps, err := paginator.Next(context.TODO())
+				if err != nil {
+					done <- err
+					return
+				}
+				for _, p := range ps {
+					ids = append(ids, p.Id)
+				}
+			}
+			done <- nil
+		}()
+
+		for i := 0; i < 3; i++ {
+			waitForSleeper(t, clk)
+			clk.Advance(time.Second)
+		}
+		require.NoError(t, <-done)
+		require.Equal(t, []string{"1", "2", "3"}, ids)
+	})
+
+	t.Run("injected latency delays the operation", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{Transport: profilesServer})
+
+		var ops []klaviyo.Operation
+		klaviyo.SetTransport(kc, func(next klaviyo.TransportFunc) klaviyo.TransportFunc {
+			return func(op klaviyo.Operation, req *http.Request) (int, http.Header, []byte, error) {
+				ops = append(ops, op)
+				time.Sleep(10 * time.Millisecond)
+				return next(op, req)
+			}
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ps, err := kc.GetProfiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, ps, 2)
+		require.Equal(t, []klaviyo.Operation{klaviyo.OperationGetProfiles}, ops)
+	})
+
+	t.Run("truncated body is reported as a retryable error", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{Transport: profilesServer})
+
+		klaviyo.SetTransport(kc, func(next klaviyo.TransportFunc) klaviyo.TransportFunc {
+			return func(op klaviyo.Operation, req *http.Request) (int, http.Header, []byte, error) {
+				return 0, nil, nil, io.ErrUnexpectedEOF
+			}
+		})
+
+		_, err := kc.GetProfiles(context.TODO())
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		require.True(t, klaviyo.IsRetryable(err))
+	})
+
+	t.Run("truncated JSON of a successful response fails decoding", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{Transport: profilesServer})
+
+		klaviyo.SetTransport(kc, func(next klaviyo.TransportFunc) klaviyo.TransportFunc {
+			return func(op klaviyo.Operation, req *http.Request) (int, http.Header, []byte, error) {
+				statusCode, header, body, err := next(op, req)
+				return statusCode, header, body[:len(body)/2], err
+			}
+		})
+
+		_, err := kc.GetProfiles(context.TODO())
+		require.Error(t, err)
+	})
+}
+
+func TestTransport_ContentType(t *testing.T) {
+	var methods, contentTypes []string
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			return profilesServer(req)
+		}
+		return jsonResponse(http.StatusNoContent, ""), nil
+	})})
+	klaviyo.SetTransport(kc, func(next klaviyo.TransportFunc) klaviyo.TransportFunc {
+		return func(op klaviyo.Operation, req *http.Request) (int, http.Header, []byte, error) {
+			methods = append(methods, req.Method)
+			contentTypes = append(contentTypes, req.Header.Get("Content-Type"))
+			return next(op, req)
+		}
+	})
+
+	require.NoError(t, kc.RemoveProfilesFromList(context.TODO(), "Y6nRLr", []string{"01H8HKMDG8F4MN7PSRZ4YQYNVQ"}))
+	_, err := kc.GetProfiles(context.TODO())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{http.MethodDelete, http.MethodGet}, methods)
+	require.Equal(t, []string{"application/json", ""}, contentTypes, "only requests with a body have a content type")
+}
@@ -0,0 +1,177 @@
+package klaviyo
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+// RateLimit holds the rate limit state of an operation as reported by the RateLimit-* headers
+// of the last response that carried them.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time until the current window resets.
+	Reset time.Duration
+	// MinRemaining is the lowest Remaining value observed since the client was created.
+	MinRemaining int
+	// ObservedAt is the time the headers were received.
+	ObservedAt time.Time
+}
+
+// OperationUsage holds the API usage of an operation since the client was created.
+type OperationUsage struct {
+	Operation Operation
+	// Requests is the number of responses received, including the ones of retried attempts.
+	Requests uint64
+	// Throttled is the number of responses with the 429 Too Many Requests status.
+	Throttled uint64
+	// RateLimit is nil if no response of the operation carried rate limit headers.
+	RateLimit *RateLimit
+}
+
+// UsageStats holds the API usage of the client since Since, aggregated from the responses received.
+// Klaviyo doesn't expose account-level API call counters, so usage of other clients sharing the
+// same API key is not included.
+type UsageStats struct {
+	Since      time.Time
+	Requests   uint64
+	Throttled  uint64
+	Operations []OperationUsage
+}
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type operationContextKey struct{}
+
+// withOperation returns a copy of the context that carries the operation, so the usage of every
+// attempt of a retried request is attributed to it.
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, op)
+}
+
+// usageTracker aggregates the API usage per operation.
+type usageTracker struct {
+	clock clock.Clock
+	since time.Time
+
+	mu  sync.Mutex
+	ops map[Operation]*OperationUsage
+}
+
+func newUsageTracker(clk clock.Clock) *usageTracker {
+	return &usageTracker{
+		clock: clk,
+		since: clk.Now(),
+		ops:   make(map[Operation]*OperationUsage),
+	}
+}
+
+// instrument returns a shallow copy of the HTTP client with the transport wrapped to record
+// the usage of every response received, including the ones of retried attempts. Responses of transports
+// that don't set their request get it, so that the backoff of the retries can wait on the context of the request.
+func (t *usageTracker) instrument(httpClient *http.Client) *http.Client {
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	hc := *httpClient
+	hc.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := next.RoundTrip(req)
+		if err == nil {
+			if resp.Request == nil {
+				resp.Request = req
+			}
+			t.observe(req, resp)
+		}
+		return resp, err
+	})
+	return &hc
+}
+
+// observe records the usage of a response.
+func (t *usageTracker) observe(req *http.Request, resp *http.Response) {
+	op, _ := OperationFromContext(req.Context())
+	rl, hasRateLimit := parseRateLimit(resp.Header)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.ops[op]
+	if !ok {
+		u = &OperationUsage{Operation: op}
+		t.ops[op] = u
+	}
+	u.Requests++
+	if resp.StatusCode == http.StatusTooManyRequests {
+		u.Throttled++
+	}
+	if hasRateLimit {
+		rl.MinRemaining = rl.Remaining
+		if u.RateLimit != nil && u.RateLimit.MinRemaining < rl.MinRemaining {
+			rl.MinRemaining = u.RateLimit.MinRemaining
+		}
+		rl.ObservedAt = t.clock.Now()
+		u.RateLimit = &rl
+	}
+}
+
+// stats returns a snapshot of the usage with the operations sorted.
+func (t *usageTracker) stats() UsageStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := UsageStats{
+		Since:      t.since,
+		Operations: make([]OperationUsage, 0, len(t.ops)),
+	}
+	for _, u := range t.ops {
+		ou := *u
+		if u.RateLimit != nil {
+			rl := *u.RateLimit
+			ou.RateLimit = &rl
+		}
+		s.Requests += u.Requests
+		s.Throttled += u.Throttled
+		s.Operations = append(s.Operations, ou)
+	}
+	sort.Slice(s.Operations, func(i, j int) bool { return s.Operations[i].Operation < s.Operations[j].Operation })
+	return s
+}
+
+// parseRateLimit parses the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+// It returns false if the limit or the remaining count is missing or malformed.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+	if reset, err := strconv.Atoi(header.Get("RateLimit-Reset")); err == nil && reset >= 0 {
+		rl.Reset = time.Duration(reset) * time.Second
+	}
+	return rl, true
+}
+
+// UsageStats returns the API usage of the client since it was created: the number of responses and throttled
+// responses per operation and the last rate limit state reported by the API, e.g. for capacity planning.
+func (c *Client) UsageStats() UsageStats {
+	return c.usage.stats()
+}
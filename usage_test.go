@@ -0,0 +1,59 @@
+package klaviyo_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+func TestClient_UsageStats(t *testing.T) {
+	var attempt int
+	c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempt++
+		switch attempt {
+		case 1:
+			resp := jsonResponse(http.StatusTooManyRequests, `{"errors":[]}`)
+			resp.Header.Set("Retry-After", "0")
+			resp.Header.Set("RateLimit-Limit", "75")
+			resp.Header.Set("RateLimit-Remaining", "0")
+			resp.Header.Set("RateLimit-Reset", "12")
+			return resp, nil
+		default:
+			resp := jsonResponse(http.StatusOK, `{"data":[]}`)
+			resp.Header.Set("RateLimit-Limit", "75")
+			resp.Header.Set("RateLimit-Remaining", "74")
+			resp.Header.Set("RateLimit-Reset", "60")
+			return resp, nil
+		}
+	})}
+
+	now := time.Date(2024, 1, 30, 12, 0, 0, 0, time.UTC)
+	kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c, klaviyo.WithClock(clock.NewManual(now)))
+
+	_, err := kc.GetEvents(context.TODO())
+	require.NoError(t, err)
+
+	stats := kc.UsageStats()
+	require.Equal(t, now, stats.Since)
+	require.EqualValues(t, 2, stats.Requests)
+	require.EqualValues(t, 1, stats.Throttled)
+	require.Equal(t, []klaviyo.OperationUsage{{
+		Operation: klaviyo.OperationGetEvents,
+		Requests:  2,
+		Throttled: 1,
+		RateLimit: &klaviyo.RateLimit{
+			Limit:        75,
+			Remaining:    74,
+			Reset:        time.Minute,
+			MinRemaining: 0,
+			ObservedAt:   now,
+		},
+	}}, stats.Operations)
+}
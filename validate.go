@@ -0,0 +1,175 @@
+package klaviyo
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxPropertyDepth is the maximum nesting depth of property values accepted by the validation.
+const maxPropertyDepth = 10
+
+// PropertyValidation defines how the client treats property values that Klaviyo coerces badly.
+type PropertyValidation int
+
+const (
+	// PropertyValidationWarn logs a warning for every suspicious property value and sends the request.
+	PropertyValidationWarn PropertyValidation = iota
+	// PropertyValidationError fails the request with ErrInvalidPropertyValue or ErrPropertyLimitExceeded before it is sent.
+	PropertyValidationError
+	// PropertyValidationOff disables the validation.
+	PropertyValidationOff
+)
+
+// ErrInvalidPropertyValue indicates that a property value is of a type that Klaviyo coerces badly,
+// e.g. time.Time serialized without an explicit format, []byte, NaN or infinite floats,
+// or maps nested too deeply. Key is the dot-separated path of the property.
+type ErrInvalidPropertyValue struct {
+	Key    string
+	Reason string
+}
+
+// Error returns a string representation of the ErrInvalidPropertyValue error.
+// It conforms to the error interface.
+func (e *ErrInvalidPropertyValue) Error() string {
+	return fmt.Sprintf("klaviyo: invalid value of property %q: %s", e.Key, e.Reason)
+}
+
+// validateProperties checks the property values and the property limits according to the configured property validation.
+// In the warning mode, the problems are logged and nil is returned.
+func (c *Client) validateProperties(op Operation, properties map[string]interface{}) error {
+	mode := c.options.propertyValidation
+	if mode == PropertyValidationOff || len(properties) == 0 {
+		return nil
+	}
+
+	problems := checkProperties("", properties, 1)
+	limits := checkPropertyLimits(properties)
+	if mode == PropertyValidationError {
+		if len(problems) > 0 {
+			return problems[0]
+		}
+		if len(limits) > 0 {
+			return limits[0]
+		}
+		return nil
+	}
+
+	for _, p := range problems {
+		c.logger.Warn("suspicious property value",
+			LogFieldOperation, op,
+			"property", p.Key,
+			"reason", p.Reason,
+		)
+	}
+	for _, l := range limits {
+		c.logger.Warn("property limit exceeded",
+			LogFieldOperation, op,
+			"property", l.Key,
+			"limit", l.Limit,
+			"size", l.Size,
+			"max", l.Max,
+		)
+	}
+	return nil
+}
+
+// checkProperties returns the problems of the property values, sorted by the property key.
+func checkProperties(prefix string, properties map[string]interface{}, depth int) []*ErrInvalidPropertyValue {
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []*ErrInvalidPropertyValue
+	for _, k := range keys {
+		problems = append(problems, checkValue(prefix+k, properties[k], depth)...)
+	}
+	return problems
+}
+
+func checkValue(key string, value interface{}, depth int) []*ErrInvalidPropertyValue {
+	problem := func(format string, args ...interface{}) []*ErrInvalidPropertyValue {
+		return []*ErrInvalidPropertyValue{{Key: key, Reason: fmt.Sprintf(format, args...)}}
+	}
+
+	switch v := value.(type) {
+	case nil, string, bool:
+		return nil
+	case float64:
+		return checkFloat(key, v)
+	case float32:
+		return checkFloat(key, float64(v))
+	case time.Time, *time.Time:
+		return problem("time values are serialized without an explicit format, format them as strings")
+	case []byte:
+		return problem("byte slices are serialized as base64 strings")
+	case map[string]interface{}:
+		if depth >= maxPropertyDepth {
+			return problem("nested deeper than %d levels", maxPropertyDepth)
+		}
+		return checkProperties(key+".", v, depth+1)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nil
+	case reflect.String, reflect.Bool:
+		return nil
+	case reflect.Float32, reflect.Float64:
+		return checkFloat(key, rv.Float())
+	case reflect.Slice, reflect.Array:
+		var problems []*ErrInvalidPropertyValue
+		for i := 0; i < rv.Len(); i++ {
+			if isPrimitive(rv.Index(i)) {
+				// primitive elements are always valid, so they don't need a key
+				continue
+			}
+			problems = append(problems, checkValue(fmt.Sprintf("%s[%d]", key, i), rv.Index(i).Interface(), depth)...)
+		}
+		return problems
+	case reflect.Map:
+		if depth >= maxPropertyDepth {
+			return problem("nested deeper than %d levels", maxPropertyDepth)
+		}
+		if rv.Type().Key().Kind() != reflect.String {
+			return problem("maps with non-string keys are not supported")
+		}
+		nested := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			nested[iter.Key().String()] = iter.Value().Interface()
+		}
+		return checkProperties(key+".", nested, depth+1)
+	case reflect.Complex64, reflect.Complex128, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return problem("values of type %s can't be serialized", rv.Type())
+	}
+	return nil
+}
+
+func checkFloat(key string, f float64) []*ErrInvalidPropertyValue {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return []*ErrInvalidPropertyValue{{Key: key, Reason: strings.ToLower(fmt.Sprint(f)) + " can't be serialized"}}
+	}
+	return nil
+}
+
+// isPrimitive reports whether the value is nil, a string, a bool or an integer.
+func isPrimitive(v reflect.Value) bool {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Invalid, reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package klaviyo_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/monetha/go-klaviyo"
+	"github.com/monetha/go-klaviyo/models/profile"
+	"github.com/monetha/go-klaviyo/models/profile/property"
+)
+
+func TestWithPropertyValidation(t *testing.T) {
+	noRequests := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("request must not be sent")
+		return nil, nil
+	})}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		key   string
+	}{
+		{name: "NaN", value: math.NaN(), key: "score"},
+		{name: "infinity", value: math.Inf(1), key: "score"},
+		{name: "time", value: time.Now(), key: "score"},
+		{name: "bytes", value: []byte("abc"), key: "score"},
+		{name: "nested NaN", value: map[string]interface{}{"a": []interface{}{1, math.NaN()}}, key: "score.a[1]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" is rejected in error mode", func(t *testing.T) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), noRequests, klaviyo.WithPropertyValidation(klaviyo.PropertyValidationError))
+
+			p, err := kc.UpdateProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM",
+				profile.WithProperties(property.WithValue("score", tt.value)),
+			)
+
+			var e *klaviyo.ErrInvalidPropertyValue
+			require.ErrorAs(t, err, &e)
+			require.Equal(t, tt.key, e.Key)
+			require.Nil(t, p)
+		})
+	}
+
+	t.Run("deeply nested map is rejected in error mode", func(t *testing.T) {
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), noRequests, klaviyo.WithPropertyValidation(klaviyo.PropertyValidationError))
+
+		nested := map[string]interface{}{"leaf": 1}
+		for i := 0; i < 10; i++ {
+			nested = map[string]interface{}{"n": nested}
+		}
+
+		_, err := kc.CreateProfile(context.TODO(), &profile.NewProfile{
+			Attributes: profile.NewAttributes{Email: "nested@example.com", Properties: nested},
+		})
+
+		var e *klaviyo.ErrInvalidPropertyValue
+		require.ErrorAs(t, err, &e)
+	})
+
+	t.Run("request is sent in warning mode", func(t *testing.T) {
+		var sent bool
+		c := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			sent = true
+			return jsonResponse(http.StatusOK, `{"data":{"type":"profile","id":"01GVCF3FZ5W7YCE0MW4ZCBVWXM","attributes":{}}}`), nil
+		})}
+
+		kc := klaviyo.NewWithClient(validAPIKey, zap.L(), c)
+
+		_, err := kc.UpdateProfile(context.TODO(), "01GVCF3FZ5W7YCE0MW4ZCBVWXM",
+			profile.WithProperties(property.WithValue("born", time.Now())),
+		)
+
+		require.NoError(t, err)
+		require.True(t, sent)
+	})
+}
+
+func TestWithPropertyValidation_Limits(t *testing.T) {
+	noRequests := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("request must not be sent")
+		return nil, nil
+	})}
+
+	many := make(map[string]interface{}, 401)
+	for i := 0; i < 401; i++ {
+		many[fmt.Sprintf("p%d", i)] = i
+	}
+
+	tests := []struct {
+		name       string
+		properties map[string]interface{}
+		want       klaviyo.ErrPropertyLimitExceeded
+	}{
+		{
+			name:       "too many properties",
+			properties: many,
+			want:       klaviyo.ErrPropertyLimitExceeded{Limit: klaviyo.PropertyLimitCount, Size: 401, Max: 400},
+		},
+		{
+			name:       "key too long",
+			properties: map[string]interface{}{strings.Repeat("k", 256): 1},
+			want:       klaviyo.ErrPropertyLimitExceeded{Key: strings.Repeat("k", 256), Limit: klaviyo.PropertyLimitKeyLength, Size: 256, Max: 255},
+		},
+		{
+			name:       "nested value too long",
+			properties: map[string]interface{}{"notes": []interface{}{"ok", strings.Repeat("é", 100001)}},
+			want:       klaviyo.ErrPropertyLimitExceeded{Key: "notes[1]", Limit: klaviyo.PropertyLimitValueLength, Size: 100001, Max: 100000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kc := klaviyo.NewWithClient(validAPIKey, zap.L(), noRequests, klaviyo.WithPropertyValidation(klaviyo.PropertyValidationError))
+
+			_, err := kc.CreateProfile(context.TODO(), &profile.NewProfile{
+				Attributes: profile.NewAttributes{Email: "limits@example.com", Properties: tt.properties},
+			})
+
+			var e *klaviyo.ErrPropertyLimitExceeded
+			require.ErrorAs(t, err, &e)
+			require.Equal(t, tt.want, *e)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package klaviyo
+
+import (
+	"net/http"
+)
+
+// Version is the version of the client.
+const Version = "v0.1.0"
+
+// Endpoint is an endpoint of the Klaviyo API called by an operation of the client.
+type Endpoint struct {
+	Operation Operation
+	Method    string
+	// Path is the path of the endpoint relative to the API host, with path parameters in braces, e.g. "profiles/{id}".
+	Path  string
+	Scope Scope
+}
+
+// Compatibility describes the Klaviyo API the client is built and tested against.
+type Compatibility struct {
+	// Version is the version of the client.
+	Version string
+	// Revision is the API revision sent with every request.
+	Revision string
+	// Endpoints are the endpoints called by the client, sorted by operation.
+	Endpoints []Endpoint
+}
+
+// endpoints maps the operations to the methods and paths of their endpoints.
+var endpoints = map[Operation]struct{ method, path string }{
+	OperationGetAccounts:            {http.MethodGet, accountsPath},
+	OperationGetBulkImportJobs:      {http.MethodGet, bulkImportJobsPath},
+	OperationGetBulkImportJob:       {http.MethodGet, bulkImportJobsPath + "/{id}"},
+	OperationCreateBulkImportJob:    {http.MethodPost, bulkImportJobsPath},
+	OperationGetCampaign:            {http.MethodGet, campaignsPath + "/{id}"},
+	OperationCreateCampaign:         {http.MethodPost, campaignsPath},
+	OperationGetCampaignSendJob:     {http.MethodGet, campaignSendJobsPath + "/{id}"},
+	OperationUpdateCampaignSendJob:  {http.MethodPatch, campaignSendJobsPath + "/{id}"},
+	OperationGetEvents:              {http.MethodGet, eventsPath},
+	OperationGetEvent:               {http.MethodGet, eventsPath + "/{id}"},
+	OperationCreateEvent:            {http.MethodPost, eventsPath},
+	OperationGetFlows:               {http.MethodGet, flowsPath},
+	OperationGetFlow:                {http.MethodGet, flowsPath + "/{id}"},
+	OperationGetFlowActions:         {http.MethodGet, flowsPath + "/{id}/" + flowActionsPath},
+	OperationGetMetrics:             {http.MethodGet, metricsPath},
+	OperationGetMetric:              {http.MethodGet, metricsPath + "/{id}"},
+	OperationGetLists:               {http.MethodGet, listsPath},
+	OperationGetList:                {http.MethodGet, listsPath + "/{id}"},
+	OperationGetListProfiles:        {http.MethodGet, listsPath + "/{id}/" + membersPath},
+	OperationRemoveProfilesFromList: {http.MethodDelete, listsPath + "/{id}/relationships/" + membersPath},
+	OperationGetProfiles:            {http.MethodGet, profilesPath},
+	OperationGetProfile:             {http.MethodGet, profilesPath + "/{id}"},
+	OperationGetProfileLists:        {http.MethodGet, profilesPath + "/{id}/" + listsPath},
+	OperationGetProfileSegments:     {http.MethodGet, profilesPath + "/{id}/" + segmentsPath},
+	OperationCreateProfile:          {http.MethodPost, profilesPath},
+	OperationUpdateProfile:          {http.MethodPatch, profilesPath + "/{id}"},
+	OperationCreateOrUpdateProfile:  {http.MethodPost, profileImportPath},
+	OperationGetSegments:            {http.MethodGet, segmentsPath},
+	OperationGetSegment:             {http.MethodGet, segmentsPath + "/{id}"},
+	OperationGetSegmentProfiles:     {http.MethodGet, segmentsPath + "/{id}/" + membersPath},
+	OperationSuppressProfiles:       {http.MethodPost, suppressionCreateJobsPath},
+	OperationUnsuppressProfiles:     {http.MethodPost, suppressionDeleteJobsPath},
+	OperationSubscribeProfiles:      {http.MethodPost, subscriptionCreateJobsPath},
+	OperationUnsubscribeProfiles:    {http.MethodPost, subscriptionDeleteJobsPath},
+}
+
+// APICompatibility returns the version of the client, the API revision it is tested against and the endpoints
+// it calls, e.g. for inventory tooling tracking which services run which client and revision.
+func APICompatibility() Compatibility {
+	ops := Operations()
+	eps := make([]Endpoint, 0, len(ops))
+	for _, op := range ops {
+		e := endpoints[op]
+		eps = append(eps, Endpoint{Operation: op, Method: e.method, Path: e.path, Scope: RequiredScope(op)})
+	}
+	return Compatibility{Version: Version, Revision: revision, Endpoints: eps}
+}
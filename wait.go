@@ -0,0 +1,145 @@
+package klaviyo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/monetha/go-klaviyo/clock"
+)
+
+// ErrWaitInterrupted is returned by the Wait helpers, e.g. WaitForCampaignSendJob, when the context is done before
+// the awaited state is reached. The helpers return the last observed state together with it, so that the caller
+// can persist the progress and resume waiting later instead of losing it.
+type ErrWaitInterrupted struct {
+	// Polls is the number of successful polls before the wait was interrupted.
+	Polls int
+	// Err is the error of the context, possibly wrapped by the error of the interrupted poll.
+	Err error
+}
+
+// Error returns a string representation of the ErrWaitInterrupted error.
+// It conforms to the error interface.
+func (e *ErrWaitInterrupted) Error() string {
+	return fmt.Sprintf("klaviyo: wait interrupted after %d polls: %v", e.Polls, e.Err)
+}
+
+// Unwrap returns the error that interrupted the wait for Go's errors.Is() and errors.As() functions.
+func (e *ErrWaitInterrupted) Unwrap() error {
+	return e.Err
+}
+
+const (
+	// defaultMaxWaitInterval caps the growing poll interval of the Wait helpers, unless set with WithMaxWaitInterval.
+	defaultMaxWaitInterval = time.Minute
+	// minWaitInterval is the minimum poll interval of the Wait helpers, so that a non-positive interval
+	// doesn't poll the API in a busy loop.
+	minWaitInterval = time.Second
+)
+
+// WaitProgress is the progress of a Wait helper reported to the callback set with WithWaitProgress.
+type WaitProgress struct {
+	// Polls is the number of successful polls so far.
+	Polls int
+	// Elapsed is the time since the wait started.
+	Elapsed time.Duration
+	// NextPoll is the time until the next poll, or zero if the awaited state is reached.
+	NextPoll time.Duration
+	// Completed, Failed and Total are the numbers of the completed, failed and all the items of the polled job,
+	// e.g. the profiles of a bulk import job. They are zero for jobs that don't report them, e.g. campaign send jobs.
+	Completed, Failed, Total int
+}
+
+// WaitOption configures a Wait helper, e.g. WaitForCampaignSendJob.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	maxInterval time.Duration
+	progress    func(interface{}, WaitProgress)
+	counts      func(interface{}) (completed, failed, total int)
+}
+
+// WithMaxWaitInterval caps the poll interval of a Wait helper, which doubles after every poll starting
+// from the interval passed to the helper. The default cap is one minute; a cap not greater than the initial
+// interval makes the helper poll at a fixed interval. Intervals shorter than a second are raised to a second.
+func WithMaxWaitInterval(max time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.maxInterval = max
+	}
+}
+
+// WithWaitProgress sets a callback called by a Wait helper with the result of every successful poll,
+// e.g. a *campaign.SendJob for WaitForCampaignSendJob, and the progress of the wait. Callbacks whose result type
+// doesn't match the helper are not called.
+func WithWaitProgress[T any](fn func(T, WaitProgress)) WaitOption {
+	return func(o *waitOptions) {
+		o.progress = func(v interface{}, p WaitProgress) {
+			if t, ok := v.(T); ok {
+				fn(t, p)
+			}
+		}
+	}
+}
+
+// withWaitCounts sets the function returning the counts of the items of the polled job, reported by WaitProgress.
+func withWaitCounts(fn func(interface{}) (completed, failed, total int)) WaitOption {
+	return func(o *waitOptions) {
+		o.counts = fn
+	}
+}
+
+// poll calls get until done reports true for its result, and returns the result. The first wait between the polls
+// is interval, raised to minWaitInterval if shorter; it doubles after every poll up to the maximum interval
+// of the options. If get fails, the last observed
+// result is returned with the error; if the context is done, the error is an *ErrWaitInterrupted.
+func poll[T any](ctx context.Context, clk clock.Clock, interval time.Duration, opts []WaitOption, get func(context.Context) (T, error), done func(T) bool) (T, error) {
+	o := waitOptions{maxInterval: defaultMaxWaitInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if interval < minWaitInterval {
+		interval = minWaitInterval
+	}
+	if o.maxInterval < minWaitInterval {
+		o.maxInterval = minWaitInterval
+	}
+
+	var (
+		last  T
+		polls int
+		start = clk.Now()
+	)
+	for {
+		v, err := get(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, &ErrWaitInterrupted{Polls: polls, Err: err}
+			}
+			return last, err
+		}
+		last, polls = v, polls+1
+		finished := done(v)
+		if o.progress != nil {
+			p := WaitProgress{Polls: polls, Elapsed: clk.Now().Sub(start)}
+			if !finished {
+				p.NextPoll = interval
+			}
+			if o.counts != nil {
+				p.Completed, p.Failed, p.Total = o.counts(v)
+			}
+			o.progress(v, p)
+		}
+		if finished {
+			return v, nil
+		}
+		if err := clk.Sleep(ctx, interval); err != nil {
+			return last, &ErrWaitInterrupted{Polls: polls, Err: err}
+		}
+		if interval < o.maxInterval {
+			interval *= 2
+			if interval > o.maxInterval {
+				interval = o.maxInterval
+			}
+		}
+	}
+}